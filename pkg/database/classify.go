@@ -0,0 +1,154 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+)
+
+type ErrorKind int
+
+const (
+	ErrorKindUnknown ErrorKind = iota
+	ErrorKindSerializationFailure
+	ErrorKindDeadlock
+	ErrorKindUniqueViolation
+	ErrorKindForeignKeyViolation
+	ErrorKindNotNullViolation
+	ErrorKindCheckViolation
+	ErrorKindConnection
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindUnknown:
+		return "unknown"
+	case ErrorKindSerializationFailure:
+		return "serialization_failure"
+	case ErrorKindDeadlock:
+		return "deadlock"
+	case ErrorKindUniqueViolation:
+		return "unique_violation"
+	case ErrorKindForeignKeyViolation:
+		return "foreign_key_violation"
+	case ErrorKindNotNullViolation:
+		return "not_null_violation"
+	case ErrorKindCheckViolation:
+		return "check_violation"
+	case ErrorKindConnection:
+		return "connection"
+	default:
+		return "ErrorKind(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// ErrorInfo is the driver-independent view of a database error. Constraint
+// is empty when the driver does not report it (e.g. not-null violations).
+type ErrorInfo struct {
+	Kind       ErrorKind
+	Constraint string
+}
+
+// ErrorClassifier inspects err (and anything it wraps) and reports false when
+// it does not recognise the error. Driver packages register one in init.
+type ErrorClassifier func(err error) (ErrorInfo, bool)
+
+var (
+	classifiersMu sync.RWMutex
+	classifiers   []ErrorClassifier
+)
+
+func RegisterErrorClassifier(classifier ErrorClassifier) {
+	if classifier == nil {
+		return
+	}
+	classifiersMu.Lock()
+	defer classifiersMu.Unlock()
+	classifiers = append(classifiers, classifier)
+}
+
+func ClassifyError(err error) ErrorInfo {
+	if err == nil {
+		return ErrorInfo{}
+	}
+
+	classifiersMu.RLock()
+	registered := classifiers
+	classifiersMu.RUnlock()
+
+	for _, classifier := range registered {
+		if info, ok := classifier(err); ok {
+			return info
+		}
+	}
+
+	if isGenericConnectionError(err) {
+		return ErrorInfo{Kind: ErrorKindConnection}
+	}
+	return ErrorInfo{}
+}
+
+func Classify(err error) ErrorKind {
+	return ClassifyError(err).Kind
+}
+
+func ConstraintName(err error) string {
+	return ClassifyError(err).Constraint
+}
+
+func IsUniqueViolation(err error) bool {
+	return Classify(err) == ErrorKindUniqueViolation
+}
+
+func IsForeignKeyViolation(err error) bool {
+	return Classify(err) == ErrorKindForeignKeyViolation
+}
+
+func IsNotNullViolation(err error) bool {
+	return Classify(err) == ErrorKindNotNullViolation
+}
+
+func IsCheckViolation(err error) bool {
+	return Classify(err) == ErrorKindCheckViolation
+}
+
+func IsSerializationFailure(err error) bool {
+	return Classify(err) == ErrorKindSerializationFailure
+}
+
+func IsDeadlock(err error) bool {
+	return Classify(err) == ErrorKindDeadlock
+}
+
+func IsConnectionError(err error) bool {
+	return Classify(err) == ErrorKindConnection
+}
+
+func IsRetryable(err error) bool {
+	switch Classify(err) {
+	case ErrorKindSerializationFailure, ErrorKindDeadlock:
+		return true
+	default:
+		return false
+	}
+}
+
+func isGenericConnectionError(err error) bool {
+	// context errors satisfy net.Error but mean the caller gave up, not that
+	// the connection was lost.
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
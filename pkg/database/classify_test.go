@@ -0,0 +1,75 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/stretchr/testify/require"
+)
+
+var errClassifierProbe = errors.New("classifier probe")
+
+func init() {
+	database.RegisterErrorClassifier(func(err error) (database.ErrorInfo, bool) {
+		if errors.Is(err, errClassifierProbe) {
+			return database.ErrorInfo{Kind: database.ErrorKindUniqueViolation, Constraint: "users_email_key"}, true
+		}
+		return database.ErrorInfo{}, false
+	})
+}
+
+func TestClassify_UsesRegisteredClassifiers(t *testing.T) {
+	// Simula o encadeamento feito pela camada de instrumentação/repositório.
+	wrapped := fmt.Errorf("repository: insert user: %w", errClassifierProbe)
+
+	require.Equal(t, database.ErrorKindUniqueViolation, database.Classify(wrapped))
+	require.True(t, database.IsUniqueViolation(wrapped))
+	require.Equal(t, "users_email_key", database.ConstraintName(wrapped))
+	require.False(t, database.IsForeignKeyViolation(wrapped))
+	require.False(t, database.IsRetryable(wrapped))
+}
+
+func TestClassify_NilAndUnknownErrors(t *testing.T) {
+	require.Equal(t, database.ErrorKindUnknown, database.Classify(nil))
+	require.Equal(t, database.ErrorKindUnknown, database.Classify(errors.New("other")))
+	require.Empty(t, database.ConstraintName(errors.New("other")))
+	require.False(t, database.IsRetryable(errors.New("other")))
+}
+
+func TestClassify_GenericConnectionErrors(t *testing.T) {
+	cases := []error{
+		driver.ErrBadConn,
+		fmt.Errorf("query: %w", sql.ErrConnDone),
+		&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+	}
+	for _, err := range cases {
+		require.True(t, database.IsConnectionError(err), err.Error())
+	}
+}
+
+func TestClassify_ContextErrorsAreNotConnectionErrors(t *testing.T) {
+	cases := []error{
+		context.DeadlineExceeded,
+		context.Canceled,
+		fmt.Errorf("query: %w", context.DeadlineExceeded),
+		&net.OpError{Op: "read", Net: "tcp", Err: context.DeadlineExceeded},
+	}
+	for _, err := range cases {
+		require.False(t, database.IsConnectionError(err), err.Error())
+		require.Equal(t, database.ErrorKindUnknown, database.Classify(err), err.Error())
+	}
+}
+
+func TestErrorKind_String(t *testing.T) {
+	require.Equal(t, "serialization_failure", database.ErrorKindSerializationFailure.String())
+	require.Equal(t, "deadlock", database.ErrorKindDeadlock.String())
+	require.Equal(t, "unique_violation", database.ErrorKindUniqueViolation.String())
+	require.Equal(t, "connection", database.ErrorKindConnection.String())
+	require.Equal(t, "ErrorKind(42)", database.ErrorKind(42).String())
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
 )
 
 const (
@@ -35,6 +37,10 @@ type CockroachConfig struct {
 	SSLMode    string
 	SearchPath string
 
+	// Credentials, when set, is consulted for every new connection and its
+	// non-empty fields override User and Password.
+	Credentials database.CredentialsProvider
+
 	MaxOpenConns int
 	MaxIdleConns int
 	ConnMaxLife  time.Duration
@@ -61,7 +67,7 @@ func (c CockroachConfig) Validate() error {
 	if c.Host == "" {
 		errs = append(errs, errors.New("cockroach: host is required"))
 	}
-	if c.User == "" {
+	if c.User == "" && c.Credentials == nil {
 		errs = append(errs, errors.New("cockroach: user is required"))
 	}
 	if c.Database == "" {
@@ -4,11 +4,59 @@ import "context"
 
 type txContextKey struct{}
 
+type ownedTx struct {
+	tx    DBTX
+	owner any
+}
+
 func WithTx(ctx context.Context, tx DBTX) context.Context {
 	return context.WithValue(ctx, txContextKey{}, tx)
 }
 
+// WithTxOwner is WithTx for a transaction begun by owner, usually the
+// manager, so code that joins transactions from ctx can tell whether it
+// belongs to the same database.
+func WithTxOwner(ctx context.Context, tx DBTX, owner any) context.Context {
+	return context.WithValue(ctx, txContextKey{}, ownedTx{tx: tx, owner: owner})
+}
+
 func FromContext(ctx context.Context) (DBTX, bool) {
-	tx, ok := ctx.Value(txContextKey{}).(DBTX)
-	return tx, ok
+	switch v := ctx.Value(txContextKey{}).(type) {
+	case ownedTx:
+		return v.tx, true
+	case DBTX:
+		return v, true
+	}
+	return nil, false
+}
+
+// TxOwner returns the owner given to WithTxOwner for the transaction in ctx.
+// It reports false when ctx has no transaction or it was stored by WithTx.
+func TxOwner(ctx context.Context) (any, bool) {
+	v, ok := ctx.Value(txContextKey{}).(ownedTx)
+	return v.owner, ok
+}
+
+type readOnlyContextKey struct{}
+
+func WithReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyContextKey{}, true)
+}
+
+func IsReadOnly(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyContextKey{}).(bool)
+	return readOnly
+}
+
+type tenantContextKey struct{}
+
+// WithTenant marks ctx as acting for tenant: query spans carry it and the
+// tenant package routes statements to the tenant's schema.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantContextKey{}).(string)
+	return tenant, ok && tenant != ""
 }
@@ -52,6 +52,22 @@ func TestWithTx_OverwritesPreviousValue(t *testing.T) {
 	require.Equal(t, tx2, got)
 }
 
+func TestWithTxOwner_RecordsOwner(t *testing.T) {
+	tx := &stubDBTX{}
+	owner := &struct{ name string }{"primary"}
+
+	ctx := database.WithTxOwner(context.Background(), tx, owner)
+	got, ok := database.FromContext(ctx)
+	require.True(t, ok)
+	require.Same(t, tx, got)
+	gotOwner, ok := database.TxOwner(ctx)
+	require.True(t, ok)
+	require.Same(t, owner, gotOwner)
+
+	_, ok = database.TxOwner(database.WithTx(ctx, &stubDBTX{}))
+	require.False(t, ok, "WithTx substitui a tx e descarta o dono anterior")
+}
+
 func TestFromContext_NilContext_ReturnsFalse(t *testing.T) {
 	tests := []struct {
 		name string
@@ -82,3 +98,19 @@ func TestFromContext_NilContext_ReturnsFalse(t *testing.T) {
 		})
 	}
 }
+
+func TestWithReadOnly_IsReadOnly(t *testing.T) {
+	require.False(t, database.IsReadOnly(context.Background()))
+	require.True(t, database.IsReadOnly(database.WithReadOnly(context.Background())))
+}
+
+func TestWithTenant_RoundTrip(t *testing.T) {
+	tenant, ok := database.TenantFromContext(database.WithTenant(context.Background(), "acme"))
+	require.True(t, ok)
+	require.Equal(t, "acme", tenant)
+
+	_, ok = database.TenantFromContext(database.WithTenant(context.Background(), ""))
+	require.False(t, ok, "tenant vazio equivale a nenhum tenant")
+	_, ok = database.TenantFromContext(context.Background())
+	require.False(t, ok)
+}
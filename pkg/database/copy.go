@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrCopyUnsupported is returned by CopyFrom when the connection does not
+// implement Copier.
+var ErrCopyUnsupported = errors.New("database: bulk copy not supported")
+
+// CopySource yields the rows written by CopyFrom. Values returns the current
+// row in the same order as the column list. The shape matches
+// pgx.CopyFromSource so sources can be passed to pgx unchanged.
+type CopySource interface {
+	Next() bool
+	Values() ([]any, error)
+	Err() error
+}
+
+// Copier is implemented by connections and transactions that support bulk
+// loading: COPY on postgres/cockroach, bulk copy on SQL Server and batched
+// multi-row INSERT on MySQL and SQLite.
+type Copier interface {
+	CopyFrom(ctx context.Context, table string, columns []string, src CopySource) (int64, error)
+}
+
+// CopyFrom bulk loads src into table and returns the number of rows written.
+// db is usually mgr.DBTX(ctx), so the load joins the transaction started by
+// uow.Do when there is one. Outside a transaction, drivers that load in
+// batches commit each batch independently.
+func CopyFrom(ctx context.Context, db DBTX, table string, columns []string, src CopySource) (int64, error) {
+	if closer, ok := src.(interface{ close() }); ok {
+		defer closer.close()
+	}
+	if table == "" || len(columns) == 0 {
+		return 0, fmt.Errorf("%w: copy requires a table and at least one column", ErrInvalidConfig)
+	}
+	copier, ok := db.(Copier)
+	if !ok {
+		return 0, fmt.Errorf("%w: %T", ErrCopyUnsupported, db)
+	}
+	return copier.CopyFrom(ctx, table, columns, src)
+}
+
+// CopyFromRows returns a CopySource over rows already held in memory.
+func CopyFromRows(rows [][]any) CopySource {
+	return &rowsSource{rows: rows, idx: -1}
+}
+
+type rowsSource struct {
+	rows [][]any
+	idx  int
+}
+
+func (s *rowsSource) Next() bool {
+	s.idx++
+	return s.idx < len(s.rows)
+}
+
+func (s *rowsSource) Values() ([]any, error) { return s.rows[s.idx], nil }
+func (s *rowsSource) Err() error             { return nil }
+
+// CopyFromSeq returns a CopySource that pulls rows from seq. A non-nil error
+// from seq stops the copy and is reported through Err. Sources passed to
+// CopyFrom are released when it returns, even if seq was not exhausted.
+func CopyFromSeq(seq iter.Seq2[[]any, error]) CopySource {
+	next, stop := iter.Pull2(seq)
+	return &seqSource{next: next, stop: stop}
+}
+
+type seqSource struct {
+	next    func() ([]any, error, bool)
+	stop    func()
+	current []any
+	err     error
+}
+
+func (s *seqSource) Next() bool {
+	if s.err != nil {
+		return false
+	}
+	values, err, ok := s.next()
+	if !ok {
+		return false
+	}
+	if err != nil {
+		s.err = err
+		s.stop()
+		return false
+	}
+	s.current = values
+	return true
+}
+
+func (s *seqSource) Values() ([]any, error) { return s.current, nil }
+func (s *seqSource) Err() error             { return s.err }
+func (s *seqSource) close()                 { s.stop() }
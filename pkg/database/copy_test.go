@@ -0,0 +1,105 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/mocks"
+)
+
+// collectCopier lê a fonte inteira, como faria um driver.
+type collectCopier struct {
+	mocks.MockDBTX
+	rows [][]any
+}
+
+func (c *collectCopier) CopyFrom(_ context.Context, _ string, _ []string, src database.CopySource) (int64, error) {
+	for src.Next() {
+		values, err := src.Values()
+		if err != nil {
+			return int64(len(c.rows)), err
+		}
+		c.rows = append(c.rows, values)
+	}
+	return int64(len(c.rows)), src.Err()
+}
+
+func TestCopyFrom_Rows(t *testing.T) {
+	db := &collectCopier{}
+	rows := [][]any{{1, "a"}, {2, "b"}}
+
+	n, err := database.CopyFrom(context.Background(), db, "users", []string{"id", "name"}, database.CopyFromRows(rows))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+	require.Equal(t, rows, db.rows)
+}
+
+func TestCopyFrom_Seq(t *testing.T) {
+	db := &collectCopier{}
+	seq := func(yield func([]any, error) bool) {
+		for i := range 3 {
+			if !yield([]any{i}, nil) {
+				return
+			}
+		}
+	}
+
+	n, err := database.CopyFrom(context.Background(), db, "users", []string{"id"}, database.CopyFromSeq(seq))
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+	require.Equal(t, [][]any{{0}, {1}, {2}}, db.rows)
+}
+
+func TestCopyFrom_SeqErrorStopsCopy(t *testing.T) {
+	db := &collectCopier{}
+	boom := errors.New("boom")
+	seq := func(yield func([]any, error) bool) {
+		if !yield([]any{1}, nil) {
+			return
+		}
+		yield(nil, boom)
+	}
+
+	n, err := database.CopyFrom(context.Background(), db, "users", []string{"id"}, database.CopyFromSeq(seq))
+	require.ErrorIs(t, err, boom)
+	require.Equal(t, int64(1), n)
+}
+
+// firstRowCopier lê uma única linha e falha.
+type firstRowCopier struct{ mocks.MockDBTX }
+
+func (*firstRowCopier) CopyFrom(_ context.Context, _ string, _ []string, src database.CopySource) (int64, error) {
+	src.Next()
+	return 0, errors.New("copy failed")
+}
+
+func TestCopyFrom_SeqReleasedWhenNotDrained(t *testing.T) {
+	// O copier abandona a fonte no meio; o iterador deve ser encerrado.
+	released := false
+	seq := func(yield func([]any, error) bool) {
+		defer func() { released = true }()
+		for i := range 10 {
+			if !yield([]any{i}, nil) {
+				return
+			}
+		}
+	}
+
+	_, err := database.CopyFrom(context.Background(), &firstRowCopier{}, "users", []string{"id"}, database.CopyFromSeq(seq))
+	require.Error(t, err)
+	require.True(t, released)
+}
+
+func TestCopyFrom_RequiresTableAndColumns(t *testing.T) {
+	src := database.CopyFromRows(nil)
+
+	_, err := database.CopyFrom(context.Background(), &collectCopier{}, "", []string{"id"}, src)
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+
+	_, err = database.CopyFrom(context.Background(), &collectCopier{}, "users", nil, src)
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+}
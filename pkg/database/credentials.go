@@ -0,0 +1,122 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials authenticate a physical connection. Empty fields keep the
+// value from the driver config.
+type Credentials struct {
+	User     string
+	Password string
+}
+
+// CredentialsProvider is consulted every time the pool opens a physical
+// connection, so credentials rotated by an external agent reach new
+// connections without rebuilding the manager. Existing connections keep
+// theirs until they are recycled by ConnMaxLife.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// CredentialsFunc adapts a function to CredentialsProvider.
+type CredentialsFunc func(ctx context.Context) (Credentials, error)
+
+func (f CredentialsFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// FileCredentials reads the password, and optionally the user, from secret
+// files such as Kubernetes secret mounts or files rendered by a Vault agent.
+// A file is read again whenever its modification time or size changes, so a
+// rotated secret is picked up on the next connection.
+type FileCredentials struct {
+	user     *secretFile
+	password *secretFile
+}
+
+var _ CredentialsProvider = (*FileCredentials)(nil)
+
+// NewFileCredentials reads passwordFile and, when not empty, userFile. It
+// fails if either cannot be read.
+func NewFileCredentials(userFile, passwordFile string) (*FileCredentials, error) {
+	if passwordFile == "" {
+		return nil, fmt.Errorf("%w: password file is required", ErrInvalidConfig)
+	}
+	p := &FileCredentials{password: &secretFile{path: passwordFile}}
+	if userFile != "" {
+		p.user = &secretFile{path: userFile}
+	}
+	if _, err := p.Credentials(context.Background()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *FileCredentials) Credentials(context.Context) (Credentials, error) {
+	password, err := p.password.read()
+	if err != nil {
+		return Credentials{}, err
+	}
+	creds := Credentials{Password: password}
+	if p.user != nil {
+		if creds.User, err = p.user.read(); err != nil {
+			return Credentials{}, err
+		}
+	}
+	return creds, nil
+}
+
+// ReadSecretFile returns the content of path without surrounding whitespace.
+func ReadSecretFile(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%w: read secret file %q: %w", ErrInvalidConfig, path, err)
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
+
+// secretFile caches the content of a file until it changes on disk. A
+// failed read after a successful one keeps serving the cached value, since
+// secret mounts are briefly missing while they are swapped.
+type secretFile struct {
+	path string
+
+	mu      sync.Mutex
+	value   string
+	loaded  bool
+	modTime time.Time
+	size    int64
+}
+
+func (f *secretFile) read() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		if f.loaded {
+			return f.value, nil
+		}
+		return "", fmt.Errorf("%w: read secret file %q: %w", ErrInvalidConfig, f.path, err)
+	}
+	if f.loaded && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.value, nil
+	}
+
+	value, err := ReadSecretFile(f.path)
+	if err != nil {
+		if f.loaded {
+			return f.value, nil
+		}
+		return "", err
+	}
+	f.value, f.loaded = value, true
+	f.modTime, f.size = info.ModTime(), info.Size()
+	return value, nil
+}
@@ -0,0 +1,63 @@
+package database_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+// writeSecret grava value em path com um mtime distinto a cada chamada,
+// como faz a troca de um secret montado.
+func writeSecret(t *testing.T, path, value string, modTime time.Time) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(value), 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestFileCredentials_PicksUpRotatedSecret(t *testing.T) {
+	dir := t.TempDir()
+	userFile := filepath.Join(dir, "username")
+	passwordFile := filepath.Join(dir, "password")
+	now := time.Now()
+	writeSecret(t, userFile, "app\n", now)
+	writeSecret(t, passwordFile, "first\n", now)
+
+	provider, err := database.NewFileCredentials(userFile, passwordFile)
+	require.NoError(t, err)
+	creds, err := provider.Credentials(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, database.Credentials{User: "app", Password: "first"}, creds, "espaços e quebras de linha são removidos")
+
+	writeSecret(t, passwordFile, "second", now.Add(time.Minute))
+	creds, err = provider.Credentials(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "second", creds.Password, "o arquivo alterado deve ser relido")
+
+	require.NoError(t, os.Remove(passwordFile))
+	creds, err = provider.Credentials(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "second", creds.Password, "durante a troca do secret o último valor continua válido")
+}
+
+func TestFileCredentials_RequiresReadableFile(t *testing.T) {
+	_, err := database.NewFileCredentials("", "")
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+
+	_, err = database.NewFileCredentials("", filepath.Join(t.TempDir(), "missing"))
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+}
+
+func TestCredentialsFunc(t *testing.T) {
+	var provider database.CredentialsProvider = database.CredentialsFunc(func(context.Context) (database.Credentials, error) {
+		return database.Credentials{Password: "token"}, nil
+	})
+	creds, err := provider.Credentials(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "token", creds.Password)
+}
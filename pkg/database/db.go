@@ -19,6 +19,9 @@ type Tx interface {
 type TxOptions struct {
 	Isolation IsolationLevel
 	ReadOnly  bool
+	// Session, when set, pins the transaction's connection and prepares it
+	// with settings that outlive the transaction.
+	Session Session
 }
 
 type Result interface {
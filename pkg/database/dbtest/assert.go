@@ -0,0 +1,138 @@
+package dbtest
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// AssertCommitted fails t unless exactly times transactions committed. Commits
+// failed through FailCommit do not count.
+func (m *Manager) AssertCommitted(t testing.TB, times int) {
+	t.Helper()
+	if got := m.count(EventCommit); got != times {
+		t.Errorf("dbtest: expected %d commit(s), got %d\n%s", times, got, m.dump())
+	}
+}
+
+func (m *Manager) AssertCommittedOnce(t testing.TB) {
+	t.Helper()
+	m.AssertCommitted(t, 1)
+}
+
+// AssertRolledBack fails t unless at least one transaction rolled back
+// successfully.
+func (m *Manager) AssertRolledBack(t testing.TB) {
+	t.Helper()
+	if m.count(EventRollback) == 0 {
+		t.Errorf("dbtest: expected a rollback\n%s", m.dump())
+	}
+}
+
+func (m *Manager) AssertNotRolledBack(t testing.TB) {
+	t.Helper()
+	if got := m.count(EventRollback); got != 0 {
+		t.Errorf("dbtest: expected no rollback, got %d\n%s", got, m.dump())
+	}
+}
+
+// AssertNoTransaction fails t if BeginTx was called.
+func (m *Manager) AssertNoTransaction(t testing.TB) {
+	t.Helper()
+	if got := m.count(EventBegin); got != 0 {
+		t.Errorf("dbtest: expected no transaction, got %d\n%s", got, m.dump())
+	}
+}
+
+// AssertExecuted fails t unless a statement matching pattern ran.
+func (m *Manager) AssertExecuted(t testing.TB, pattern string) {
+	t.Helper()
+	if len(m.matching(pattern)) == 0 {
+		t.Errorf("dbtest: expected a statement matching %q\n%s", pattern, m.dump())
+	}
+}
+
+func (m *Manager) AssertNotExecuted(t testing.TB, pattern string) {
+	t.Helper()
+	if len(m.matching(pattern)) != 0 {
+		t.Errorf("dbtest: expected no statement matching %q\n%s", pattern, m.dump())
+	}
+}
+
+// AssertExecutedInTx fails t unless every statement matching pattern ran
+// inside a transaction, and at least one did.
+func (m *Manager) AssertExecutedInTx(t testing.TB, pattern string) {
+	t.Helper()
+	matches := m.matching(pattern)
+	if len(matches) == 0 {
+		t.Errorf("dbtest: expected a statement matching %q\n%s", pattern, m.dump())
+		return
+	}
+	for _, e := range matches {
+		if e.TxID == 0 {
+			t.Errorf("dbtest: statement %q ran outside a transaction\n%s", e.Query, m.dump())
+			return
+		}
+	}
+}
+
+// AssertExecutedOutsideTx fails t unless every statement matching pattern ran
+// on the pool, and at least one did.
+func (m *Manager) AssertExecutedOutsideTx(t testing.TB, pattern string) {
+	t.Helper()
+	matches := m.matching(pattern)
+	if len(matches) == 0 {
+		t.Errorf("dbtest: expected a statement matching %q\n%s", pattern, m.dump())
+		return
+	}
+	for _, e := range matches {
+		if e.TxID != 0 {
+			t.Errorf("dbtest: statement %q ran inside transaction %d\n%s", e.Query, e.TxID, m.dump())
+			return
+		}
+	}
+}
+
+// AssertEvents fails t unless the recorded event kinds equal kinds, in order.
+func (m *Manager) AssertEvents(t testing.TB, kinds ...EventKind) {
+	t.Helper()
+	events := m.Events()
+	ok := len(events) == len(kinds)
+	for i := 0; ok && i < len(kinds); i++ {
+		ok = events[i].Kind == kinds[i]
+	}
+	if !ok {
+		t.Errorf("dbtest: expected events %v\n%s", kinds, m.dump())
+	}
+}
+
+func (m *Manager) count(kind EventKind) int {
+	n := 0
+	for _, e := range m.Events() {
+		if e.Kind == kind && e.Err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (m *Manager) matching(pattern string) []Event {
+	re := regexp.MustCompile(pattern)
+	var matches []Event
+	for _, e := range m.Statements() {
+		if re.MatchString(e.Query) {
+			matches = append(matches, e)
+		}
+	}
+	return matches
+}
+
+func (m *Manager) dump() string {
+	var b strings.Builder
+	b.WriteString("recorded events:")
+	for _, e := range m.Events() {
+		b.WriteString("\n  ")
+		b.WriteString(e.String())
+	}
+	return b.String()
+}
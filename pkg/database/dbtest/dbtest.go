@@ -0,0 +1,430 @@
+// Package dbtest provides an in-memory manager.Manager for unit tests. It
+// records every statement and transaction boundary, answers statements from
+// responses scripted by query pattern, and offers assertions over what ran.
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sync"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/locking"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/manager"
+)
+
+// ErrUnexpectedStatement is returned in strict mode for statements that no
+// scripted response matches.
+var ErrUnexpectedStatement = errors.New("dbtest: unexpected statement")
+
+var _ manager.Manager = (*Manager)(nil)
+
+type EventKind int
+
+const (
+	EventBegin EventKind = iota + 1
+	EventExec
+	EventQuery
+	EventQueryRow
+	EventCommit
+	EventRollback
+	// EventDiscard records a connection closed instead of pooled because
+	// its database.Session could not be entered or exited.
+	EventDiscard
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventBegin:
+		return "begin"
+	case EventExec:
+		return "exec"
+	case EventQuery:
+		return "query"
+	case EventQueryRow:
+		return "query_row"
+	case EventCommit:
+		return "commit"
+	case EventRollback:
+		return "rollback"
+	case EventDiscard:
+		return "discard"
+	default:
+		return fmt.Sprintf("EventKind(%d)", int(k))
+	}
+}
+
+// Event is one recorded call. TxID is 0 for statements run on the pool and
+// identifies the transaction (1, 2, …, in BeginTx order) otherwise. Err holds
+// the failure injected into Commit or Rollback.
+type Event struct {
+	Kind   EventKind
+	TxID   int
+	Query  string
+	Args   []any
+	TxOpts database.TxOptions
+	Err    error
+}
+
+func (e Event) String() string {
+	scope := "pool"
+	if e.TxID != 0 {
+		scope = fmt.Sprintf("tx%d", e.TxID)
+	}
+	if e.Err != nil {
+		return fmt.Sprintf("[%s] %s failed: %v", scope, e.Kind, e.Err)
+	}
+	if !e.isStatement() {
+		return fmt.Sprintf("[%s] %s", scope, e.Kind)
+	}
+	return fmt.Sprintf("[%s] %s %s %v", scope, e.Kind, e.Query, e.Args)
+}
+
+func (e Event) isStatement() bool {
+	return e.Kind == EventExec || e.Kind == EventQuery || e.Kind == EventQueryRow
+}
+
+type Option func(*Manager)
+
+func WithDriver(driver database.Driver) Option {
+	return func(m *Manager) {
+		if driver != "" {
+			m.driver = driver
+		}
+	}
+}
+
+// WithStrict makes statements without a matching response fail with
+// ErrUnexpectedStatement instead of returning empty results.
+func WithStrict() Option {
+	return func(m *Manager) {
+		m.strict = true
+	}
+}
+
+type Manager struct {
+	mu          sync.Mutex
+	driver      database.Driver
+	strict      bool
+	responses   []*Response
+	events      []Event
+	txCount     int
+	beginErr    error
+	commitErr   error
+	rollbackErr error
+	pingErr     error
+	closed      bool
+	pool        *conn
+	locks       *locking.Locker
+}
+
+func New(opts ...Option) *Manager {
+	m := &Manager{driver: database.DriverPostgres}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.pool = &conn{m: m}
+	m.locks = locking.NewLocal()
+	return m
+}
+
+func (m *Manager) Driver() database.Driver { return m.driver }
+
+// DBTX returns the transaction carried by ctx, or the recording pool.
+func (m *Manager) DBTX(ctx context.Context) database.DBTX {
+	if tx, ok := database.FromContext(ctx); ok {
+		return tx
+	}
+	return m.pool
+}
+
+// BeginTx behaves like database/sql: the transaction is rolled back when ctx
+// ends. With opts.Session, Enter and Exit run on the pool scope around the
+// transaction, Exit only once Commit or Rollback is called.
+func (m *Manager) BeginTx(ctx context.Context, opts database.TxOptions) (database.Tx, error) {
+	if err := m.checkBegin(); err != nil {
+		return nil, err
+	}
+	if opts.Session != nil {
+		if err := opts.Session.Enter(ctx, m.pool); err != nil {
+			m.discard()
+			return nil, err
+		}
+	}
+
+	m.mu.Lock()
+	m.txCount++
+	m.events = append(m.events, Event{Kind: EventBegin, TxID: m.txCount, TxOpts: opts})
+	t := &tx{conn: conn{m: m, txID: m.txCount}, session: opts.Session}
+	m.mu.Unlock()
+
+	context.AfterFunc(ctx, func() { _ = t.finish(EventRollback) })
+	return t, nil
+}
+
+func (m *Manager) checkBegin() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return database.ErrManagerClosed
+	}
+	return m.beginErr
+}
+
+func (m *Manager) discard() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, Event{Kind: EventDiscard})
+}
+
+func (m *Manager) Ping(_ context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return database.ErrManagerClosed
+	}
+	return m.pingErr
+}
+
+// TryLock takes name in memory; locks only exclude callers of the same
+// Manager.
+func (m *Manager) TryLock(ctx context.Context, name string) (database.Lock, bool, error) {
+	locks, err := m.locker()
+	if err != nil {
+		return nil, false, err
+	}
+	return locks.TryLock(ctx, name)
+}
+
+// Lock waits for name in memory; locks only exclude callers of the same
+// Manager.
+func (m *Manager) Lock(ctx context.Context, name string) (database.Lock, error) {
+	locks, err := m.locker()
+	if err != nil {
+		return nil, err
+	}
+	return locks.Lock(ctx, name)
+}
+
+func (m *Manager) WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	return database.WithLock(ctx, m, name, fn)
+}
+
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.closed = true
+	locks := m.locks
+	m.mu.Unlock()
+	return locks.Close(ctx)
+}
+
+func (m *Manager) locker() (*locking.Locker, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return nil, database.ErrManagerClosed
+	}
+	return m.locks, nil
+}
+
+// On scripts the answer to every statement whose SQL matches pattern, a
+// regular expression (use regexp.QuoteMeta for literal SQL). Responses are
+// tried in registration order; one exhausted by Times is skipped.
+func (m *Manager) On(pattern string) *Response {
+	r := &Response{pattern: regexp.MustCompile(pattern)}
+	m.mu.Lock()
+	m.responses = append(m.responses, r)
+	m.mu.Unlock()
+	return r
+}
+
+// FailBegin makes every following BeginTx return err; nil restores success.
+func (m *Manager) FailBegin(err error) { m.setErr(&m.beginErr, err) }
+
+// FailCommit makes every following Commit return err; nil restores success.
+func (m *Manager) FailCommit(err error) { m.setErr(&m.commitErr, err) }
+
+// FailRollback makes every following Rollback return err; nil restores success.
+func (m *Manager) FailRollback(err error) { m.setErr(&m.rollbackErr, err) }
+
+// FailPing makes every following Ping return err; nil restores success.
+func (m *Manager) FailPing(err error) { m.setErr(&m.pingErr, err) }
+
+func (m *Manager) setErr(target *error, err error) {
+	m.mu.Lock()
+	*target = err
+	m.mu.Unlock()
+}
+
+// Events returns every recorded call in the order it happened.
+func (m *Manager) Events() []Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.events)
+}
+
+// Statements returns the recorded Exec, Query and QueryRow calls.
+func (m *Manager) Statements() []Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var statements []Event
+	for _, e := range m.events {
+		if e.isStatement() {
+			statements = append(statements, e)
+		}
+	}
+	return statements
+}
+
+// Reset forgets recorded events, scripted responses, injected failures and
+// held locks.
+func (m *Manager) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.responses = nil
+	m.events = nil
+	m.txCount = 0
+	m.beginErr, m.commitErr, m.rollbackErr, m.pingErr = nil, nil, nil, nil
+	m.closed = false
+	m.locks = locking.NewLocal()
+}
+
+// record stores the statement and returns the response that answers it.
+func (m *Manager) record(kind EventKind, txID int, query string, args []any) (*Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.events = append(m.events, Event{Kind: kind, TxID: txID, Query: query, Args: slices.Clone(args)})
+	for _, r := range m.responses {
+		if r.take(query) {
+			return r, nil
+		}
+	}
+	if m.strict {
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedStatement, query)
+	}
+	return &Response{}, nil
+}
+
+func (m *Manager) finish(kind EventKind, txID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.rollbackErr
+	if kind == EventCommit {
+		err = m.commitErr
+	}
+	m.events = append(m.events, Event{Kind: kind, TxID: txID, Err: err})
+	return err
+}
+
+type conn struct {
+	m    *Manager
+	txID int
+}
+
+func (c *conn) ExecContext(_ context.Context, query string, args ...any) (database.Result, error) {
+	r, err := c.m.record(EventExec, c.txID, query, args)
+	if err != nil {
+		return nil, err
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return result(r.rowsAffected), nil
+}
+
+func (c *conn) QueryContext(_ context.Context, query string, args ...any) (database.Rows, error) {
+	r, err := c.m.record(EventQuery, c.txID, query, args)
+	if err != nil {
+		return nil, err
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return newRows(r.columns, r.rows), nil
+}
+
+func (c *conn) QueryRowContext(_ context.Context, query string, args ...any) database.Row {
+	r, err := c.m.record(EventQueryRow, c.txID, query, args)
+	if err != nil {
+		return row{err: err}
+	}
+	if r.err != nil {
+		return row{err: r.err}
+	}
+	if len(r.rows) == 0 {
+		return row{err: sql.ErrNoRows}
+	}
+	return row{values: r.rows[0]}
+}
+
+type tx struct {
+	conn
+	session database.Session
+	mu      sync.Mutex
+	done    bool
+	exited  bool
+}
+
+func (t *tx) ExecContext(ctx context.Context, query string, args ...any) (database.Result, error) {
+	if t.isDone() {
+		return nil, sql.ErrTxDone
+	}
+	return t.conn.ExecContext(ctx, query, args...)
+}
+
+func (t *tx) QueryContext(ctx context.Context, query string, args ...any) (database.Rows, error) {
+	if t.isDone() {
+		return nil, sql.ErrTxDone
+	}
+	return t.conn.QueryContext(ctx, query, args...)
+}
+
+func (t *tx) QueryRowContext(ctx context.Context, query string, args ...any) database.Row {
+	if t.isDone() {
+		return row{err: sql.ErrTxDone}
+	}
+	return t.conn.QueryRowContext(ctx, query, args...)
+}
+
+func (t *tx) Commit(ctx context.Context) error   { return t.end(ctx, EventCommit) }
+func (t *tx) Rollback(ctx context.Context) error { return t.end(ctx, EventRollback) }
+
+func (t *tx) end(ctx context.Context, kind EventKind) error {
+	err := t.finish(kind)
+	t.exit(ctx)
+	return err
+}
+
+func (t *tx) finish(kind EventKind) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+	return t.m.finish(kind, t.txID)
+}
+
+func (t *tx) exit(ctx context.Context) {
+	t.mu.Lock()
+	if t.session == nil || t.exited {
+		t.mu.Unlock()
+		return
+	}
+	t.exited = true
+	t.mu.Unlock()
+	if err := t.session.Exit(context.WithoutCancel(ctx), t.m.pool); err != nil {
+		t.m.discard()
+	}
+}
+
+func (t *tx) isDone() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.done
+}
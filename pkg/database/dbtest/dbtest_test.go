@@ -0,0 +1,217 @@
+package dbtest_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/dbtest"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/uow"
+	"github.com/JailtonJunior94/devkit-go/pkg/nullable"
+)
+
+type user struct {
+	ID   int64
+	Name string
+	Bio  nullable.String
+}
+
+// createUser simula um caso de uso típico: lê e escreve dentro de uow.Do.
+func createUser(ctx context.Context, work uow.UnitOfWork[int64], name string) (int64, error) {
+	return work.Do(ctx, func(ctx context.Context, tx database.DBTX) (int64, error) {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM users WHERE name = $1)", name).Scan(&exists); err != nil {
+			return 0, err
+		}
+		if exists {
+			return 0, errors.New("duplicate")
+		}
+		var id int64
+		err := tx.QueryRowContext(ctx, "INSERT INTO users (name) VALUES ($1) RETURNING id", name).Scan(&id)
+		return id, err
+	})
+}
+
+func TestManager_UnitOfWorkCommit(t *testing.T) {
+	mgr := dbtest.New()
+	mgr.On(`SELECT EXISTS`).Rows([]string{"exists"}, []any{false})
+	mgr.On(`INSERT INTO users`).Rows([]string{"id"}, []any{int64(42)})
+
+	id, err := createUser(context.Background(), uow.New[int64](mgr), "ana")
+
+	require.NoError(t, err)
+	require.Equal(t, int64(42), id)
+	mgr.AssertCommittedOnce(t)
+	mgr.AssertNotRolledBack(t)
+	mgr.AssertExecutedInTx(t, `INSERT INTO users`)
+	mgr.AssertEvents(t, dbtest.EventBegin, dbtest.EventQueryRow, dbtest.EventQueryRow, dbtest.EventCommit)
+	require.Equal(t, []any{"ana"}, mgr.Statements()[1].Args)
+}
+
+func TestManager_UnitOfWorkRollback(t *testing.T) {
+	mgr := dbtest.New()
+	mgr.On(`SELECT EXISTS`).Rows([]string{"exists"}, []any{true})
+
+	_, err := createUser(context.Background(), uow.New[int64](mgr), "ana")
+
+	require.EqualError(t, err, "duplicate")
+	mgr.AssertCommitted(t, 0)
+	mgr.AssertRolledBack(t)
+	mgr.AssertNotExecuted(t, `INSERT`)
+}
+
+func TestManager_ScriptedErrorsAndTimes(t *testing.T) {
+	ctx := context.Background()
+	boom := errors.New("boom")
+	mgr := dbtest.New()
+	mgr.On(`^UPDATE`).Err(boom).Times(1)
+	mgr.On(`^UPDATE`).RowsAffected(3)
+
+	_, err := mgr.DBTX(ctx).ExecContext(ctx, "UPDATE users SET name = $1", "x")
+	require.ErrorIs(t, err, boom)
+
+	res, err := mgr.DBTX(ctx).ExecContext(ctx, "UPDATE users SET name = $1", "y")
+	require.NoError(t, err)
+	affected, _ := res.RowsAffected()
+	require.Equal(t, int64(3), affected)
+	mgr.AssertExecutedOutsideTx(t, `^UPDATE`)
+	mgr.AssertNoTransaction(t)
+}
+
+func TestManager_QueryRowsSupportStructScanning(t *testing.T) {
+	ctx := context.Background()
+	mgr := dbtest.New()
+	mgr.On(`FROM users`).Rows([]string{"id", "name", "bio"},
+		[]any{int32(1), []byte("ana"), "dev"},
+		[]any{int64(2), "bob", nil},
+	)
+
+	users, err := database.QueryAll[user](ctx, mgr.DBTX(ctx), "SELECT id, name, bio FROM users")
+
+	require.NoError(t, err)
+	require.Equal(t, []user{
+		{ID: 1, Name: "ana", Bio: nullable.StringOf("dev")},
+		{ID: 2, Name: "bob"},
+	}, users)
+}
+
+func TestManager_DefaultsAndStrictMode(t *testing.T) {
+	ctx := context.Background()
+
+	lenient := dbtest.New()
+	var name string
+	require.ErrorIs(t, lenient.DBTX(ctx).QueryRowContext(ctx, "SELECT name").Scan(&name), sql.ErrNoRows)
+	rows, err := lenient.DBTX(ctx).QueryContext(ctx, "SELECT name")
+	require.NoError(t, err)
+	require.False(t, rows.Next())
+
+	strict := dbtest.New(dbtest.WithStrict(), dbtest.WithDriver(database.DriverMySQL))
+	_, err = strict.DBTX(ctx).ExecContext(ctx, "DELETE FROM users")
+	require.ErrorIs(t, err, dbtest.ErrUnexpectedStatement)
+	require.Equal(t, database.DriverMySQL, strict.Driver())
+}
+
+func TestManager_InjectedTransactionFailures(t *testing.T) {
+	ctx := context.Background()
+	boom := errors.New("boom")
+	mgr := dbtest.New()
+	work := uow.New[struct{}](mgr)
+	noop := func(context.Context, database.DBTX) (struct{}, error) { return struct{}{}, nil }
+
+	mgr.FailBegin(boom)
+	_, err := work.Do(ctx, noop)
+	require.ErrorIs(t, err, boom)
+	mgr.AssertNoTransaction(t)
+
+	mgr.FailBegin(nil)
+	mgr.FailCommit(boom)
+	_, err = work.Do(ctx, noop)
+	require.ErrorIs(t, err, boom)
+	mgr.AssertEvents(t, dbtest.EventBegin, dbtest.EventCommit)
+	mgr.AssertCommitted(t, 0)
+}
+
+func TestManager_FinishedTxAndShutdown(t *testing.T) {
+	ctx := context.Background()
+	mgr := dbtest.New()
+
+	tx, err := mgr.BeginTx(ctx, database.TxOptions{ReadOnly: true})
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback(ctx))
+	_, err = tx.ExecContext(ctx, "SELECT 1")
+	require.ErrorIs(t, err, sql.ErrTxDone)
+	require.True(t, mgr.Events()[0].TxOpts.ReadOnly)
+
+	require.NoError(t, mgr.Shutdown(ctx))
+	require.ErrorIs(t, mgr.Ping(ctx), database.ErrManagerClosed)
+	_, err = mgr.BeginTx(ctx, database.TxOptions{})
+	require.ErrorIs(t, err, database.ErrManagerClosed)
+
+	mgr.Reset()
+	require.Empty(t, mgr.Events())
+	require.NoError(t, mgr.Ping(ctx))
+}
+
+// stmtSession aplica e desfaz um comando de sessão ao redor da transação.
+type stmtSession struct{ enter, exit string }
+
+func (s stmtSession) Enter(ctx context.Context, conn database.DBTX) error {
+	_, err := conn.ExecContext(ctx, s.enter)
+	return err
+}
+
+func (s stmtSession) Exit(ctx context.Context, conn database.DBTX) error {
+	_, err := conn.ExecContext(ctx, s.exit)
+	return err
+}
+
+func TestManager_SessionAndContextRollback(t *testing.T) {
+	mgr := dbtest.New()
+	mgr.On(`RESET`).Err(errors.New("connection lost"))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	tx, err := mgr.BeginTx(ctx, database.TxOptions{Session: stmtSession{enter: "SET app.x = 1", exit: "RESET app.x"}})
+	require.NoError(t, err)
+	cancel()
+	require.Eventually(t, func() bool {
+		_, err := tx.ExecContext(context.Background(), "SELECT 1")
+		return errors.Is(err, sql.ErrTxDone)
+	}, time.Second, time.Millisecond, "como no database/sql, o fim do ctx desfaz a transação")
+
+	require.ErrorIs(t, tx.Rollback(context.Background()), sql.ErrTxDone)
+	events := mgr.Events()
+	require.Equal(t, dbtest.EventDiscard, events[len(events)-1].Kind, "a conexão é descartada quando a sessão não é desfeita")
+	mgr.AssertExecutedOutsideTx(t, `SET app.x`)
+	mgr.AssertExecutedOutsideTx(t, `RESET app.x`)
+	mgr.AssertRolledBack(t)
+}
+
+func TestManager_LocksAreExclusiveUntilShutdown(t *testing.T) {
+	ctx := context.Background()
+	mgr := dbtest.New()
+
+	err := mgr.WithLock(ctx, "jobs", func(ctx context.Context) error {
+		_, ok, err := mgr.TryLock(ctx, "jobs")
+		require.NoError(t, err)
+		require.False(t, ok, "o lock está com o WithLock")
+		return nil
+	})
+	require.NoError(t, err)
+
+	lock, err := mgr.Lock(ctx, "jobs")
+	require.NoError(t, err)
+	require.NoError(t, mgr.Shutdown(ctx))
+	<-lock.Done()
+	_, _, err = mgr.TryLock(ctx, "jobs")
+	require.ErrorIs(t, err, database.ErrManagerClosed)
+
+	mgr.Reset()
+	_, ok, err := mgr.TryLock(ctx, "jobs")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
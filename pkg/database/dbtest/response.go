@@ -0,0 +1,58 @@
+package dbtest
+
+import (
+	"regexp"
+	"slices"
+)
+
+// Response is the scripted answer to statements matching a pattern. Without
+// any setter it answers with no rows and zero rows affected.
+type Response struct {
+	pattern      *regexp.Regexp
+	columns      []string
+	rows         [][]any
+	rowsAffected int64
+	err          error
+	remaining    int
+	limited      bool
+}
+
+// Rows sets the result set returned to Query and QueryRow (first row only).
+func (r *Response) Rows(columns []string, rows ...[]any) *Response {
+	r.columns = slices.Clone(columns)
+	r.rows = rows
+	return r
+}
+
+func (r *Response) RowsAffected(n int64) *Response {
+	r.rowsAffected = n
+	return r
+}
+
+// Err makes matching statements fail with err.
+func (r *Response) Err(err error) *Response {
+	r.err = err
+	return r
+}
+
+// Times limits the response to the next n matching statements.
+func (r *Response) Times(n int) *Response {
+	r.remaining = n
+	r.limited = true
+	return r
+}
+
+// take reports whether r answers query and consumes one use. Callers hold the
+// manager lock.
+func (r *Response) take(query string) bool {
+	if r.limited && r.remaining <= 0 {
+		return false
+	}
+	if !r.pattern.MatchString(query) {
+		return false
+	}
+	if r.limited {
+		r.remaining--
+	}
+	return true
+}
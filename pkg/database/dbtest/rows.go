@@ -0,0 +1,110 @@
+package dbtest
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+type result int64
+
+func (r result) RowsAffected() (int64, error) { return int64(r), nil }
+
+type rows struct {
+	columns []string
+	data    [][]any
+	pos     int
+	closed  bool
+}
+
+func newRows(columns []string, data [][]any) *rows {
+	return &rows{columns: columns, data: data}
+}
+
+func (r *rows) Next() bool {
+	if r.closed || r.pos >= len(r.data) {
+		return false
+	}
+	r.pos++
+	return true
+}
+
+func (r *rows) Scan(dest ...any) error {
+	if r.pos == 0 || r.closed {
+		return fmt.Errorf("dbtest: Scan called without calling Next")
+	}
+	return scanValues(r.data[r.pos-1], dest)
+}
+
+func (r *rows) Columns() ([]string, error) { return r.columns, nil }
+func (r *rows) Close() error               { r.closed = true; return nil }
+func (r *rows) Err() error                 { return nil }
+
+type row struct {
+	values []any
+	err    error
+}
+
+func (r row) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanValues(r.values, dest)
+}
+
+func scanValues(values []any, dest []any) error {
+	if len(dest) != len(values) {
+		return fmt.Errorf("dbtest: expected %d destination arguments in Scan, not %d", len(values), len(dest))
+	}
+	for i, d := range dest {
+		if err := assign(d, values[i]); err != nil {
+			return fmt.Errorf("dbtest: column %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// assign copies src into dest the way a driver would: scanners receive the
+// raw value, pointers to pointers model NULL, and numeric values convert
+// between widths.
+func assign(dest, src any) error {
+	if scanner, ok := dest.(sql.Scanner); ok {
+		return scanner.Scan(src)
+	}
+
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() {
+		return fmt.Errorf("destination %T is not a non-nil pointer", dest)
+	}
+	target := dv.Elem()
+
+	if src == nil {
+		target.SetZero()
+		return nil
+	}
+	if target.Kind() == reflect.Pointer {
+		value := reflect.New(target.Type().Elem())
+		if err := assign(value.Interface(), src); err != nil {
+			return err
+		}
+		target.Set(value)
+		return nil
+	}
+
+	sv := reflect.ValueOf(src)
+	switch {
+	case sv.Type().AssignableTo(target.Type()):
+		target.Set(sv)
+	case target.Kind() == reflect.String && sv.Kind() == reflect.Slice && sv.Type().Elem().Kind() == reflect.Uint8:
+		target.SetString(string(sv.Bytes()))
+	case isNumeric(target.Kind()) && isNumeric(sv.Kind()):
+		target.Set(sv.Convert(target.Type()))
+	default:
+		return fmt.Errorf("cannot assign %T to %s", src, target.Type())
+	}
+	return nil
+}
+
+func isNumeric(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Uint64) || k == reflect.Float32 || k == reflect.Float64
+}
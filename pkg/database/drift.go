@@ -0,0 +1,66 @@
+package database
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrMigrationDrift is matched by every MigrationDriftError.
+var ErrMigrationDrift = errors.New("database: migration drift detected")
+
+// DriftPolicy selects what a migration run does when the migration files no
+// longer match the checksums recorded when they were applied.
+type DriftPolicy int
+
+const (
+	// DriftFail aborts the run with a *MigrationDriftError.
+	DriftFail DriftPolicy = iota
+	// DriftWarn logs the drift at warn level and keeps going.
+	DriftWarn
+	// DriftIgnore neither verifies nor records checksums.
+	DriftIgnore
+)
+
+func (p DriftPolicy) String() string {
+	switch p {
+	case DriftFail:
+		return "fail"
+	case DriftWarn:
+		return "warn"
+	case DriftIgnore:
+		return "ignore"
+	default:
+		return "DriftPolicy(" + strconv.Itoa(int(p)) + ")"
+	}
+}
+
+// MigrationDriftError lists the migration files that diverge from what was
+// recorded when they were applied.
+type MigrationDriftError struct {
+	// Modified are applied files whose content changed since they ran.
+	Modified []string
+	// Missing are applied files no longer present in the source.
+	Missing []string
+	// OutOfOrder are files with a version below the current one that were
+	// never applied and never will be.
+	OutOfOrder []string
+}
+
+func (e *MigrationDriftError) Error() string {
+	var parts []string
+	if len(e.Modified) > 0 {
+		parts = append(parts, "modified: "+strings.Join(e.Modified, ", "))
+	}
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing: "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.OutOfOrder) > 0 {
+		parts = append(parts, "out of order: "+strings.Join(e.OutOfOrder, ", "))
+	}
+	return ErrMigrationDrift.Error() + " (" + strings.Join(parts, "; ") + ")"
+}
+
+func (e *MigrationDriftError) Is(target error) bool {
+	return target == ErrMigrationDrift
+}
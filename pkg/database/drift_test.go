@@ -0,0 +1,34 @@
+package database_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+func TestMigrationDriftError_ListsFiles(t *testing.T) {
+	err := fmt.Errorf("%w: %w", database.ErrMigrationFailed, &database.MigrationDriftError{
+		Modified:   []string{"1_users.up.sql"},
+		OutOfOrder: []string{"2_posts.up.sql", "3_tags.up.sql"},
+	})
+
+	require.ErrorIs(t, err, database.ErrMigrationDrift)
+	require.ErrorIs(t, err, database.ErrMigrationFailed)
+
+	var drift *database.MigrationDriftError
+	require.True(t, errors.As(err, &drift))
+	require.Equal(t, []string{"1_users.up.sql"}, drift.Modified)
+	require.Contains(t, err.Error(), "modified: 1_users.up.sql; out of order: 2_posts.up.sql, 3_tags.up.sql")
+	require.NotContains(t, err.Error(), "missing")
+}
+
+func TestDriftPolicy_String(t *testing.T) {
+	require.Equal(t, "fail", database.DriftFail.String())
+	require.Equal(t, "warn", database.DriftWarn.String())
+	require.Equal(t, "ignore", database.DriftIgnore.String())
+	require.Equal(t, "DriftPolicy(9)", database.DriftPolicy(9).String())
+}
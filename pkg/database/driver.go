@@ -1,5 +1,7 @@
 package database
 
+import "strconv"
+
 type Driver string
 
 const (
@@ -7,4 +9,18 @@ const (
 	DriverCockroach Driver = "cockroach"
 	DriverMySQL     Driver = "mysql"
 	DriverMSSQL     Driver = "mssql"
+	DriverSQLite    Driver = "sqlite"
 )
+
+// Placeholder returns the positional bind marker for the n-th (1-based)
+// argument in the driver's SQL dialect.
+func (d Driver) Placeholder(n int) string {
+	switch d {
+	case DriverPostgres, DriverCockroach:
+		return "$" + strconv.Itoa(n)
+	case DriverMSSQL:
+		return "@p" + strconv.Itoa(n)
+	default:
+		return "?"
+	}
+}
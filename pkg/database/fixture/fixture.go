@@ -0,0 +1,164 @@
+// Package fixture loads test data through a manager.Manager and isolates tests.
+package fixture
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/dialect"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/manager"
+)
+
+// Row maps column names to values.
+type Row map[string]any
+
+// Fixtures maps table names to the rows to insert into them, in order.
+type Fixtures map[string][]Row
+
+// Parse decodes a YAML or JSON document.
+func Parse(data []byte) (Fixtures, error) {
+	f, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("fixture: %w", err)
+	}
+	return f, nil
+}
+
+func parse(data []byte) (Fixtures, error) {
+	var f Fixtures
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	for table := range f {
+		if !dialect.ValidIdentifier(table) {
+			return nil, fmt.Errorf("%w: invalid table name %q", database.ErrInvalidConfig, table)
+		}
+	}
+	return f, nil
+}
+
+// ReadFile reads and parses a .yml, .yaml or .json file.
+func ReadFile(path string) (Fixtures, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml", ".json":
+	default:
+		return nil, fmt.Errorf("%w: fixture: %s: unsupported extension", database.ErrInvalidConfig, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("fixture: %w", err)
+	}
+	f, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("fixture: %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Tables returns the table names of f, sorted.
+func (f Fixtures) Tables() []string {
+	return slices.Sorted(maps.Keys(f))
+}
+
+// Load inserts fixtures parents first, in the transaction of ctx or one of its own.
+func Load(ctx context.Context, mgr manager.Manager, fixtures ...Fixtures) error {
+	merged := Fixtures{}
+	for _, f := range fixtures {
+		for table, rows := range f {
+			merged[table] = append(merged[table], rows...)
+		}
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+
+	return inTx(ctx, mgr, func(ctx context.Context) error {
+		return load(ctx, mgr, merged)
+	})
+}
+
+func load(ctx context.Context, mgr manager.Manager, f Fixtures) error {
+	db := mgr.DBTX(ctx)
+	order, err := insertOrder(ctx, db, mgr.Driver(), f.Tables())
+	if err != nil {
+		return err
+	}
+	named := database.Named(db, mgr.Driver())
+	for _, table := range order {
+		for i, row := range f[table] {
+			query, args, err := insertRow(table, row)
+			if err != nil {
+				return err
+			}
+			if _, err := named.ExecContext(ctx, query, args); err != nil {
+				return fmt.Errorf("fixture: %s row %d: %w", table, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func insertRow(table string, row Row) (string, map[string]any, error) {
+	columns := slices.Sorted(maps.Keys(row))
+	if len(columns) == 0 {
+		return "", nil, fmt.Errorf("%w: fixture: empty row in %s", database.ErrInvalidConfig, table)
+	}
+	args := make(map[string]any, len(row))
+	placeholders := make([]string, len(columns))
+	for i, c := range columns {
+		if !columnPattern.MatchString(c) {
+			return "", nil, fmt.Errorf("%w: fixture: invalid column %q in %s", database.ErrInvalidConfig, c, table)
+		}
+		value, err := columnValue(row[c])
+		if err != nil {
+			return "", nil, fmt.Errorf("fixture: %s.%s: %w", table, c, err)
+		}
+		args[c] = value
+		placeholders[i] = ":" + c
+	}
+	query := "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(placeholders, ", ") + ")"
+	return query, args, nil
+}
+
+// columnValue encodes maps and lists as JSON so named parameters do not expand them.
+func columnValue(value any) (any, error) {
+	if _, ok := value.([]byte); ok {
+		return value, nil
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Map, reflect.Slice:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	}
+	return value, nil
+}
+
+// LoadFiles reads and loads the fixture files at paths, failing tb on any error.
+func LoadFiles(ctx context.Context, tb testing.TB, mgr manager.Manager, paths ...string) {
+	tb.Helper()
+	fixtures := make([]Fixtures, 0, len(paths))
+	for _, path := range paths {
+		f, err := ReadFile(path)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		fixtures = append(fixtures, f)
+	}
+	if err := Load(ctx, mgr, fixtures...); err != nil {
+		tb.Fatal(err)
+	}
+}
@@ -0,0 +1,138 @@
+package fixture_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/dbtest"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/fixture"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/manager"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/sqlite"
+)
+
+const schema = `
+CREATE TABLE customers (id INTEGER PRIMARY KEY, name TEXT NOT NULL);
+CREATE TABLE orders (id INTEGER PRIMARY KEY, customer_id INTEGER NOT NULL REFERENCES customers (id), meta TEXT);
+CREATE TABLE order_items (order_id INTEGER NOT NULL REFERENCES orders (id), sku TEXT NOT NULL, qty INTEGER NOT NULL);
+`
+
+// newShop abre um SQLite em memória com foreign keys ativas, então inserir
+// um filho antes do pai falha.
+func newShop(t *testing.T) manager.Manager {
+	t.Helper()
+	mgr, err := manager.New(sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: t.Name()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mgr.Shutdown(context.Background()) })
+
+	_, err = mgr.DBTX(context.Background()).ExecContext(context.Background(), schema)
+	require.NoError(t, err)
+	return mgr
+}
+
+func count(t *testing.T, ctx context.Context, mgr manager.Manager, table string) int {
+	t.Helper()
+	n, err := database.QueryOne[int](ctx, mgr.DBTX(ctx), "SELECT COUNT(*) FROM "+table)
+	require.NoError(t, err)
+	return n
+}
+
+func TestLoadFiles_InsertsParentsFirst(t *testing.T) {
+	mgr := newShop(t)
+	ctx := context.Background()
+
+	fixture.LoadFiles(ctx, t, mgr, "testdata/shop.yml", "testdata/extra.json")
+
+	require.Equal(t, 2, count(t, ctx, mgr, "customers"))
+	require.Equal(t, 2, count(t, ctx, mgr, "orders"))
+	require.Equal(t, 2, count(t, ctx, mgr, "order_items"))
+
+	meta, err := database.QueryOne[string](ctx, mgr.DBTX(ctx), "SELECT meta FROM orders WHERE id = 10")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"channel":"web","tags":["promo"]}`, meta, "mapas e listas viram JSON")
+}
+
+func TestLoad_RollsBackWhenARowFails(t *testing.T) {
+	mgr := newShop(t)
+	ctx := context.Background()
+
+	err := fixture.Load(ctx, mgr, fixture.Fixtures{
+		"customers": {{"id": 1, "name": "Ana"}},
+		"orders":    {{"id": 10, "customer_id": 99}},
+	})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "orders row 0")
+	require.Zero(t, count(t, ctx, mgr, "customers"), "nada fica gravado quando uma linha falha")
+}
+
+func TestLoad_PostgresOrdersByCatalog(t *testing.T) {
+	db := dbtest.New()
+	db.On(`information_schema`).Rows([]string{"child", "parent"},
+		[]any{"invoices", "customers"},
+		[]any{"invoice_lines", "invoices"},
+		[]any{"audit", "users"},
+	)
+	ctx := context.Background()
+
+	err := fixture.Load(ctx, db, fixture.Fixtures{
+		"public.invoice_lines": {{"invoice_id": 1, "amount": 10}},
+		"invoices":             {{"id": 1, "customer_id": 7}},
+		"customers":            {{"id": 7, "name": "Ana"}},
+	})
+	require.NoError(t, err)
+
+	var inserts []string
+	for _, e := range db.Statements() {
+		if e.Kind == dbtest.EventExec {
+			inserts = append(inserts, e.Query)
+		}
+	}
+	require.Equal(t, []string{
+		"INSERT INTO customers (id, name) VALUES ($1, $2)",
+		"INSERT INTO invoices (customer_id, id) VALUES ($1, $2)",
+		"INSERT INTO public.invoice_lines (amount, invoice_id) VALUES ($1, $2)",
+	}, inserts, "tabelas com schema são comparadas pelo nome")
+	db.AssertCommittedOnce(t)
+}
+
+func TestLoad_JoinsTransactionInContext(t *testing.T) {
+	db := dbtest.New()
+	tx, err := db.BeginTx(context.Background(), database.TxOptions{})
+	require.NoError(t, err)
+	ctx := database.WithTx(context.Background(), tx)
+
+	require.NoError(t, fixture.Load(ctx, db, fixture.Fixtures{"customers": {{"id": 1}}}))
+	db.AssertExecutedInTx(t, `INSERT INTO customers`)
+	db.AssertCommitted(t, 0)
+}
+
+func TestLoad_RejectsCycles(t *testing.T) {
+	db := dbtest.New(dbtest.WithDriver(database.DriverMySQL))
+	db.On(`information_schema`).Rows([]string{"child", "parent"}, []any{"a", "b"}, []any{"b", "a"})
+
+	err := fixture.Load(context.Background(), db, fixture.Fixtures{"a": {{"id": 1}}, "b": {{"id": 1}}})
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+	require.ErrorContains(t, err, "cycle between a, b")
+	db.AssertNotExecuted(t, `INSERT`)
+	db.AssertRolledBack(t)
+}
+
+func TestParse_Validation(t *testing.T) {
+	_, err := fixture.Parse([]byte("users; DROP TABLE users:\n  - id: 1\n"))
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+
+	_, err = fixture.Parse([]byte("users: [1, 2]"))
+	require.Error(t, err, "cada linha precisa ser um mapa")
+
+	_, err = fixture.ReadFile("testdata/shop.csv")
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+
+	err = fixture.Load(context.Background(), dbtest.New(), fixture.Fixtures{"users": {{"name; --": "x"}}})
+	require.ErrorIs(t, err, database.ErrInvalidConfig, "nomes de coluna também são validados")
+
+	f, err := fixture.ReadFile("testdata/shop.yml")
+	require.NoError(t, err)
+	require.Equal(t, []string{"customers", "order_items", "orders"}, f.Tables())
+}
@@ -0,0 +1,136 @@
+package fixture
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/dialect"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/manager"
+)
+
+// Transaction returns a context carrying a transaction rolled back when the test ends.
+func Transaction(tb testing.TB, mgr manager.Manager) context.Context {
+	tb.Helper()
+	ctx := tb.Context()
+	tx, err := mgr.BeginTx(context.WithoutCancel(ctx), database.TxOptions{})
+	if err != nil {
+		tb.Fatalf("fixture: begin test transaction: %v", err)
+	}
+	tb.Cleanup(func() {
+		if err := tx.Rollback(context.Background()); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			tb.Errorf("fixture: roll back test transaction: %v", err)
+		}
+	})
+	return database.WithTxOwner(ctx, tx, mgr)
+}
+
+// Truncate empties tables when the test ends.
+func Truncate(tb testing.TB, mgr manager.Manager, tables ...string) {
+	tb.Helper()
+	for _, t := range tables {
+		if !dialect.ValidIdentifier(t) {
+			tb.Fatalf("fixture: invalid table name %q", t)
+		}
+	}
+	tb.Cleanup(func() {
+		if err := truncate(context.Background(), mgr, tables); err != nil {
+			tb.Errorf("%v", err)
+		}
+	})
+}
+
+func truncate(ctx context.Context, mgr manager.Manager, tables []string) error {
+	if len(tables) == 0 {
+		return nil
+	}
+	return inTx(ctx, mgr, func(ctx context.Context) error {
+		db := mgr.DBTX(ctx)
+		if mgr.Driver() == database.DriverPostgres {
+			if _, err := db.ExecContext(ctx, "TRUNCATE TABLE "+strings.Join(tables, ", ")+" RESTART IDENTITY CASCADE"); err != nil {
+				return fmt.Errorf("fixture: truncate: %w", err)
+			}
+			return nil
+		}
+		order, err := insertOrder(ctx, db, mgr.Driver(), tables)
+		if err != nil {
+			return err
+		}
+		for i := len(order) - 1; i >= 0; i-- {
+			if _, err := db.ExecContext(ctx, "DELETE FROM "+order[i]); err != nil {
+				return fmt.Errorf("fixture: delete from %s: %w", order[i], err)
+			}
+		}
+		return nil
+	})
+}
+
+// Clone copies template into a Postgres database dropped when the test ends.
+func Clone(tb testing.TB, admin manager.Manager, template string, connect func(ctx context.Context, database string) (manager.Manager, error)) manager.Manager {
+	tb.Helper()
+	if admin.Driver() != database.DriverPostgres {
+		tb.Fatalf("fixture: clone needs postgres, got %q", admin.Driver())
+	}
+	if !columnPattern.MatchString(template) {
+		tb.Fatalf("fixture: invalid template name %q", template)
+	}
+	name, err := cloneName(template)
+	if err != nil {
+		tb.Fatalf("fixture: %v", err)
+	}
+
+	ctx := tb.Context()
+	if _, err := admin.DBTX(ctx).ExecContext(ctx, `CREATE DATABASE "`+name+`" TEMPLATE "`+template+`"`); err != nil {
+		tb.Fatalf("fixture: clone %s: %v", template, err)
+	}
+	tb.Cleanup(func() {
+		ctx := context.Background()
+		if _, err := admin.DBTX(ctx).ExecContext(ctx, `DROP DATABASE IF EXISTS "`+name+`" WITH (FORCE)`); err != nil {
+			tb.Errorf("fixture: drop %s: %v", name, err)
+		}
+	})
+
+	mgr, err := connect(ctx, name)
+	if err != nil {
+		tb.Fatalf("fixture: connect to %s: %v", name, err)
+	}
+	tb.Cleanup(func() {
+		if err := mgr.Shutdown(context.Background()); err != nil {
+			tb.Errorf("fixture: shut down %s: %v", name, err)
+		}
+	})
+	return mgr
+}
+
+// cloneName stays within the 63 bytes Postgres keeps of an identifier.
+func cloneName(template string) (string, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%.40s_%s", template, hex.EncodeToString(suffix)), nil
+}
+
+func inTx(ctx context.Context, mgr manager.Manager, fn func(ctx context.Context) error) error {
+	if _, ok := database.FromContext(ctx); ok {
+		return fn(ctx)
+	}
+	tx, err := mgr.BeginTx(ctx, database.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("fixture: begin: %w", err)
+	}
+	if err := fn(database.WithTxOwner(ctx, tx, mgr)); err != nil {
+		_ = tx.Rollback(context.WithoutCancel(ctx))
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("fixture: commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package fixture_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/dbtest"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/fixture"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/manager"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/uow"
+)
+
+func TestTransaction_RollsBackAfterTest(t *testing.T) {
+	mgr := newShop(t)
+
+	t.Run("teste isolado", func(t *testing.T) {
+		ctx := fixture.Transaction(t, mgr)
+		fixture.LoadFiles(ctx, t, mgr, "testdata/shop.yml")
+
+		_, err := uow.NewVoid(mgr).Do(ctx, func(ctx context.Context, tx database.DBTX) (struct{}, error) {
+			_, err := tx.ExecContext(ctx, "INSERT INTO customers (id, name) VALUES (3, 'Carla')")
+			return struct{}{}, err
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, count(t, ctx, mgr, "customers"), "o uow participa da transação do teste")
+	})
+
+	require.Zero(t, count(t, context.Background(), mgr, "customers"), "tudo é desfeito ao fim do teste")
+	require.Zero(t, count(t, context.Background(), mgr, "order_items"))
+}
+
+func TestTruncate_DeletesChildrenFirst(t *testing.T) {
+	mgr := newShop(t)
+
+	t.Run("teste isolado", func(t *testing.T) {
+		fixture.Truncate(t, mgr, "customers", "orders", "order_items")
+		fixture.LoadFiles(context.Background(), t, mgr, "testdata/shop.yml")
+		require.Equal(t, 1, count(t, context.Background(), mgr, "orders"))
+	})
+
+	for _, table := range []string{"customers", "orders", "order_items"} {
+		require.Zero(t, count(t, context.Background(), mgr, table), table)
+	}
+}
+
+func TestTruncate_PostgresUsesTruncate(t *testing.T) {
+	db := dbtest.New()
+
+	t.Run("teste isolado", func(t *testing.T) {
+		fixture.Truncate(t, db, "orders", "customers")
+	})
+
+	db.AssertExecutedInTx(t, `^TRUNCATE TABLE orders, customers RESTART IDENTITY CASCADE$`)
+	db.AssertNotExecuted(t, `information_schema`)
+	db.AssertCommittedOnce(t)
+}
+
+func TestClone_CreatesAndDropsDatabase(t *testing.T) {
+	admin := dbtest.New()
+	clone := dbtest.New()
+	var connected string
+
+	t.Run("teste isolado", func(t *testing.T) {
+		mgr := fixture.Clone(t, admin, "app_template", func(_ context.Context, name string) (manager.Manager, error) {
+			connected = name
+			return clone, nil
+		})
+		require.Same(t, clone, mgr)
+	})
+
+	require.Regexp(t, `^app_template_[0-9a-f]{16}$`, connected)
+	require.Equal(t, []string{
+		`CREATE DATABASE "` + connected + `" TEMPLATE "app_template"`,
+		`DROP DATABASE IF EXISTS "` + connected + `" WITH (FORCE)`,
+	}, []string{admin.Statements()[0].Query, admin.Statements()[1].Query})
+	require.ErrorIs(t, clone.Ping(context.Background()), database.ErrManagerClosed, "o manager do clone é encerrado antes do drop")
+}
@@ -0,0 +1,115 @@
+package fixture
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/dialect"
+)
+
+var columnPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// foreignKeysQuery lists (child, parent) pairs; SQLite is queried per table instead.
+func foreignKeysQuery(driver database.Driver) string {
+	switch driver {
+	case database.DriverPostgres, database.DriverCockroach:
+		return `SELECT tc.table_name, ccu.table_name
+FROM information_schema.table_constraints tc
+JOIN information_schema.constraint_column_usage ccu
+  ON ccu.constraint_schema = tc.constraint_schema AND ccu.constraint_name = tc.constraint_name
+WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = current_schema()`
+	case database.DriverMySQL:
+		return `SELECT table_name, referenced_table_name
+FROM information_schema.key_column_usage
+WHERE table_schema = DATABASE() AND referenced_table_name IS NOT NULL`
+	case database.DriverMSSQL:
+		return `SELECT OBJECT_NAME(parent_object_id), OBJECT_NAME(referenced_object_id) FROM sys.foreign_keys`
+	}
+	return ""
+}
+
+func foreignKeys(ctx context.Context, db database.DBTX, driver database.Driver, tables []string) (map[string][]string, error) {
+	byName := make(map[string]string, len(tables))
+	for _, t := range tables {
+		byName[baseName(t)] = t
+	}
+
+	parents := map[string][]string{}
+	add := func(child, parent string) {
+		c, okc := byName[baseName(child)]
+		p, okp := byName[baseName(parent)]
+		if okc && okp && c != p && !slices.Contains(parents[c], p) {
+			parents[c] = append(parents[c], p)
+		}
+	}
+
+	if driver == database.DriverSQLite {
+		query := `SELECT "table" FROM pragma_foreign_key_list(` + dialect.Placeholder(driver, 1) + `)`
+		for _, t := range tables {
+			refs, err := database.QueryAll[string](ctx, db, query, baseName(t))
+			if err != nil {
+				return nil, fmt.Errorf("fixture: read foreign keys of %s: %w", t, err)
+			}
+			for _, ref := range refs {
+				add(t, ref)
+			}
+		}
+		return parents, nil
+	}
+
+	query := foreignKeysQuery(driver)
+	if query == "" {
+		return nil, fmt.Errorf("%w: fixture: unsupported driver %q", database.ErrInvalidConfig, driver)
+	}
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("fixture: read foreign keys: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var child, parent string
+		if err := rows.Scan(&child, &parent); err != nil {
+			return nil, fmt.Errorf("fixture: read foreign keys: %w", err)
+		}
+		add(child, parent)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fixture: read foreign keys: %w", err)
+	}
+	return parents, nil
+}
+
+// insertOrder puts parents first, breaking ties by name; cycles are reported.
+func insertOrder(ctx context.Context, db database.DBTX, driver database.Driver, tables []string) ([]string, error) {
+	parents, err := foreignKeys(ctx, db, driver, tables)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := slices.Sorted(slices.Values(tables))
+	order := make([]string, 0, len(pending))
+	done := map[string]bool{}
+	for len(pending) > 0 {
+		next := slices.IndexFunc(pending, func(t string) bool {
+			return !slices.ContainsFunc(parents[t], func(p string) bool { return !done[p] })
+		})
+		if next < 0 {
+			return nil, fmt.Errorf("%w: fixture: foreign keys form a cycle between %s", database.ErrInvalidConfig, strings.Join(pending, ", "))
+		}
+		order = append(order, pending[next])
+		done[pending[next]] = true
+		pending = slices.Delete(pending, next, next+1)
+	}
+	return order, nil
+}
+
+func baseName(table string) string {
+	if i := strings.LastIndexByte(table, '.'); i >= 0 {
+		table = table[i+1:]
+	}
+	return strings.ToLower(table)
+}
@@ -0,0 +1,269 @@
+// Package checksum records the checksum of every applied migration file and
+// reports drift between those records and the files of the source. It is
+// shared by the migration package and the manager startup migrations.
+package checksum
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/golang-migrate/migrate/v4/source"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+)
+
+// Table holds one row per applied migration file.
+const Table = "schema_migration_checksums"
+
+// NilVersion is the current version of a database with nothing applied.
+const NilVersion = -1
+
+// File is an up migration file of the source.
+type File struct {
+	Version uint
+	Name    string
+	Sum     string
+}
+
+// Sum returns the hex SHA-256 of a migration body.
+func Sum(body []byte) string {
+	h := sha256.Sum256(body)
+	return hex.EncodeToString(h[:])
+}
+
+// Scan reads the up migration files at the root of fsys. Names that do not
+// follow the golang-migrate convention are skipped, as golang-migrate does.
+func Scan(fsys fs.FS) ([]File, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	var files []File
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		m, err := source.DefaultParse(entry.Name())
+		if err != nil || m.Direction != source.Up {
+			continue
+		}
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Version: m.Version, Name: entry.Name(), Sum: Sum(body)})
+	}
+	slices.SortFunc(files, func(a, b File) int { return cmp.Compare(a.Version, b.Version) })
+	return files, nil
+}
+
+type record struct {
+	name string
+	sum  string
+}
+
+// Store reads and writes Table through db using the driver's dialect.
+type Store struct {
+	db     database.DBTX
+	driver database.Driver
+}
+
+func NewStore(db database.DBTX, driver database.Driver) *Store {
+	return &Store{db: db, driver: driver}
+}
+
+func (s *Store) ensure(ctx context.Context) error {
+	const columns = " (version BIGINT NOT NULL PRIMARY KEY, name VARCHAR(255) NOT NULL, checksum CHAR(64) NOT NULL)"
+	ddl := "CREATE TABLE IF NOT EXISTS " + Table + columns
+	if s.driver == database.DriverMSSQL {
+		ddl = "IF OBJECT_ID(N'" + Table + "', N'U') IS NULL CREATE TABLE " + Table + columns
+	}
+	if _, err := s.db.ExecContext(ctx, ddl); err != nil {
+		return fmt.Errorf("checksum: create %s: %w", Table, err)
+	}
+	return nil
+}
+
+func (s *Store) load(ctx context.Context) (map[uint]record, error) {
+	if err := s.ensure(ctx); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx, "SELECT version, name, checksum FROM "+Table)
+	if err != nil {
+		return nil, fmt.Errorf("checksum: read %s: %w", Table, err)
+	}
+	defer rows.Close()
+
+	recorded := make(map[uint]record)
+	for rows.Next() {
+		var (
+			version int64
+			rec     record
+		)
+		if err := rows.Scan(&version, &rec.name, &rec.sum); err != nil {
+			return nil, fmt.Errorf("checksum: read %s: %w", Table, err)
+		}
+		recorded[uint(version)] = rec
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("checksum: read %s: %w", Table, err)
+	}
+	return recorded, nil
+}
+
+// insertQuery skips a version that is already recorded, so replicas migrating
+// at the same time do not fail on each other's rows.
+func (s *Store) insertQuery() string {
+	p1, p2, p3 := s.driver.Placeholder(1), s.driver.Placeholder(2), s.driver.Placeholder(3)
+	switch s.driver {
+	case database.DriverMySQL:
+		return "INSERT IGNORE INTO " + Table + " (version, name, checksum) VALUES (" + p1 + ", " + p2 + ", " + p3 + ")"
+	case database.DriverMSSQL:
+		return "MERGE " + Table + " WITH (HOLDLOCK) AS t USING (SELECT " + p1 + " AS version, " + p2 + " AS name, " + p3 + " AS checksum) AS s" +
+			" ON t.version = s.version WHEN NOT MATCHED THEN INSERT (version, name, checksum) VALUES (s.version, s.name, s.checksum);"
+	default:
+		return "INSERT INTO " + Table + " (version, name, checksum) VALUES (" + p1 + ", " + p2 + ", " + p3 + ") ON CONFLICT (version) DO NOTHING"
+	}
+}
+
+func (s *Store) insert(ctx context.Context, f File) error {
+	if _, err := s.db.ExecContext(ctx, s.insertQuery(), int64(f.Version), f.Name, f.Sum); err != nil {
+		return fmt.Errorf("checksum: record %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+func (s *Store) deleteAbove(ctx context.Context, current int) error {
+	query := "DELETE FROM " + Table + " WHERE version > " + s.driver.Placeholder(1)
+	if _, err := s.db.ExecContext(ctx, query, int64(current)); err != nil {
+		return fmt.Errorf("checksum: prune %s: %w", Table, err)
+	}
+	return nil
+}
+
+// Verify compares files with the checksums recorded for the versions applied
+// up to current. It returns a *database.MigrationDriftError when a recorded
+// file changed or disappeared, or when an unrecorded file sits below current.
+// Nothing is reported while no checksum has been recorded yet.
+func (s *Store) Verify(ctx context.Context, files []File, current int) error {
+	recorded, err := s.load(ctx)
+	if err != nil || len(recorded) == 0 {
+		return err
+	}
+
+	byVersion := make(map[uint]File, len(files))
+	for _, f := range files {
+		byVersion[f.Version] = f
+	}
+
+	drift := &database.MigrationDriftError{}
+	for _, v := range slices.Sorted(maps.Keys(recorded)) {
+		if int(v) > current {
+			continue
+		}
+		rec := recorded[v]
+		f, ok := byVersion[v]
+		switch {
+		case !ok:
+			drift.Missing = append(drift.Missing, rec.name)
+		case f.Sum != rec.sum:
+			drift.Modified = append(drift.Modified, f.Name)
+		}
+	}
+	for _, f := range files {
+		if _, ok := recorded[f.Version]; !ok && int(f.Version) <= current {
+			drift.OutOfOrder = append(drift.OutOfOrder, f.Name)
+		}
+	}
+
+	if len(drift.Modified)+len(drift.Missing)+len(drift.OutOfOrder) == 0 {
+		return nil
+	}
+	return drift
+}
+
+// Sync updates the records after a run moved the version from prev to
+// current: records above current are dropped and the files in (prev, current]
+// are recorded. When nothing is recorded yet every file up to current is
+// recorded as the baseline.
+func (s *Store) Sync(ctx context.Context, files []File, prev, current int) error {
+	recorded, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.deleteAbove(ctx, current); err != nil {
+		return err
+	}
+	if len(recorded) == 0 {
+		prev = NilVersion
+	}
+	for _, f := range files {
+		v := int(f.Version)
+		if _, ok := recorded[f.Version]; ok || v <= prev || v > current {
+			continue
+		}
+		if err := s.insert(ctx, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reset replaces every record with the checksums of the files up to current,
+// accepting whatever drift existed.
+func (s *Store) Reset(ctx context.Context, files []File, current int) error {
+	if err := s.ensure(ctx); err != nil {
+		return err
+	}
+	if err := s.deleteAbove(ctx, NilVersion); err != nil {
+		return err
+	}
+	for _, f := range files {
+		if int(f.Version) > current {
+			break
+		}
+		if err := s.insert(ctx, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Report applies policy to the result of Verify: DriftWarn logs a drift
+// error and swallows it. Other errors are returned unchanged.
+func Report(ctx context.Context, policy database.DriftPolicy, obs observability.Observability, err error) error {
+	var drift *database.MigrationDriftError
+	if policy != database.DriftWarn || !errors.As(err, &drift) {
+		return err
+	}
+
+	const msg = "database migration drift detected"
+	if obs != nil && !isNoop(obs) {
+		obs.Logger().Warn(ctx, msg,
+			observability.Any("modified", drift.Modified),
+			observability.Any("missing", drift.Missing),
+			observability.Any("out_of_order", drift.OutOfOrder),
+		)
+		return nil
+	}
+	slog.Default().WarnContext(ctx, msg,
+		"modified", drift.Modified,
+		"missing", drift.Missing,
+		"out_of_order", drift.OutOfOrder,
+	)
+	return nil
+}
+
+func isNoop(obs observability.Observability) bool {
+	m, ok := obs.(interface{ IsNoop() bool })
+	return ok && m.IsNoop()
+}
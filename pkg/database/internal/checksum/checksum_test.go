@@ -0,0 +1,55 @@
+package checksum
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+func TestScan_ReadsUpFilesInVersionOrder(t *testing.T) {
+	files, err := Scan(fstest.MapFS{
+		"10_c.up.sql":    {Data: []byte("C")},
+		"2_b.up.sql":     {Data: []byte("B")},
+		"2_b.down.sql":   {Data: []byte("-B")},
+		"README.md":      {Data: []byte("ignorado")},
+		"sub/1_a.up.sql": {Data: []byte("A")},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []File{
+		{Version: 2, Name: "2_b.up.sql", Sum: Sum([]byte("B"))},
+		{Version: 10, Name: "10_c.up.sql", Sum: Sum([]byte("C"))},
+	}, files)
+	require.Len(t, files[0].Sum, 64)
+}
+
+func TestReport_AppliesPolicy(t *testing.T) {
+	ctx := context.Background()
+	drift := &database.MigrationDriftError{Modified: []string{"1_a.up.sql"}}
+
+	require.ErrorIs(t, Report(ctx, database.DriftFail, nil, drift), database.ErrMigrationDrift)
+	require.NoError(t, Report(ctx, database.DriftWarn, nil, drift))
+	require.NoError(t, Report(ctx, database.DriftWarn, nil, nil))
+
+	// Erros que não são de drift nunca são descartados.
+	require.ErrorIs(t, Report(ctx, database.DriftWarn, nil, database.ErrManagerClosed), database.ErrManagerClosed)
+}
+
+// TestStore_InsertSkipsRecordedVersions verifica que cada dialeto grava o
+// checksum sem falhar quando outra réplica já gravou a mesma versão.
+func TestStore_InsertSkipsRecordedVersions(t *testing.T) {
+	cases := map[database.Driver]string{
+		database.DriverPostgres:  "INSERT INTO schema_migration_checksums (version, name, checksum) VALUES ($1, $2, $3) ON CONFLICT (version) DO NOTHING",
+		database.DriverCockroach: "INSERT INTO schema_migration_checksums (version, name, checksum) VALUES ($1, $2, $3) ON CONFLICT (version) DO NOTHING",
+		database.DriverSQLite:    "INSERT INTO schema_migration_checksums (version, name, checksum) VALUES (?, ?, ?) ON CONFLICT (version) DO NOTHING",
+		database.DriverMySQL:     "INSERT IGNORE INTO schema_migration_checksums (version, name, checksum) VALUES (?, ?, ?)",
+		database.DriverMSSQL: "MERGE schema_migration_checksums WITH (HOLDLOCK) AS t USING (SELECT @p1 AS version, @p2 AS name, @p3 AS checksum) AS s" +
+			" ON t.version = s.version WHEN NOT MATCHED THEN INSERT (version, name, checksum) VALUES (s.version, s.name, s.checksum);",
+	}
+	for driver, want := range cases {
+		require.Equal(t, want, NewStore(nil, driver).insertQuery(), driver)
+	}
+}
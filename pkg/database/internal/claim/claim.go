@@ -0,0 +1,107 @@
+// Package claim holds what the outbox relay and the queue source share to
+// poll a table whose rows are claimed with SKIP LOCKED.
+package claim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/dialect"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+)
+
+const (
+	maxLastErrorLength = 1024
+	rollbackTimeout    = 5 * time.Second
+)
+
+// Options are the polling settings common to both tables. The setters ignore
+// zero values so the defaults stay in place.
+type Options struct {
+	Table         string
+	BatchSize     int
+	PollInterval  time.Duration
+	MaxAttempts   int
+	Observability observability.Observability
+}
+
+func (o *Options) SetTable(table string) {
+	if table != "" {
+		o.Table = table
+	}
+}
+
+func (o *Options) SetBatchSize(n int) {
+	if n > 0 {
+		o.BatchSize = n
+	}
+}
+
+func (o *Options) SetPollInterval(d time.Duration) {
+	if d > 0 {
+		o.PollInterval = d
+	}
+}
+
+func (o *Options) SetMaxAttempts(n int) {
+	if n > 0 {
+		o.MaxAttempts = n
+	}
+}
+
+func (o *Options) SetObservability(obs observability.Observability) {
+	if obs != nil {
+		o.Observability = obs
+	}
+}
+
+// Validate rejects drivers without SKIP LOCKED support and table names that
+// cannot be interpolated safely. pkg prefixes the error message.
+func Validate(pkg string, driver database.Driver, tables ...string) error {
+	if !dialect.Supported(driver) {
+		return fmt.Errorf("%w: %s: unsupported driver %q", database.ErrInvalidConfig, pkg, driver)
+	}
+	for _, table := range tables {
+		if !dialect.ValidIdentifier(table) {
+			return fmt.Errorf("%w: %s: invalid table name %q", database.ErrInvalidConfig, pkg, table)
+		}
+	}
+	return nil
+}
+
+// Query selects up to limit rows matching where and locks them, skipping rows
+// another transaction already holds.
+func Query(driver database.Driver, columns, table, where, orderBy, limit string) string {
+	if driver == database.DriverMSSQL {
+		return fmt.Sprintf(
+			"SELECT TOP (%s) %s FROM %s WITH (UPDLOCK, READPAST, ROWLOCK) WHERE %s ORDER BY %s",
+			limit, columns, table, where, orderBy,
+		)
+	}
+	return fmt.Sprintf(
+		"SELECT %s FROM %s WHERE %s ORDER BY %s LIMIT %s FOR UPDATE SKIP LOCKED",
+		columns, table, where, orderBy, limit,
+	)
+}
+
+// LastError is the text stored in a last_error column.
+func LastError(err error) string {
+	s := err.Error()
+	if len(s) <= maxLastErrorLength {
+		return s
+	}
+	return s[:maxLastErrorLength]
+}
+
+// Rollback rolls tx back even when ctx is already done.
+func Rollback(ctx context.Context, tx database.Tx, pkg string) error {
+	rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+	defer cancel()
+
+	if err := tx.Rollback(rollbackCtx); err != nil {
+		return fmt.Errorf("%s: rollback: %w", pkg, err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package claim_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/claim"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery_PerDriver(t *testing.T) {
+	cases := map[database.Driver]string{
+		database.DriverPostgres: "SELECT id, payload FROM jobs WHERE attempts < $1 ORDER BY id LIMIT $2 FOR UPDATE SKIP LOCKED",
+		database.DriverMySQL:    "SELECT id, payload FROM jobs WHERE attempts < ? ORDER BY id LIMIT ? FOR UPDATE SKIP LOCKED",
+		database.DriverMSSQL:    "SELECT TOP (@p2) id, payload FROM jobs WITH (UPDLOCK, READPAST, ROWLOCK) WHERE attempts < @p1 ORDER BY id",
+	}
+	for driver, want := range cases {
+		t.Run(string(driver), func(t *testing.T) {
+			got := claim.Query(driver, "id, payload", "jobs", "attempts < "+driver.Placeholder(1), "id", driver.Placeholder(2))
+			require.Equal(t, want, got)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, claim.Validate("queue", database.DriverPostgres, "jobs", "jobs_dead"))
+
+	err := claim.Validate("queue", database.DriverSQLite, "jobs")
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+	require.Contains(t, err.Error(), "queue: unsupported driver")
+
+	err = claim.Validate("outbox", database.DriverMySQL, "jobs", "jobs; DROP TABLE users")
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+	require.Contains(t, err.Error(), "outbox: invalid table name")
+}
+
+func TestLastError_Truncates(t *testing.T) {
+	require.Equal(t, "boom", claim.LastError(errors.New("boom")))
+	require.Len(t, claim.LastError(errors.New(strings.Repeat("x", 2000))), 1024)
+}
+
+func TestOptions_SettersIgnoreZeroValues(t *testing.T) {
+	o := claim.Options{Table: "jobs", BatchSize: 10, MaxAttempts: 3}
+	o.SetTable("")
+	o.SetBatchSize(0)
+	o.SetMaxAttempts(-1)
+	o.SetObservability(nil)
+	require.Equal(t, claim.Options{Table: "jobs", BatchSize: 10, MaxAttempts: 3}, o)
+
+	o.SetTable("other")
+	o.SetBatchSize(50)
+	require.Equal(t, "other", o.Table)
+	require.Equal(t, 50, o.BatchSize)
+}
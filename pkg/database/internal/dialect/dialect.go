@@ -0,0 +1,30 @@
+package dialect
+
+import (
+	"regexp"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+func Supported(driver database.Driver) bool {
+	switch driver {
+	case database.DriverPostgres, database.DriverCockroach, database.DriverMySQL, database.DriverMSSQL:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsPgFlavor(driver database.Driver) bool {
+	return driver == database.DriverPostgres || driver == database.DriverCockroach
+}
+
+func Placeholder(driver database.Driver, n int) string {
+	return driver.Placeholder(n)
+}
+
+func ValidIdentifier(name string) bool {
+	return identifierPattern.MatchString(name)
+}
@@ -0,0 +1,56 @@
+package dialect_test
+
+import (
+	"testing"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceholder_PerDriver(t *testing.T) {
+	cases := []struct {
+		driver database.Driver
+		n      int
+		want   string
+	}{
+		{database.DriverPostgres, 1, "$1"},
+		{database.DriverCockroach, 12, "$12"},
+		{database.DriverMySQL, 3, "?"},
+		{database.DriverMSSQL, 2, "@p2"},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.driver), func(t *testing.T) {
+			require.Equal(t, tc.want, dialect.Placeholder(tc.driver, tc.n))
+		})
+	}
+}
+
+func TestValidIdentifier(t *testing.T) {
+	cases := []struct {
+		name string
+		want bool
+	}{
+		{"outbox", true},
+		{"public.outbox_messages", true},
+		{"_tbl1", true},
+		{"", false},
+		{"1table", false},
+		{"a.b.c", false},
+		{"outbox; DROP TABLE users", false},
+		{"out-box", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, dialect.ValidIdentifier(tc.name))
+		})
+	}
+}
+
+func TestSupported(t *testing.T) {
+	require.True(t, dialect.Supported(database.DriverPostgres))
+	require.True(t, dialect.Supported(database.DriverMSSQL))
+	require.False(t, dialect.Supported(database.Driver("oracle")))
+}
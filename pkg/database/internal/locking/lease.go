@@ -0,0 +1,126 @@
+package locking
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+// LeaseTable holds one row per lease lock while it is held.
+const LeaseTable = "database_locks"
+
+const leasePollInterval = 500 * time.Millisecond
+
+// leaseBackend takes a lock by owning its row until expires_at. The holder
+// renews the row while it runs; a crashed holder lets it expire.
+type leaseBackend struct {
+	db     database.DBTX
+	driver database.Driver
+	ttl    time.Duration
+
+	ensureMu sync.Mutex
+	ensured  bool
+}
+
+func (b *leaseBackend) ensure(ctx context.Context) error {
+	b.ensureMu.Lock()
+	defer b.ensureMu.Unlock()
+	if b.ensured {
+		return nil
+	}
+	_, err := b.db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+LeaseTable+
+		" (name STRING PRIMARY KEY, owner STRING NOT NULL, expires_at TIMESTAMPTZ NOT NULL)")
+	if err != nil {
+		return fmt.Errorf("locking: create %s: %w", LeaseTable, err)
+	}
+	b.ensured = true
+	return nil
+}
+
+func (b *leaseBackend) acquire(ctx context.Context, name string, wait bool) (holder, error) {
+	if err := b.ensure(ctx); err != nil {
+		return nil, err
+	}
+	owner, err := newOwner()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		ok, err := b.try(ctx, name, owner)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return &leaseHold{backend: b, name: name, owner: owner}, nil
+		}
+		if !wait {
+			return nil, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(leasePollInterval):
+		}
+	}
+}
+
+// try inserts the row, or takes it over once expired. No row comes back when
+// another owner still holds a live lease.
+func (b *leaseBackend) try(ctx context.Context, name, owner string) (bool, error) {
+	query := "INSERT INTO " + LeaseTable + " (name, owner, expires_at) VALUES ($1, $2, now() + $3 * INTERVAL '1 millisecond')" +
+		" ON CONFLICT (name) DO UPDATE SET owner = excluded.owner, expires_at = excluded.expires_at" +
+		" WHERE " + LeaseTable + ".expires_at < now() RETURNING owner"
+	_, err := database.QueryOne[string](ctx, b.db, query, name, owner, b.ttl.Milliseconds())
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func newOwner() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+type leaseHold struct {
+	backend *leaseBackend
+	name    string
+	owner   string
+}
+
+func (h *leaseHold) keepAlive(ctx context.Context) error {
+	query := "UPDATE " + LeaseTable + " SET expires_at = now() + $3 * INTERVAL '1 millisecond' WHERE name = $1 AND owner = $2"
+	return h.exec(ctx, query, h.backend.ttl.Milliseconds())
+}
+
+func (h *leaseHold) release(ctx context.Context) error {
+	return h.exec(ctx, "DELETE FROM "+LeaseTable+" WHERE name = $1 AND owner = $2")
+}
+
+// exec runs query on the row of this owner; no row means the lease expired
+// and was taken over.
+func (h *leaseHold) exec(ctx context.Context, query string, args ...any) error {
+	res, err := h.backend.db.ExecContext(ctx, query, append([]any{h.name, h.owner}, args...)...)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return database.ErrLockNotHeld
+	}
+	return nil
+}
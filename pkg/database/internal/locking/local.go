@@ -0,0 +1,51 @@
+package locking
+
+import (
+	"context"
+	"sync"
+)
+
+// localBackend keeps locks in memory; a lock is a channel closed on release.
+type localBackend struct {
+	mu   sync.Mutex
+	held map[string]chan struct{}
+}
+
+func (b *localBackend) acquire(ctx context.Context, name string, wait bool) (holder, error) {
+	for {
+		b.mu.Lock()
+		released, taken := b.held[name]
+		if !taken {
+			released = make(chan struct{})
+			b.held[name] = released
+			b.mu.Unlock()
+			return &localHold{backend: b, name: name, released: released}, nil
+		}
+		b.mu.Unlock()
+
+		if !wait {
+			return nil, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+type localHold struct {
+	backend  *localBackend
+	name     string
+	released chan struct{}
+}
+
+func (h *localHold) keepAlive(context.Context) error { return nil }
+
+func (h *localHold) release(context.Context) error {
+	h.backend.mu.Lock()
+	delete(h.backend.held, h.name)
+	h.backend.mu.Unlock()
+	close(h.released)
+	return nil
+}
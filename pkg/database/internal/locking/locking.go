@@ -0,0 +1,232 @@
+// Package locking implements database.Locker on top of the adapters: session
+// locks on a pinned connection for postgres, MySQL and MSSQL, and a lease
+// table for cockroach, which has no advisory locks.
+package locking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+const (
+	// DefaultKeepAlive is how often a session lock checks its connection.
+	DefaultKeepAlive = 10 * time.Second
+	// DefaultLeaseTTL is how long a lease lock survives without renewal.
+	DefaultLeaseTTL = 30 * time.Second
+
+	releaseTimeout = 5 * time.Second
+)
+
+// Conn is a pooled connection pinned to one lock session.
+type Conn interface {
+	database.DBTX
+	// Release hands the connection back to the pool.
+	Release()
+	// Destroy closes the connection instead of pooling it, dropping any
+	// session state left on it.
+	Destroy()
+}
+
+// Pinner is implemented by adapters that can pin a pooled connection.
+type Pinner interface {
+	Conn(ctx context.Context) (Conn, error)
+}
+
+// Config selects the lock backend of a driver.
+type Config struct {
+	Driver database.Driver
+	// Pin opens the dedicated connection of session locks.
+	Pin func(ctx context.Context) (Conn, error)
+	// DB runs the statements of lease locks.
+	DB        database.DBTX
+	KeepAlive time.Duration
+	LeaseTTL  time.Duration
+	// OnRelease is called once for every lock that is released or lost.
+	OnRelease func(name string)
+}
+
+type backend interface {
+	// acquire returns nil, nil when wait is false and name is taken.
+	acquire(ctx context.Context, name string, wait bool) (holder, error)
+}
+
+type holder interface {
+	keepAlive(ctx context.Context) error
+	release(ctx context.Context) error
+}
+
+// Locker hands out locks and remembers them until they are released.
+type Locker struct {
+	backend   backend
+	keepAlive time.Duration
+	onRelease func(string)
+
+	mu     sync.Mutex
+	held   map[*lock]struct{}
+	closed bool
+}
+
+// New returns the Locker of cfg.Driver, or database.ErrLockUnsupported.
+func New(cfg Config) (*Locker, error) {
+	keepAlive := cfg.KeepAlive
+	if keepAlive <= 0 {
+		keepAlive = DefaultKeepAlive
+	}
+
+	var b backend
+	switch cfg.Driver {
+	case database.DriverPostgres, database.DriverMySQL, database.DriverMSSQL:
+		if cfg.Pin == nil {
+			return nil, fmt.Errorf("%w: %s", database.ErrLockUnsupported, cfg.Driver)
+		}
+		b = &sessionBackend{pin: cfg.Pin, dialect: sessionDialects[cfg.Driver]}
+	case database.DriverCockroach:
+		ttl := cfg.LeaseTTL
+		if ttl <= 0 {
+			ttl = DefaultLeaseTTL
+		}
+		b = &leaseBackend{db: cfg.DB, driver: cfg.Driver, ttl: ttl}
+		keepAlive = ttl / 3
+	default:
+		return nil, fmt.Errorf("%w: %s", database.ErrLockUnsupported, cfg.Driver)
+	}
+	return newLocker(b, keepAlive, cfg.OnRelease), nil
+}
+
+// NewLocal returns a Locker whose locks only exclude other callers of the
+// same Locker. It backs in-memory fakes.
+func NewLocal() *Locker {
+	return newLocker(&localBackend{held: map[string]chan struct{}{}}, time.Hour, nil)
+}
+
+func newLocker(b backend, keepAlive time.Duration, onRelease func(string)) *Locker {
+	if onRelease == nil {
+		onRelease = func(string) {}
+	}
+	return &Locker{backend: b, keepAlive: keepAlive, onRelease: onRelease, held: map[*lock]struct{}{}}
+}
+
+func (l *Locker) TryLock(ctx context.Context, name string) (database.Lock, bool, error) {
+	lk, err := l.acquire(ctx, name, false)
+	if lk == nil {
+		return nil, false, err
+	}
+	return lk, true, nil
+}
+
+func (l *Locker) Lock(ctx context.Context, name string) (database.Lock, error) {
+	lk, err := l.acquire(ctx, name, true)
+	if err != nil {
+		return nil, err
+	}
+	return lk, nil
+}
+
+func (l *Locker) acquire(ctx context.Context, name string, wait bool) (*lock, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: empty lock name", database.ErrInvalidConfig)
+	}
+	h, err := l.backend.acquire(ctx, name, wait)
+	if err != nil || h == nil {
+		return nil, err
+	}
+
+	lk := &lock{locker: l, name: name, h: h, done: make(chan struct{})}
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+		defer cancel()
+		_ = h.release(releaseCtx)
+		return nil, database.ErrManagerClosed
+	}
+	l.held[lk] = struct{}{}
+	l.mu.Unlock()
+
+	go lk.watch(ctx, l.keepAlive)
+	return lk, nil
+}
+
+// Close releases every held lock and rejects new ones.
+func (l *Locker) Close(ctx context.Context) error {
+	l.mu.Lock()
+	l.closed = true
+	held := make([]*lock, 0, len(l.held))
+	for lk := range l.held {
+		held = append(held, lk)
+	}
+	l.mu.Unlock()
+
+	var errs []error
+	for _, lk := range held {
+		if err := lk.end(ctx); err != nil && !errors.Is(err, database.ErrLockNotHeld) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type lock struct {
+	locker *Locker
+	name   string
+	h      holder
+	done   chan struct{}
+
+	mu    sync.Mutex
+	ended bool
+}
+
+func (lk *lock) Name() string          { return lk.name }
+func (lk *lock) Done() <-chan struct{} { return lk.done }
+
+func (lk *lock) Unlock(ctx context.Context) error {
+	return lk.end(ctx)
+}
+
+// end releases the lock exactly once; later calls report ErrLockNotHeld.
+func (lk *lock) end(ctx context.Context) error {
+	lk.mu.Lock()
+	if lk.ended {
+		lk.mu.Unlock()
+		return database.ErrLockNotHeld
+	}
+	lk.ended = true
+	lk.mu.Unlock()
+
+	releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+	defer cancel()
+	err := lk.h.release(releaseCtx)
+
+	lk.locker.mu.Lock()
+	delete(lk.locker.held, lk)
+	lk.locker.mu.Unlock()
+	close(lk.done)
+	lk.locker.onRelease(lk.name)
+	return err
+}
+
+// watch releases the lock when ctx is done and checks it is still held every
+// interval, releasing what is left of it once it is lost.
+func (lk *lock) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-lk.done:
+			return
+		case <-ctx.Done():
+			_ = lk.end(ctx)
+			return
+		case <-ticker.C:
+			if err := lk.h.keepAlive(ctx); err != nil {
+				_ = lk.end(ctx)
+				return
+			}
+		}
+	}
+}
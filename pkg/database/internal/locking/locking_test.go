@@ -0,0 +1,263 @@
+package locking
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+func TestNew_SelectsBackendByDriver(t *testing.T) {
+	pin := func(context.Context) (Conn, error) { return nil, nil }
+
+	l, err := New(Config{Driver: database.DriverPostgres, Pin: pin})
+	require.NoError(t, err)
+	require.IsType(t, &sessionBackend{}, l.backend)
+
+	l, err = New(Config{Driver: database.DriverCockroach, LeaseTTL: 9 * time.Second})
+	require.NoError(t, err)
+	require.IsType(t, &leaseBackend{}, l.backend)
+	require.Equal(t, 3*time.Second, l.keepAlive, "o lease é renovado a cada terço do TTL")
+
+	_, err = New(Config{Driver: database.DriverMySQL})
+	require.ErrorIs(t, err, database.ErrLockUnsupported, "locks de sessão exigem Pin")
+
+	_, err = New(Config{Driver: database.DriverSQLite})
+	require.ErrorIs(t, err, database.ErrLockUnsupported)
+}
+
+func TestLocker_TryLockExcludesOtherCallers(t *testing.T) {
+	l := NewLocal()
+	ctx := context.Background()
+
+	lock, ok, err := l.TryLock(ctx, "jobs")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "jobs", lock.Name())
+
+	other, ok, err := l.TryLock(ctx, "jobs")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Nil(t, other)
+
+	require.NoError(t, lock.Unlock(ctx))
+	require.ErrorIs(t, lock.Unlock(ctx), database.ErrLockNotHeld, "o segundo Unlock não libera nada")
+
+	_, ok, err = l.TryLock(ctx, "jobs")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestLocker_RejectsEmptyName(t *testing.T) {
+	_, _, err := NewLocal().TryLock(context.Background(), "")
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+}
+
+func TestLocker_LockWaitsForRelease(t *testing.T) {
+	l := NewLocal()
+	ctx := context.Background()
+	first, err := l.Lock(ctx, "jobs")
+	require.NoError(t, err)
+
+	acquired := make(chan database.Lock)
+	go func() {
+		lock, err := l.Lock(ctx, "jobs")
+		if err == nil {
+			acquired <- lock
+		}
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Lock não deve retornar enquanto o lock está ocupado")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	require.NoError(t, first.Unlock(ctx))
+	select {
+	case lock := <-acquired:
+		require.NoError(t, lock.Unlock(ctx))
+	case <-time.After(time.Second):
+		t.Fatal("Lock deve retornar após a liberação")
+	}
+}
+
+func TestLocker_LockHonoursContext(t *testing.T) {
+	l := NewLocal()
+	held, err := l.Lock(context.Background(), "jobs")
+	require.NoError(t, err)
+	defer held.Unlock(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.Lock(ctx, "jobs")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLocker_ReleasesWhenAcquireContextIsDone(t *testing.T) {
+	l := NewLocal()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	lock, err := l.Lock(ctx, "jobs")
+	require.NoError(t, err)
+	cancel()
+
+	select {
+	case <-lock.Done():
+	case <-time.After(time.Second):
+		t.Fatal("o lock deve ser liberado quando o contexto termina")
+	}
+	_, ok, err := l.TryLock(context.Background(), "jobs")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.ErrorIs(t, lock.Unlock(context.Background()), database.ErrLockNotHeld)
+}
+
+func TestLocker_CloseReleasesHeldLocks(t *testing.T) {
+	l := NewLocal()
+	ctx := context.Background()
+	a, err := l.Lock(ctx, "a")
+	require.NoError(t, err)
+	b, err := l.Lock(ctx, "b")
+	require.NoError(t, err)
+
+	require.NoError(t, l.Close(ctx))
+	require.True(t, isClosed(a.Done()))
+	require.True(t, isClosed(b.Done()))
+
+	_, err = l.Lock(ctx, "c")
+	require.ErrorIs(t, err, database.ErrManagerClosed)
+}
+
+// fakeBackend entrega holders cujo keepAlive o teste controla.
+type fakeBackend struct {
+	keepAliveErr error
+	released     int
+	mu           sync.Mutex
+}
+
+func (b *fakeBackend) acquire(context.Context, string, bool) (holder, error) { return b, nil }
+func (b *fakeBackend) keepAlive(context.Context) error                       { return b.keepAliveErr }
+func (b *fakeBackend) release(context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.released++
+	return nil
+}
+
+func TestLocker_KeepAliveFailureEndsLock(t *testing.T) {
+	backend := &fakeBackend{keepAliveErr: errors.New("connection reset")}
+	var releases []string
+	var mu sync.Mutex
+	l := newLocker(backend, time.Millisecond, func(name string) {
+		mu.Lock()
+		releases = append(releases, name)
+		mu.Unlock()
+	})
+
+	lock, err := l.Lock(context.Background(), "jobs")
+	require.NoError(t, err)
+
+	select {
+	case <-lock.Done():
+	case <-time.After(time.Second):
+		t.Fatal("a falha do keepAlive deve encerrar o lock")
+	}
+	require.ErrorIs(t, lock.Unlock(context.Background()), database.ErrLockNotHeld)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{"jobs"}, releases, "OnRelease é chamado uma única vez")
+}
+
+// fakeConn responde às funções de lock com resultados fixos.
+type fakeConn struct {
+	result    any
+	err       error
+	released  bool
+	destroyed bool
+}
+
+func (c *fakeConn) ExecContext(context.Context, string, ...any) (database.Result, error) {
+	return nil, c.err
+}
+func (c *fakeConn) QueryContext(context.Context, string, ...any) (database.Rows, error) {
+	return nil, c.err
+}
+func (c *fakeConn) QueryRowContext(context.Context, string, ...any) database.Row {
+	return fakeRow{value: c.result, err: c.err}
+}
+func (c *fakeConn) Release() { c.released = true }
+func (c *fakeConn) Destroy() { c.destroyed = true }
+
+type fakeRow struct {
+	value any
+	err   error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	switch d := dest[0].(type) {
+	case *bool:
+		*d = r.value.(bool)
+	case *int:
+		*d = r.value.(int)
+	}
+	return nil
+}
+
+func TestSessionBackend_ReleasesConnWhenBusy(t *testing.T) {
+	conn := &fakeConn{result: false}
+	b := &sessionBackend{pin: func(context.Context) (Conn, error) { return conn, nil }, dialect: sessionDialects[database.DriverPostgres]}
+
+	h, err := b.acquire(context.Background(), "jobs", false)
+	require.NoError(t, err)
+	require.Nil(t, h)
+	require.True(t, conn.released)
+	require.False(t, conn.destroyed)
+}
+
+func TestSessionBackend_DestroysConnOnError(t *testing.T) {
+	conn := &fakeConn{err: context.Canceled}
+	b := &sessionBackend{pin: func(context.Context) (Conn, error) { return conn, nil }, dialect: sessionDialects[database.DriverMSSQL]}
+
+	_, err := b.acquire(context.Background(), "jobs", true)
+	require.ErrorIs(t, err, context.Canceled)
+	require.True(t, conn.destroyed, "a sessão pode ter recebido o lock e deve ser descartada")
+}
+
+func TestSessionHold_ReleaseReturnsConnToPool(t *testing.T) {
+	conn := &fakeConn{result: true}
+	b := &sessionBackend{pin: func(context.Context) (Conn, error) { return conn, nil }, dialect: sessionDialects[database.DriverPostgres]}
+
+	h, err := b.acquire(context.Background(), "jobs", false)
+	require.NoError(t, err)
+	require.NotNil(t, h)
+	require.NoError(t, h.release(context.Background()))
+	require.True(t, conn.released)
+}
+
+func TestMySQLLockName_HashesLongNames(t *testing.T) {
+	require.Equal(t, "jobs", mysqlLockName("jobs"))
+
+	long := strings.Repeat("x", 65)
+	require.Len(t, mysqlLockName(long), 64)
+	require.NotEqual(t, mysqlLockName(long), mysqlLockName(long+"y"))
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
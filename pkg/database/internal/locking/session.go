@@ -0,0 +1,194 @@
+package locking
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+// sessionDialect runs the lock statements of one driver. Session locks live
+// as long as the connection that took them.
+type sessionDialect struct {
+	try    func(ctx context.Context, db database.DBTX, name string) (bool, error)
+	wait   func(ctx context.Context, db database.DBTX, name string) error
+	unlock func(ctx context.Context, db database.DBTX, name string) error
+}
+
+var sessionDialects = map[database.Driver]sessionDialect{
+	database.DriverPostgres: {
+		try: func(ctx context.Context, db database.DBTX, name string) (bool, error) {
+			var ok bool
+			err := db.QueryRowContext(ctx, "SELECT pg_try_advisory_lock(hashtextextended($1, 0))", name).Scan(&ok)
+			return ok, err
+		},
+		wait: func(ctx context.Context, db database.DBTX, name string) error {
+			_, err := db.ExecContext(ctx, "SELECT pg_advisory_lock(hashtextextended($1, 0))", name)
+			return err
+		},
+		unlock: func(ctx context.Context, db database.DBTX, name string) error {
+			var ok bool
+			if err := db.QueryRowContext(ctx, "SELECT pg_advisory_unlock(hashtextextended($1, 0))", name).Scan(&ok); err != nil {
+				return err
+			}
+			if !ok {
+				return database.ErrLockNotHeld
+			}
+			return nil
+		},
+	},
+	database.DriverMySQL: {
+		try: func(ctx context.Context, db database.DBTX, name string) (bool, error) {
+			return mysqlGetLock(ctx, db, name, 0)
+		},
+		wait: func(ctx context.Context, db database.DBTX, name string) error {
+			ok, err := mysqlGetLock(ctx, db, name, -1)
+			if err == nil && !ok {
+				err = fmt.Errorf("locking: GET_LOCK(%q) did not acquire the lock", name)
+			}
+			return err
+		},
+		unlock: func(ctx context.Context, db database.DBTX, name string) error {
+			var released sql.NullInt64
+			if err := db.QueryRowContext(ctx, "SELECT RELEASE_LOCK(?)", mysqlLockName(name)).Scan(&released); err != nil {
+				return err
+			}
+			if released.Int64 != 1 {
+				return database.ErrLockNotHeld
+			}
+			return nil
+		},
+	},
+	database.DriverMSSQL: {
+		try: func(ctx context.Context, db database.DBTX, name string) (bool, error) {
+			return mssqlGetAppLock(ctx, db, name, 0)
+		},
+		wait: func(ctx context.Context, db database.DBTX, name string) error {
+			ok, err := mssqlGetAppLock(ctx, db, name, -1)
+			if err == nil && !ok {
+				err = fmt.Errorf("locking: sp_getapplock(%q) did not acquire the lock", name)
+			}
+			return err
+		},
+		unlock: func(ctx context.Context, db database.DBTX, name string) error {
+			var status int
+			err := db.QueryRowContext(ctx,
+				"DECLARE @r int; EXEC @r = sp_releaseapplock @Resource = @p1, @LockOwner = 'Session'; SELECT @r",
+				name,
+			).Scan(&status)
+			if err != nil {
+				return err
+			}
+			if status != 0 {
+				return database.ErrLockNotHeld
+			}
+			return nil
+		},
+	},
+}
+
+// mysqlGetLock runs GET_LOCK, which returns 1 when acquired, 0 on timeout
+// and NULL on error. A negative timeout waits forever.
+func mysqlGetLock(ctx context.Context, db database.DBTX, name string, timeout int) (bool, error) {
+	var acquired sql.NullInt64
+	if err := db.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", mysqlLockName(name), timeout).Scan(&acquired); err != nil {
+		return false, err
+	}
+	if !acquired.Valid {
+		return false, fmt.Errorf("locking: GET_LOCK(%q) failed", name)
+	}
+	return acquired.Int64 == 1, nil
+}
+
+// mysqlLockName hashes names longer than the 64 characters GET_LOCK accepts.
+func mysqlLockName(name string) string {
+	if len(name) <= 64 {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:])
+}
+
+// mssqlGetAppLock runs sp_getapplock, whose status is >= 0 when acquired, -1
+// on timeout and lower on error. A negative timeout waits forever.
+func mssqlGetAppLock(ctx context.Context, db database.DBTX, name string, timeout int) (bool, error) {
+	var status int
+	err := db.QueryRowContext(ctx,
+		"DECLARE @r int; EXEC @r = sp_getapplock @Resource = @p1, @LockMode = 'Exclusive', @LockOwner = 'Session', @LockTimeout = @p2; SELECT @r",
+		name, timeout,
+	).Scan(&status)
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case status >= 0:
+		return true, nil
+	case status == -1:
+		return false, nil
+	default:
+		return false, fmt.Errorf("locking: sp_getapplock(%q) returned %d", name, status)
+	}
+}
+
+type sessionBackend struct {
+	pin     func(ctx context.Context) (Conn, error)
+	dialect sessionDialect
+}
+
+func (b *sessionBackend) acquire(ctx context.Context, name string, wait bool) (holder, error) {
+	conn, err := b.pin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ok := true
+	if wait {
+		err = b.dialect.wait(ctx, conn, name)
+	} else {
+		ok, err = b.dialect.try(ctx, conn, name)
+	}
+	if err != nil {
+		// The server may have granted the lock as the wait was cancelled;
+		// dropping the session guarantees it is not left behind.
+		conn.Destroy()
+		return nil, err
+	}
+	if !ok {
+		conn.Release()
+		return nil, nil
+	}
+	return &sessionHold{conn: conn, name: name, dialect: b.dialect}, nil
+}
+
+// sessionHold serializes keep-alives and the release on its connection,
+// which is not safe for concurrent use.
+type sessionHold struct {
+	mu      sync.Mutex
+	conn    Conn
+	name    string
+	dialect sessionDialect
+}
+
+func (h *sessionHold) keepAlive(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.conn.ExecContext(ctx, "SELECT 1")
+	return err
+}
+
+func (h *sessionHold) release(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	err := h.dialect.unlock(ctx, h.conn, h.name)
+	if err != nil && !errors.Is(err, database.ErrLockNotHeld) {
+		h.conn.Destroy()
+		return err
+	}
+	h.conn.Release()
+	return err
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/JailtonJunior94/devkit-go/pkg/database"
 	internalpool "github.com/JailtonJunior94/devkit-go/pkg/database/internal/pool"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/session"
 	"github.com/JailtonJunior94/devkit-go/pkg/observability"
 )
 
@@ -33,6 +34,8 @@ type OpenParams struct {
 	InfoFn        func(*pgx.ConnConfig) internalpool.ConnInfo
 	PingTimeout   time.Duration
 	Observability observability.Observability
+	// Credentials is consulted before every new connection.
+	Credentials database.CredentialsProvider
 }
 
 type Adapter struct {
@@ -54,6 +57,9 @@ func Open(p OpenParams) (*Adapter, error) {
 		p.ApplyDefaults(poolCfg)
 	}
 	applySettings(poolCfg, p.Settings)
+	if p.Credentials != nil {
+		poolCfg.BeforeConnect = beforeConnect(p.Driver, p.Credentials)
+	}
 
 	pool, err := pgxpool.NewWithConfig(context.Background(), poolCfg)
 	if err != nil {
@@ -95,6 +101,22 @@ func Open(p OpenParams) (*Adapter, error) {
 	return a, nil
 }
 
+func beforeConnect(driver database.Driver, provider database.CredentialsProvider) func(context.Context, *pgx.ConnConfig) error {
+	return func(ctx context.Context, cfg *pgx.ConnConfig) error {
+		creds, err := provider.Credentials(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: credentials: %w", driver, err)
+		}
+		if creds.User != "" {
+			cfg.User = creds.User
+		}
+		if creds.Password != "" {
+			cfg.Password = creds.Password
+		}
+		return nil
+	}
+}
+
 func applySettings(cfg *pgxpool.Config, s ConnSettings) {
 	if s.MaxOpenConns > 0 {
 		cfg.MaxConns = int32(s.MaxOpenConns)
@@ -123,11 +145,29 @@ func (a *Adapter) BeginTx(ctx context.Context, opts database.TxOptions) (databas
 	if opts.ReadOnly {
 		pgxOpts.AccessMode = pgx.ReadOnly
 	}
-	tx, err := a.pool.BeginTx(ctx, pgxOpts)
+	if opts.Session == nil {
+		tx, err := a.pool.BeginTx(ctx, pgxOpts)
+		if err != nil {
+			return nil, fmt.Errorf("%s: begin tx: %w", a.driver, err)
+		}
+		return &Tx{tx: tx}, nil
+	}
+
+	conn, err := a.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: begin tx: %w", a.driver, err)
+	}
+	tx, err := session.Begin(ctx, &PinnedConn{conn: conn}, opts.Session, func(ctx context.Context) (database.Tx, error) {
+		tx, err := conn.BeginTx(ctx, pgxOpts)
+		if err != nil {
+			return nil, err
+		}
+		return &Tx{tx: tx}, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: begin tx: %w", a.driver, err)
 	}
-	return &Tx{tx: tx}, nil
+	return tx, nil
 }
 
 func (a *Adapter) Stats() internalpool.Stats {
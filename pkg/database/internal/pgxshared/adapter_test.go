@@ -0,0 +1,35 @@
+package pgxshared
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+func TestBeforeConnect_AppliesProviderCredentials(t *testing.T) {
+	calls := 0
+	hook := beforeConnect(database.DriverPostgres, database.CredentialsFunc(func(context.Context) (database.Credentials, error) {
+		calls++
+		return database.Credentials{Password: "rotated"}, nil
+	}))
+
+	cfg := &pgx.ConnConfig{}
+	cfg.User, cfg.Password = "app", "expired"
+	require.NoError(t, hook(context.Background(), cfg))
+	require.Equal(t, "app", cfg.User, "campo vazio mantém o valor da config")
+	require.Equal(t, "rotated", cfg.Password)
+	require.Equal(t, 1, calls)
+}
+
+func TestBeforeConnect_PropagatesProviderError(t *testing.T) {
+	boom := errors.New("vault sealed")
+	hook := beforeConnect(database.DriverPostgres, database.CredentialsFunc(func(context.Context) (database.Credentials, error) {
+		return database.Credentials{}, boom
+	}))
+	require.ErrorIs(t, hook(context.Background(), &pgx.ConnConfig{}), boom)
+}
@@ -0,0 +1,52 @@
+package pgxshared
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/locking"
+)
+
+var _ locking.Pinner = (*Adapter)(nil)
+
+// PinnedConn is a DBTX pinned to one pooled connection.
+type PinnedConn struct {
+	conn *pgxpool.Conn
+}
+
+// Conn pins a connection of the pool until it is released.
+func (a *Adapter) Conn(ctx context.Context) (locking.Conn, error) {
+	conn, err := a.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &PinnedConn{conn: conn}, nil
+}
+
+func (c *PinnedConn) ExecContext(ctx context.Context, query string, args ...any) (database.Result, error) {
+	tag, err := c.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return Result{tag: tag}, nil
+}
+
+func (c *PinnedConn) QueryContext(ctx context.Context, query string, args ...any) (database.Rows, error) {
+	rows, err := c.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &Rows{rows: rows}, nil
+}
+
+func (c *PinnedConn) QueryRowContext(ctx context.Context, query string, args ...any) database.Row {
+	return &Row{row: c.conn.QueryRow(ctx, query, args...)}
+}
+
+func (c *PinnedConn) Release() { c.conn.Release() }
+
+func (c *PinnedConn) Destroy() {
+	_ = c.conn.Hijack().Close(context.Background())
+}
@@ -0,0 +1,31 @@
+package pgxshared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/dialect"
+)
+
+type copyFromer interface {
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+func (p *PoolDBTX) CopyFrom(ctx context.Context, table string, columns []string, src database.CopySource) (int64, error) {
+	return copyFrom(ctx, p.pool, table, columns, src)
+}
+
+func (t *Tx) CopyFrom(ctx context.Context, table string, columns []string, src database.CopySource) (int64, error) {
+	return copyFrom(ctx, t.tx, table, columns, src)
+}
+
+func copyFrom(ctx context.Context, c copyFromer, table string, columns []string, src database.CopySource) (int64, error) {
+	if !dialect.ValidIdentifier(table) {
+		return 0, fmt.Errorf("%w: invalid copy table %q", database.ErrInvalidConfig, table)
+	}
+	return c.CopyFrom(ctx, pgx.Identifier(strings.Split(table, ".")), columns, src)
+}
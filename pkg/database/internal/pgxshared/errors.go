@@ -0,0 +1,62 @@
+package pgxshared
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+const (
+	sqlStateNotNullViolation     = "23502"
+	sqlStateForeignKeyViolation  = "23503"
+	sqlStateUniqueViolation      = "23505"
+	sqlStateCheckViolation       = "23514"
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+	sqlStateAdminShutdown        = "57P01"
+	sqlStateCrashShutdown        = "57P02"
+	sqlStateCannotConnectNow     = "57P03"
+	sqlStateClassConnection      = "08"
+)
+
+func init() {
+	database.RegisterErrorClassifier(classifyError)
+}
+
+func classifyError(err error) (database.ErrorInfo, bool) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		var connErr *pgconn.ConnectError
+		if errors.As(err, &connErr) {
+			return database.ErrorInfo{Kind: database.ErrorKindConnection}, true
+		}
+		return database.ErrorInfo{}, false
+	}
+
+	info := database.ErrorInfo{Constraint: pgErr.ConstraintName}
+	switch pgErr.Code {
+	case sqlStateUniqueViolation:
+		info.Kind = database.ErrorKindUniqueViolation
+	case sqlStateForeignKeyViolation:
+		info.Kind = database.ErrorKindForeignKeyViolation
+	case sqlStateNotNullViolation:
+		info.Kind = database.ErrorKindNotNullViolation
+	case sqlStateCheckViolation:
+		info.Kind = database.ErrorKindCheckViolation
+	case sqlStateSerializationFailure:
+		info.Kind = database.ErrorKindSerializationFailure
+	case sqlStateDeadlockDetected:
+		info.Kind = database.ErrorKindDeadlock
+	case sqlStateAdminShutdown, sqlStateCrashShutdown, sqlStateCannotConnectNow:
+		info.Kind = database.ErrorKindConnection
+	default:
+		if !strings.HasPrefix(pgErr.Code, sqlStateClassConnection) {
+			return database.ErrorInfo{}, false
+		}
+		info.Kind = database.ErrorKindConnection
+	}
+	return info, true
+}
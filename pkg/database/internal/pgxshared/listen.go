@@ -0,0 +1,95 @@
+package pgxshared
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+var _ database.Listener = (*Adapter)(nil)
+
+// Listen pins a connection of the pool and runs LISTEN for every channel on
+// it. Only postgres supports it; cockroach has no LISTEN/NOTIFY.
+func (a *Adapter) Listen(ctx context.Context, channels ...string) (database.Subscription, error) {
+	if a.driver != database.DriverPostgres {
+		return nil, fmt.Errorf("%w: %s", database.ErrListenUnsupported, a.driver)
+	}
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("%w: listen requires at least one channel", database.ErrInvalidConfig)
+	}
+
+	conn, err := a.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, channel := range channels {
+		if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			_ = conn.Hijack().Close(context.WithoutCancel(ctx))
+			return nil, fmt.Errorf("listen %q: %w", channel, err)
+		}
+	}
+
+	closeCtx, cancel := context.WithCancel(context.Background())
+	return &Subscription{conn: conn, closeCtx: closeCtx, cancel: cancel}, nil
+}
+
+// Subscription waits for notifications on its pinned connection. mu is held
+// by Next while it waits, so Close cancels the wait before cleaning up.
+type Subscription struct {
+	conn     *pgxpool.Conn
+	closeCtx context.Context
+	cancel   context.CancelFunc
+
+	mu       sync.Mutex
+	released bool
+}
+
+func (s *Subscription) Next(ctx context.Context) (database.Notification, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(s.closeCtx, cancel)
+	defer stop()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.released {
+		return database.Notification{}, database.ErrSubscriptionClosed
+	}
+	n, err := s.conn.Conn().WaitForNotification(ctx)
+	if err != nil {
+		if s.closeCtx.Err() != nil {
+			return database.Notification{}, database.ErrSubscriptionClosed
+		}
+		return database.Notification{}, err
+	}
+	return database.Notification{Channel: n.Channel, Payload: n.Payload, PID: n.PID}, nil
+}
+
+// Close runs UNLISTEN * so the connection goes back to the pool clean. A
+// connection broken by a cancelled wait is discarded by the pool instead.
+func (s *Subscription) Close(ctx context.Context) error {
+	s.cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.released {
+		return nil
+	}
+	s.released = true
+
+	if s.conn.Conn().IsClosed() {
+		s.conn.Release()
+		return nil
+	}
+	if _, err := s.conn.Exec(ctx, "UNLISTEN *"); err != nil {
+		_ = s.conn.Hijack().Close(context.WithoutCancel(ctx))
+		return err
+	}
+	s.conn.Release()
+	return nil
+}
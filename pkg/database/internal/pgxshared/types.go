@@ -27,6 +27,15 @@ func (r *Rows) Scan(dest ...any) error { return r.rows.Scan(dest...) }
 func (r *Rows) Err() error             { return r.rows.Err() }
 func (r *Rows) Close() error           { r.rows.Close(); return nil }
 
+func (r *Rows) Columns() ([]string, error) {
+	fields := r.rows.FieldDescriptions()
+	columns := make([]string, len(fields))
+	for i, f := range fields {
+		columns[i] = f.Name
+	}
+	return columns, nil
+}
+
 type Row struct {
 	row pgx.Row
 }
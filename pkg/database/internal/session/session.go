@@ -0,0 +1,75 @@
+// Package session runs transactions that carry a database.Session on a
+// pinned connection, shared by the driver adapters.
+package session
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/locking"
+)
+
+// exitTimeout bounds Exit, which runs after the caller's context may have
+// ended.
+const exitTimeout = 5 * time.Second
+
+// Begin enters s on conn, then begins the transaction on conn with begin.
+// conn is released once the returned transaction ends, or destroyed when s
+// cannot be entered or exited.
+func Begin(ctx context.Context, conn locking.Conn, s database.Session, begin func(ctx context.Context) (database.Tx, error)) (database.Tx, error) {
+	if err := s.Enter(ctx, conn); err != nil {
+		conn.Destroy()
+		return nil, fmt.Errorf("enter session: %w", err)
+	}
+	tx, err := begin(ctx)
+	if err != nil {
+		exit(ctx, conn, s)
+		return nil, err
+	}
+	return &Tx{Tx: tx, conn: conn, session: s}, nil
+}
+
+// Tx exits its session after COMMIT or ROLLBACK. The connection is only
+// handed back to the pool then, so a rollback done by database/sql when the
+// BeginTx context ends cannot release it with the session still applied.
+type Tx struct {
+	database.Tx
+	conn    locking.Conn
+	session database.Session
+	once    sync.Once
+}
+
+// Commit reports the outcome of COMMIT. A failed Exit does not turn a
+// committed transaction into an error; the connection is destroyed instead.
+func (t *Tx) Commit(ctx context.Context) error {
+	err := t.Tx.Commit(ctx)
+	t.end(ctx)
+	return err
+}
+
+func (t *Tx) Rollback(ctx context.Context) error {
+	err := t.Tx.Rollback(ctx)
+	t.end(ctx)
+	return err
+}
+
+func (t *Tx) CopyFrom(ctx context.Context, table string, columns []string, src database.CopySource) (int64, error) {
+	return database.CopyFrom(ctx, t.Tx, table, columns, src)
+}
+
+func (t *Tx) end(ctx context.Context) {
+	t.once.Do(func() { exit(ctx, t.conn, t.session) })
+}
+
+func exit(ctx context.Context, conn locking.Conn, s database.Session) {
+	exitCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), exitTimeout)
+	defer cancel()
+	if err := s.Exit(exitCtx, conn); err != nil {
+		conn.Destroy()
+		return
+	}
+	conn.Release()
+}
@@ -3,11 +3,13 @@ package sqlshared
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"time"
 
 	"github.com/JailtonJunior94/devkit-go/pkg/database"
 	internalpool "github.com/JailtonJunior94/devkit-go/pkg/database/internal/pool"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/session"
 	"github.com/JailtonJunior94/devkit-go/pkg/observability"
 )
 
@@ -29,6 +31,11 @@ type OpenParams struct {
 	Info          internalpool.ConnInfo
 	PingTimeout   time.Duration
 	Observability observability.Observability
+	// Copy backs database.Copier; nil leaves bulk copy unsupported.
+	Copy CopyFunc
+	// Connector, when set, opens the connections instead of DriverName and
+	// DSN; drivers use it to apply credentials per connection.
+	Connector driver.Connector
 }
 
 type Adapter struct {
@@ -37,10 +44,11 @@ type Adapter struct {
 	driver   database.Driver
 	info     internalpool.ConnInfo
 	scraper  *internalpool.Scraper
+	copy     CopyFunc
 }
 
 func Open(p OpenParams) (*Adapter, error) {
-	db, err := sql.Open(p.DriverName, p.DSN)
+	db, err := openDB(p)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s: invalid DSN/config", database.ErrInvalidConfig, p.Driver)
 	}
@@ -64,8 +72,8 @@ func Open(p OpenParams) (*Adapter, error) {
 	info := p.Info
 	info.Driver = string(p.Driver)
 
-	a := &Adapter{db: db, driver: p.Driver, info: info}
-	a.poolDBTX = &PoolDBTX{db: db}
+	a := &Adapter{db: db, driver: p.Driver, info: info, copy: p.Copy}
+	a.poolDBTX = &PoolDBTX{db: db, driver: p.Driver, copy: p.Copy}
 	if p.Observability != nil {
 		a.scraper = internalpool.NewScraper(
 			a.Stats,
@@ -77,6 +85,13 @@ func Open(p OpenParams) (*Adapter, error) {
 	return a, nil
 }
 
+func openDB(p OpenParams) (*sql.DB, error) {
+	if p.Connector != nil {
+		return sql.OpenDB(p.Connector), nil
+	}
+	return sql.Open(p.DriverName, p.DSN)
+}
+
 func applySettings(db *sql.DB, s ConnSettings) {
 	if s.MaxOpenConns > 0 {
 		db.SetMaxOpenConns(s.MaxOpenConns)
@@ -101,11 +116,30 @@ func (a *Adapter) BeginTx(ctx context.Context, opts database.TxOptions) (databas
 		Isolation: sql.IsolationLevel(opts.Isolation),
 		ReadOnly:  opts.ReadOnly,
 	}
-	tx, err := a.db.BeginTx(ctx, sqlOpts)
+	if opts.Session == nil {
+		tx, err := a.db.BeginTx(ctx, sqlOpts)
+		if err != nil {
+			return nil, fmt.Errorf("%s: begin tx: %w", a.driver, err)
+		}
+		return &Tx{tx: tx, driver: a.driver, copy: a.copy}, nil
+	}
+
+	conn, err := a.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: begin tx: %w", a.driver, err)
+	}
+	pinned := &PinnedConn{conn: conn}
+	tx, err := session.Begin(ctx, pinned, opts.Session, func(ctx context.Context) (database.Tx, error) {
+		tx, err := conn.BeginTx(ctx, sqlOpts)
+		if err != nil {
+			return nil, err
+		}
+		return &Tx{tx: tx, driver: a.driver, copy: a.copy}, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: begin tx: %w", a.driver, err)
 	}
-	return &Tx{tx: tx}, nil
+	return tx, nil
 }
 
 func (a *Adapter) Stats() internalpool.Stats {
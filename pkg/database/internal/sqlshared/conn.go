@@ -0,0 +1,54 @@
+package sqlshared
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/locking"
+)
+
+var _ locking.Pinner = (*Adapter)(nil)
+
+// PinnedConn is a DBTX pinned to one pooled connection.
+type PinnedConn struct {
+	conn *sql.Conn
+}
+
+// Conn pins a connection of the pool until it is released.
+func (a *Adapter) Conn(ctx context.Context) (locking.Conn, error) {
+	conn, err := a.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &PinnedConn{conn: conn}, nil
+}
+
+func (c *PinnedConn) ExecContext(ctx context.Context, query string, args ...any) (database.Result, error) {
+	res, err := c.conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return Result{res: res}, nil
+}
+
+func (c *PinnedConn) QueryContext(ctx context.Context, query string, args ...any) (database.Rows, error) {
+	rows, err := c.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &Rows{rows: rows}, nil
+}
+
+func (c *PinnedConn) QueryRowContext(ctx context.Context, query string, args ...any) database.Row {
+	return &Row{row: c.conn.QueryRowContext(ctx, query, args...)}
+}
+
+func (c *PinnedConn) Release() { _ = c.conn.Close() }
+
+// Destroy makes database/sql discard the connection instead of pooling it.
+func (c *PinnedConn) Destroy() {
+	_ = c.conn.Raw(func(any) error { return driver.ErrBadConn })
+	_ = c.conn.Close()
+}
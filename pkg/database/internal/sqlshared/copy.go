@@ -0,0 +1,147 @@
+package sqlshared
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/dialect"
+)
+
+// DefaultCopyBatchSize is the largest number of rows BatchInsert sends in a
+// single INSERT.
+const DefaultCopyBatchSize = 500
+
+// Conn is the subset of *sql.Conn and *sql.Tx a CopyFunc needs. Copies always
+// run on a single connection.
+type Conn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// CopyFunc implements database.Copier for a database/sql driver. table and
+// columns have already been validated as identifiers.
+type CopyFunc func(ctx context.Context, conn Conn, table string, columns []string, src database.CopySource) (int64, error)
+
+func (d *PoolDBTX) CopyFrom(ctx context.Context, table string, columns []string, src database.CopySource) (int64, error) {
+	if d.copy == nil {
+		return 0, fmt.Errorf("%w: %s", database.ErrCopyUnsupported, d.driver)
+	}
+	if err := validateCopyTarget(table, columns); err != nil {
+		return 0, err
+	}
+	conn, err := d.db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	return d.copy(ctx, conn, table, columns, src)
+}
+
+func (t *Tx) CopyFrom(ctx context.Context, table string, columns []string, src database.CopySource) (int64, error) {
+	if t.copy == nil {
+		return 0, fmt.Errorf("%w: %s", database.ErrCopyUnsupported, t.driver)
+	}
+	if err := validateCopyTarget(table, columns); err != nil {
+		return 0, err
+	}
+	return t.copy(ctx, t.tx, table, columns, src)
+}
+
+func validateCopyTarget(table string, columns []string) error {
+	if !dialect.ValidIdentifier(table) {
+		return fmt.Errorf("%w: invalid copy table %q", database.ErrInvalidConfig, table)
+	}
+	for _, c := range columns {
+		if !dialect.ValidIdentifier(c) || strings.Contains(c, ".") {
+			return fmt.Errorf("%w: invalid copy column %q", database.ErrInvalidConfig, c)
+		}
+	}
+	return nil
+}
+
+// BatchInsert returns a CopyFunc that writes rows with multi-row INSERT
+// statements, quoting identifiers with quote. Each statement carries at most
+// DefaultCopyBatchSize rows and maxParams bind arguments.
+func BatchInsert(driver database.Driver, quote func(string) string, maxParams int) CopyFunc {
+	return func(ctx context.Context, conn Conn, table string, columns []string, src database.CopySource) (int64, error) {
+		size := max(1, min(DefaultCopyBatchSize, maxParams/len(columns)))
+		prefix := insertPrefix(quote, table, columns)
+
+		var total int64
+		args := make([]any, 0, size*len(columns))
+		rows := 0
+		flush := func() error {
+			if rows == 0 {
+				return nil
+			}
+			res, err := conn.ExecContext(ctx, prefix+valuesClause(driver, len(columns), rows), args...)
+			if err != nil {
+				return err
+			}
+			n, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			total += n
+			args = args[:0]
+			rows = 0
+			return nil
+		}
+
+		for src.Next() {
+			values, err := src.Values()
+			if err != nil {
+				return total, err
+			}
+			if len(values) != len(columns) {
+				return total, fmt.Errorf("%s: copy row has %d values, want %d", driver, len(values), len(columns))
+			}
+			args = append(args, values...)
+			rows++
+			if rows == size {
+				if err := flush(); err != nil {
+					return total, err
+				}
+			}
+		}
+		if err := src.Err(); err != nil {
+			return total, err
+		}
+		return total, flush()
+	}
+}
+
+func insertPrefix(quote func(string) string, table string, columns []string) string {
+	parts := strings.Split(table, ".")
+	for i, p := range parts {
+		parts[i] = quote(p)
+	}
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = quote(c)
+	}
+	return "INSERT INTO " + strings.Join(parts, ".") + " (" + strings.Join(quoted, ", ") + ") VALUES "
+}
+
+func valuesClause(driver database.Driver, columns, rows int) string {
+	var b strings.Builder
+	n := 1
+	for r := range rows {
+		if r > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for c := range columns {
+			if c > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(driver.Placeholder(n))
+			n++
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
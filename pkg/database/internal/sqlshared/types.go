@@ -22,6 +22,8 @@ func (r *Rows) Scan(dest ...any) error { return r.rows.Scan(dest...) }
 func (r *Rows) Err() error             { return r.rows.Err() }
 func (r *Rows) Close() error           { return r.rows.Close() }
 
+func (r *Rows) Columns() ([]string, error) { return r.rows.Columns() }
+
 type Row struct {
 	row *sql.Row
 }
@@ -29,7 +31,9 @@ type Row struct {
 func (r *Row) Scan(dest ...any) error { return r.row.Scan(dest...) }
 
 type PoolDBTX struct {
-	db *sql.DB
+	db     *sql.DB
+	driver database.Driver
+	copy   CopyFunc
 }
 
 func (d *PoolDBTX) ExecContext(ctx context.Context, query string, args ...any) (database.Result, error) {
@@ -53,7 +57,9 @@ func (d *PoolDBTX) QueryRowContext(ctx context.Context, query string, args ...an
 }
 
 type Tx struct {
-	tx *sql.Tx
+	tx     *sql.Tx
+	driver database.Driver
+	copy   CopyFunc
 }
 
 func (t *Tx) ExecContext(ctx context.Context, query string, args ...any) (database.Result, error) {
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrLockUnsupported is returned by Locker methods of drivers without
+	// distributed locks.
+	ErrLockUnsupported = errors.New("database: locks not supported by driver")
+	// ErrLockNotHeld is returned by Unlock when the lock was already
+	// released, or lost together with the session holding it.
+	ErrLockNotHeld = errors.New("database: lock not held")
+)
+
+// Lock is a named lock held exclusively across every process connected to
+// the same database. It is released by Unlock, when the context it was
+// acquired with is cancelled, or when the session holding it is lost; Done
+// is closed in all three cases.
+type Lock interface {
+	Name() string
+	Done() <-chan struct{}
+	Unlock(ctx context.Context) error
+}
+
+// Locker acquires distributed locks by name.
+type Locker interface {
+	// TryLock acquires name without waiting. ok is false when another
+	// session holds it.
+	TryLock(ctx context.Context, name string) (lock Lock, ok bool, err error)
+	// Lock waits until name is acquired or ctx is done.
+	Lock(ctx context.Context, name string) (Lock, error)
+}
+
+// WithLock runs fn while holding name, waiting for it first. The context
+// passed to fn is cancelled if the lock is lost before fn returns, in which
+// case the returned error also matches ErrLockNotHeld.
+func WithLock(ctx context.Context, l Locker, name string, fn func(ctx context.Context) error) error {
+	lock, err := l.Lock(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	fnCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-lock.Done():
+			cancel()
+		case <-fnCtx.Done():
+		}
+	}()
+
+	fnErr := fn(fnCtx)
+	cancel()
+	return errors.Join(fnErr, lock.Unlock(context.WithoutCancel(ctx)))
+}
@@ -0,0 +1,98 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/locking"
+)
+
+// lostLock é um lock cuja perda o teste controla fechando done.
+type lostLock struct {
+	done     chan struct{}
+	unlocked bool
+}
+
+func (l *lostLock) Name() string          { return "jobs" }
+func (l *lostLock) Done() <-chan struct{} { return l.done }
+func (l *lostLock) Unlock(context.Context) error {
+	l.unlocked = true
+	select {
+	case <-l.done:
+		return database.ErrLockNotHeld
+	default:
+		close(l.done)
+		return nil
+	}
+}
+
+type lostLocker struct{ lock *lostLock }
+
+func (l *lostLocker) TryLock(context.Context, string) (database.Lock, bool, error) {
+	return l.lock, true, nil
+}
+func (l *lostLocker) Lock(context.Context, string) (database.Lock, error) { return l.lock, nil }
+
+func TestWithLock_HoldsLockWhileFnRuns(t *testing.T) {
+	locker := locking.NewLocal()
+	ctx := context.Background()
+
+	err := database.WithLock(ctx, locker, "jobs", func(context.Context) error {
+		_, ok, err := locker.TryLock(ctx, "jobs")
+		require.NoError(t, err)
+		require.False(t, ok, "o lock deve estar ocupado durante fn")
+		return nil
+	})
+	require.NoError(t, err)
+
+	lock, ok, err := locker.TryLock(ctx, "jobs")
+	require.NoError(t, err)
+	require.True(t, ok, "o lock deve ser liberado ao fim de fn")
+	require.NoError(t, lock.Unlock(ctx))
+}
+
+func TestWithLock_ReturnsFnErrorAndReleases(t *testing.T) {
+	locker := locking.NewLocal()
+	boom := errors.New("boom")
+
+	err := database.WithLock(context.Background(), locker, "jobs", func(context.Context) error { return boom })
+	require.ErrorIs(t, err, boom)
+
+	_, ok, err := locker.TryLock(context.Background(), "jobs")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestWithLock_CancelsFnWhenLockIsLost(t *testing.T) {
+	lock := &lostLock{done: make(chan struct{})}
+
+	err := database.WithLock(context.Background(), &lostLocker{lock: lock}, "jobs", func(ctx context.Context) error {
+		close(lock.done)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, err, database.ErrLockNotHeld)
+	require.True(t, lock.unlocked)
+}
+
+func TestWithLock_LockErrorSkipsFn(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	locker := locking.NewLocal()
+	held, err := locker.Lock(context.Background(), "jobs")
+	require.NoError(t, err)
+	defer held.Unlock(context.Background())
+
+	called := false
+	err = database.WithLock(ctx, locker, "jobs", func(context.Context) error {
+		called = true
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.False(t, called)
+}
@@ -0,0 +1,48 @@
+package manager
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	databasePkgPrefix = "github.com/JailtonJunior94/devkit-go/pkg/database"
+	maxCallerDepth    = 32
+)
+
+// captureCallers records the current stack cheaply; frames are only resolved
+// when a slow query is actually logged.
+func captureCallers() []uintptr {
+	pcs := make([]uintptr, maxCallerDepth)
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
+}
+
+// resolveCaller returns "file:line" of the first frame outside the database
+// toolkit, which is the application code that issued the statement. Test
+// files count as application code.
+func resolveCaller(pcs []uintptr) string {
+	if len(pcs) == 0 {
+		return ""
+	}
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if strings.HasSuffix(frame.File, "_test.go") || !isToolkitFrame(frame.Function) {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func isToolkitFrame(function string) bool {
+	pkg := function
+	slash := strings.LastIndexByte(function, '/')
+	if dot := strings.IndexByte(function[slash+1:], '.'); dot >= 0 {
+		pkg = function[:slash+1+dot]
+	}
+	return pkg == "iter" || pkg == "runtime" || strings.HasPrefix(pkg, databasePkgPrefix)
+}
@@ -0,0 +1,263 @@
+package manager
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+// ErrUnknownDatabase is returned by Registry.Get for names never registered.
+var ErrUnknownDatabase = errors.New("database: unknown database")
+
+var databaseNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+const driverEnvSuffix = "_DRIVER"
+
+// Registry holds one manager per named database, configured from DB_<NAME>_* env variables.
+type Registry struct {
+	mu              sync.RWMutex
+	managers        map[string]Manager
+	closed          bool
+	shutdownTimeout time.Duration
+}
+
+// NewRegistry builds one manager per entry of configs; a nil config is read from the env.
+func NewRegistry(configs map[string]DriverConfig, opts ...Option) (*Registry, error) {
+	r := newRegistry(opts)
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if err := r.build(name, configs[name], opts); err != nil {
+			_ = r.Shutdown(context.Background())
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// NewRegistryFromEnv builds the named databases, or every DB_<NAME>_DRIVER set, from the env.
+func NewRegistryFromEnv(names []string, opts ...Option) (*Registry, error) {
+	if len(names) == 0 {
+		names = envDatabaseNames(os.Environ())
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: no DB_<NAME>_DRIVER variable set", database.ErrInvalidConfig)
+	}
+
+	configs := make(map[string]DriverConfig, len(names))
+	for _, name := range names {
+		configs[name] = nil
+	}
+	return NewRegistry(configs, opts...)
+}
+
+func newRegistry(opts []Option) *Registry {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Registry{managers: map[string]Manager{}, shutdownTimeout: o.shutdownTimeout}
+}
+
+func (r *Registry) build(name string, cfg DriverConfig, opts []Option) error {
+	key, err := normalizeDatabaseName(name)
+	if err != nil {
+		return err
+	}
+	opts = append(slices.Clip(opts), withDatabaseMigrations(key))
+	mgr, err := newManager(cfg, prefixedEnv(key), opts)
+	if err != nil {
+		return fmt.Errorf("database %q: %w", key, err)
+	}
+	if err := r.Register(key, mgr); err != nil {
+		_ = mgr.Shutdown(context.Background())
+		return err
+	}
+	return nil
+}
+
+func withDatabaseMigrations(name string) Option {
+	return func(o *options) {
+		if o.startupMigrationFS != nil {
+			o.startupMigrationRoot = path.Join(cmp.Or(o.startupMigrationRoot, "."), name)
+			return
+		}
+		o.startupMigrationDir = filepath.Join(cmp.Or(o.startupMigrationDir, "migrations"), name)
+	}
+}
+
+// Register adds a manager built elsewhere; the registry shuts it down with the others.
+func (r *Registry) Register(name string, mgr Manager) error {
+	key, err := normalizeDatabaseName(name)
+	if err != nil {
+		return err
+	}
+	if mgr == nil {
+		return fmt.Errorf("%w: database %q: manager is nil", database.ErrInvalidConfig, key)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return database.ErrManagerClosed
+	}
+	if _, ok := r.managers[key]; ok {
+		return fmt.Errorf("%w: database %q registered twice", database.ErrInvalidConfig, key)
+	}
+	r.managers[key] = mgr
+	return nil
+}
+
+// Get returns the manager registered under name.
+func (r *Registry) Get(name string) (Manager, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	mgr, ok := r.managers[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownDatabase, name)
+	}
+	return mgr, nil
+}
+
+// MustGet is Get for wiring code where a missing database is a bug.
+func (r *Registry) MustGet(name string) Manager {
+	mgr, err := r.Get(name)
+	if err != nil {
+		panic(err)
+	}
+	return mgr
+}
+
+// Names returns the registered names in sorted order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.managers))
+	for name := range r.managers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Ping pings every database concurrently and joins the failures.
+func (r *Registry) Ping(ctx context.Context) error {
+	return r.each(func(name string, mgr Manager) error {
+		if err := mgr.Ping(ctx); err != nil {
+			return fmt.Errorf("database %q: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// HealthChecks returns one ping check per database keyed "database.<name>".
+func (r *Registry) HealthChecks() map[string]func(ctx context.Context) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	checks := make(map[string]func(ctx context.Context) error, len(r.managers))
+	for name, mgr := range r.managers {
+		checks["database."+name] = mgr.Ping
+	}
+	return checks
+}
+
+// Shutdown shuts every manager down concurrently.
+func (r *Registry) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+
+	if r.shutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.shutdownTimeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- r.each(func(name string, mgr Manager) error {
+			if err := mgr.Shutdown(ctx); err != nil {
+				return fmt.Errorf("database %q: %w", name, err)
+			}
+			return nil
+		})
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return database.ErrShutdownTimeout
+	}
+}
+
+func (r *Registry) each(fn func(name string, mgr Manager) error) error {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.managers))
+	managers := make([]Manager, 0, len(r.managers))
+	for name, mgr := range r.managers {
+		names = append(names, name)
+		managers = append(managers, mgr)
+	}
+	r.mu.RUnlock()
+
+	errs := make([]error, len(managers))
+	var wg sync.WaitGroup
+	for i := range managers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = fn(names[i], managers[i])
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func normalizeDatabaseName(name string) (string, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if !databaseNamePattern.MatchString(key) {
+		return "", fmt.Errorf("%w: invalid database name %q", database.ErrInvalidConfig, name)
+	}
+	return key, nil
+}
+
+func prefixedEnv(name string) func(string) string {
+	prefix := "DB_" + strings.ToUpper(name) + "_"
+	return func(key string) string {
+		return os.Getenv(prefix + strings.TrimPrefix(key, "DB_"))
+	}
+}
+
+func envDatabaseNames(environ []string) []string {
+	var names []string
+	for _, entry := range environ {
+		key, value, _ := strings.Cut(entry, "=")
+		rest, prefixed := strings.CutPrefix(key, "DB_")
+		name, declared := strings.CutSuffix(rest, driverEnvSuffix)
+		if !prefixed || !declared || strings.TrimSpace(value) == "" {
+			continue
+		}
+		if name = strings.ToLower(name); databaseNamePattern.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
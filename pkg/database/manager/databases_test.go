@@ -0,0 +1,219 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/mssql"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/postgres"
+)
+
+// stubBuildAdapter troca a construção dos adapters por mocks, registrando a
+// config resolvida de cada banco.
+func stubBuildAdapter(t *testing.T) (map[database.Driver]DriverConfig, map[database.Driver]*mockAdapter) {
+	t.Helper()
+	originalBuildAdapterFunc := buildAdapterFunc
+	originalRunStartupMigrationsFunc := runStartupMigrationsFunc
+	t.Cleanup(func() {
+		buildAdapterFunc = originalBuildAdapterFunc
+		runStartupMigrationsFunc = originalRunStartupMigrationsFunc
+	})
+
+	configs := map[database.Driver]DriverConfig{}
+	adapters := map[database.Driver]*mockAdapter{}
+	buildAdapterFunc = func(cfg DriverConfig, _ options) (driverAdapter, error) {
+		driver := database.DriverPostgres
+		if _, ok := cfg.(mssql.MSSQLConfig); ok {
+			driver = database.DriverMSSQL
+		}
+		configs[driver] = cfg
+		adapters[driver] = &mockAdapter{driver: driver, dbtx: &stubDBTX{}}
+		return adapters[driver], nil
+	}
+	runStartupMigrationsFunc = func(_ DriverConfig, _ database.Driver, _ database.DBTX, _ options) error { return nil }
+	return configs, adapters
+}
+
+func TestNewRegistryFromEnv_ReadsPrefixedVariables(t *testing.T) {
+	configs, _ := stubBuildAdapter(t)
+	t.Setenv("DB_DRIVER", "mysql")
+	t.Setenv("DB_ORDERS_DRIVER", "postgres")
+	t.Setenv("DB_ORDERS_DSN", "postgres://orders@localhost/orders?sslmode=disable")
+	t.Setenv("DB_LEGACY_DRIVER", "mssql")
+	t.Setenv("DB_LEGACY_HOST", "legacy-host")
+	t.Setenv("DB_LEGACY_PORT", "1433")
+	t.Setenv("DB_LEGACY_USER", "sa")
+	t.Setenv("DB_LEGACY_PASSWORD", "secret")
+	t.Setenv("DB_LEGACY_DATABASE", "erp")
+
+	reg, err := NewRegistryFromEnv(nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = reg.Shutdown(context.Background()) })
+
+	require.Equal(t, []string{"legacy", "orders"}, reg.Names(), "DB_DRIVER sem prefixo não declara banco nomeado")
+	require.Equal(t, "postgres://orders@localhost/orders?sslmode=disable", configs[database.DriverPostgres].(postgres.PostgresConfig).DSN)
+	legacy := configs[database.DriverMSSQL].(mssql.MSSQLConfig)
+	require.Equal(t, "legacy-host", legacy.Host)
+	require.Equal(t, 1433, legacy.Port)
+	require.Equal(t, "erp", legacy.Database)
+
+	orders, err := reg.Get("ORDERS")
+	require.NoError(t, err)
+	require.Equal(t, database.DriverPostgres, orders.Driver())
+	require.Equal(t, database.DriverMSSQL, reg.MustGet("legacy").Driver())
+
+	_, err = reg.Get("billing")
+	require.ErrorIs(t, err, ErrUnknownDatabase)
+}
+
+func TestNewRegistry_TypedConfigsMergePrefixedEnv(t *testing.T) {
+	configs, _ := stubBuildAdapter(t)
+	t.Setenv("DB_HOST", "default-host")
+	t.Setenv("DB_ORDERS_HOST", "orders-host")
+	t.Setenv("DB_ORDERS_PASSWORD", "orders-pass")
+
+	reg, err := NewRegistry(map[string]DriverConfig{
+		"orders": postgres.PostgresConfig{User: "app", Database: "orders"},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = reg.Shutdown(context.Background()) })
+
+	cfg := configs[database.DriverPostgres].(postgres.PostgresConfig)
+	require.Equal(t, "orders-host", cfg.Host, "campos ausentes vêm das variáveis com prefixo")
+	require.Equal(t, "orders-pass", cfg.Password)
+	require.Equal(t, "app", cfg.User)
+}
+
+func TestNewRegistry_FailureShutsDownBuiltManagers(t *testing.T) {
+	_, adapters := stubBuildAdapter(t)
+
+	_, err := NewRegistry(map[string]DriverConfig{
+		"a_orders": postgres.PostgresConfig{DSN: "postgres://localhost/orders"},
+		"b_legacy": nil,
+	})
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+	require.ErrorContains(t, err, `database "b_legacy"`)
+	require.Equal(t, 1, adapters[database.DriverPostgres].closeCalls, "o manager já criado deve ser fechado")
+
+	_, err = NewRegistry(map[string]DriverConfig{"orders-db": postgres.PostgresConfig{DSN: "postgres://localhost/orders"}})
+	require.ErrorIs(t, err, database.ErrInvalidConfig, "nomes devem ser válidos como variável de ambiente")
+
+	_, err = NewRegistryFromEnv(nil)
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+}
+
+func TestNewRegistry_MigrationsPerDatabaseOnSameDriver(t *testing.T) {
+	stubBuildAdapter(t)
+	var mu sync.Mutex
+	dirs := map[string]string{}
+	runStartupMigrationsFunc = func(cfg DriverConfig, _ database.Driver, _ database.DBTX, o options) error {
+		mu.Lock()
+		defer mu.Unlock()
+		dirs[cfg.(postgres.PostgresConfig).Database] = o.startupMigrationDir
+		return nil
+	}
+	configs := map[string]DriverConfig{
+		"orders":  postgres.PostgresConfig{DSN: "postgres://localhost/orders", Database: "orders"},
+		"billing": postgres.PostgresConfig{DSN: "postgres://localhost/billing", Database: "billing"},
+	}
+
+	reg, err := NewRegistry(configs)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = reg.Shutdown(context.Background()) })
+	require.Equal(t, map[string]string{
+		"orders":  filepath.Join("migrations", "orders"),
+		"billing": filepath.Join("migrations", "billing"),
+	}, dirs, "bancos do mesmo driver não compartilham migrations/postgres")
+
+	reg, err = NewRegistry(configs, WithStartupMigrationDir("db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = reg.Shutdown(context.Background()) })
+	require.Equal(t, filepath.Join("db", "orders"), dirs["orders"], "o diretório do registry recebe o nome do banco")
+}
+
+func TestNewRegistry_MigrationFSPerDatabase(t *testing.T) {
+	stubBuildAdapter(t)
+	var roots []string
+	runStartupMigrationsFunc = func(_ DriverConfig, _ database.Driver, _ database.DBTX, o options) error {
+		roots = append(roots, o.startupMigrationRoot)
+		return nil
+	}
+
+	reg, err := NewRegistry(map[string]DriverConfig{
+		"orders": postgres.PostgresConfig{DSN: "postgres://localhost/orders"},
+	}, WithStartupMigrationFS(fstest.MapFS{}, "sql"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = reg.Shutdown(context.Background()) })
+	require.Equal(t, []string{"sql/orders"}, roots)
+
+	o := defaultOptions()
+	WithStartupMigrationFS(fstest.MapFS{}, "sql/orders")(&o)
+	err = runStartupMigrations(postgres.PostgresConfig{DSN: "postgres://localhost/orders"}, database.DriverPostgres, nil, o)
+	require.NoError(t, err, "sem o diretório do banco não há migrações a aplicar")
+}
+
+func TestRegistry_PingAndHealthChecks(t *testing.T) {
+	reg, err := NewRegistry(nil)
+	require.NoError(t, err)
+	orders := &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}
+	legacy := &mockAdapter{driver: database.DriverMSSQL, dbtx: &stubDBTX{}, pingErr: errors.New("login failed")}
+	require.NoError(t, reg.Register("orders", newTestManager(orders)))
+	require.NoError(t, reg.Register("legacy", newTestManager(legacy)))
+	require.ErrorIs(t, reg.Register("orders", newTestManager(orders)), database.ErrInvalidConfig)
+
+	err = reg.Ping(context.Background())
+	require.ErrorContains(t, err, `database "legacy": login failed`)
+	require.NotContains(t, err.Error(), "orders")
+
+	checks := reg.HealthChecks()
+	require.Len(t, checks, 2)
+	require.NoError(t, checks["database.orders"](context.Background()))
+	require.Error(t, checks["database.legacy"](context.Background()))
+}
+
+func TestRegistry_ShutdownClosesEveryManager(t *testing.T) {
+	reg, err := NewRegistry(nil)
+	require.NoError(t, err)
+	orders := &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}
+	legacy := &mockAdapter{driver: database.DriverMSSQL, dbtx: &stubDBTX{}}
+	require.NoError(t, reg.Register("orders", newTestManager(orders)))
+	require.NoError(t, reg.Register("legacy", newTestManager(legacy)))
+
+	require.NoError(t, reg.Shutdown(context.Background()))
+	require.Equal(t, 1, orders.closeCalls)
+	require.Equal(t, 1, legacy.closeCalls)
+	require.ErrorIs(t, reg.MustGet("orders").Ping(context.Background()), database.ErrManagerClosed)
+	require.ErrorIs(t, reg.Register("billing", newTestManager(orders)), database.ErrManagerClosed)
+}
+
+func TestRegistry_ShutdownHonorsTimeout(t *testing.T) {
+	reg, err := NewRegistry(nil, WithShutdownTimeout(20*time.Millisecond))
+	require.NoError(t, err)
+	slow := &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}, closeSlow: time.Second}
+	require.NoError(t, reg.Register("orders", newTestManager(slow)))
+
+	start := time.Now()
+	err = reg.Shutdown(context.Background())
+	require.ErrorIs(t, err, database.ErrShutdownTimeout)
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestEnvDatabaseNames(t *testing.T) {
+	names := envDatabaseNames([]string{
+		"DB_DRIVER=postgres",
+		"DB_ORDERS_DRIVER=postgres",
+		"DB_READ_MODEL_DRIVER=mysql",
+		"DB_EMPTY_DRIVER=",
+		"DB_ORDERS_HOST=localhost",
+		"PATH=/usr/bin",
+	})
+	require.Equal(t, []string{"orders", "read_model"}, names)
+}
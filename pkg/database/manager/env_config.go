@@ -1,8 +1,8 @@
 package manager
 
 import (
+	"context"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 
@@ -13,21 +13,29 @@ import (
 	"github.com/JailtonJunior94/devkit-go/pkg/database/postgres"
 )
 
-func resolveConfig(cfg DriverConfig) (DriverConfig, error) {
+func resolveConfig(cfg DriverConfig, getenv func(string) string) (DriverConfig, error) {
 	if cfg != nil {
-		return mergeConfigWithEnvDefaults(cfg)
+		return mergeConfigWithEnvDefaults(cfg, getenv)
 	}
 
-	return resolveEnvConfig()
+	return resolveEnvConfig(getenv)
 }
 
-func resolveEnvConfig() (DriverConfig, error) {
-	driver := strings.ToLower(strings.TrimSpace(os.Getenv("DB_DRIVER")))
+func resolveEnvConfig(getenv func(string) string) (DriverConfig, error) {
+	driver := strings.ToLower(strings.TrimSpace(getenv("DB_DRIVER")))
 	if driver == "" {
 		return nil, fmt.Errorf("%w: config is required", database.ErrInvalidConfig)
 	}
 
-	port, err := envInt("DB_PORT")
+	port, err := envInt(getenv, "DB_PORT")
+	if err != nil {
+		return nil, err
+	}
+	dsn, err := envSecret(getenv, "DB_DSN")
+	if err != nil {
+		return nil, err
+	}
+	creds, err := resolveEnvCredentials(getenv, "", "")
 	if err != nil {
 		return nil, err
 	}
@@ -35,81 +43,98 @@ func resolveEnvConfig() (DriverConfig, error) {
 	switch driver {
 	case string(database.DriverPostgres):
 		return postgres.PostgresConfig{
-			DSN:        os.Getenv("DB_DSN"),
-			Host:       os.Getenv("DB_HOST"),
-			Port:       port,
-			User:       os.Getenv("DB_USER"),
-			Password:   os.Getenv("DB_PASSWORD"),
-			Database:   envFirst("DB_DATABASE", "DB_NAME"),
-			SSLMode:    os.Getenv("DB_SSLMODE"),
-			SearchPath: os.Getenv("DB_SEARCH_PATH"),
+			DSN:         dsn,
+			Host:        getenv("DB_HOST"),
+			Port:        port,
+			User:        creds.User,
+			Password:    creds.Password,
+			Credentials: creds.Provider,
+			Database:    envFirst(getenv, "DB_DATABASE", "DB_NAME"),
+			SSLMode:     getenv("DB_SSLMODE"),
+			SearchPath:  getenv("DB_SEARCH_PATH"),
 		}, nil
 	case string(database.DriverCockroach):
 		return cockroach.CockroachConfig{
-			DSN:        os.Getenv("DB_DSN"),
-			Host:       os.Getenv("DB_HOST"),
-			Port:       port,
-			User:       os.Getenv("DB_USER"),
-			Password:   os.Getenv("DB_PASSWORD"),
-			Database:   envFirst("DB_DATABASE", "DB_NAME"),
-			SSLMode:    os.Getenv("DB_SSLMODE"),
-			SearchPath: os.Getenv("DB_SEARCH_PATH"),
+			DSN:         dsn,
+			Host:        getenv("DB_HOST"),
+			Port:        port,
+			User:        creds.User,
+			Password:    creds.Password,
+			Credentials: creds.Provider,
+			Database:    envFirst(getenv, "DB_DATABASE", "DB_NAME"),
+			SSLMode:     getenv("DB_SSLMODE"),
+			SearchPath:  getenv("DB_SEARCH_PATH"),
 		}, nil
 	case string(database.DriverMySQL):
 		return mysql.MySQLConfig{
-			DSN:      os.Getenv("DB_DSN"),
-			Host:     os.Getenv("DB_HOST"),
-			Port:     port,
-			User:     os.Getenv("DB_USER"),
-			Password: os.Getenv("DB_PASSWORD"),
-			Database: envFirst("DB_DATABASE", "DB_NAME"),
+			DSN:         dsn,
+			Host:        getenv("DB_HOST"),
+			Port:        port,
+			User:        creds.User,
+			Password:    creds.Password,
+			Credentials: creds.Provider,
+			Database:    envFirst(getenv, "DB_DATABASE", "DB_NAME"),
 		}, nil
 	case string(database.DriverMSSQL):
 		return mssql.MSSQLConfig{
-			DSN:           os.Getenv("DB_DSN"),
-			Host:          os.Getenv("DB_HOST"),
+			DSN:           dsn,
+			Host:          getenv("DB_HOST"),
 			Port:          port,
-			User:          os.Getenv("DB_USER"),
-			Password:      os.Getenv("DB_PASSWORD"),
-			Database:      envFirst("DB_DATABASE", "DB_NAME"),
-			DefaultSchema: os.Getenv("DB_DEFAULT_SCHEMA"),
+			User:          creds.User,
+			Password:      creds.Password,
+			Credentials:   creds.Provider,
+			Database:      envFirst(getenv, "DB_DATABASE", "DB_NAME"),
+			DefaultSchema: getenv("DB_DEFAULT_SCHEMA"),
 		}, nil
 	default:
+		if loader, ok := lookupEnvConfig(database.Driver(driver)); ok {
+			return loader(getenv)
+		}
 		return nil, fmt.Errorf("%w: unsupported DB_DRIVER %q", database.ErrInvalidConfig, driver)
 	}
 }
 
-func mergeConfigWithEnvDefaults(cfg DriverConfig) (DriverConfig, error) {
+func mergeConfigWithEnvDefaults(cfg DriverConfig, getenv func(string) string) (DriverConfig, error) {
 	switch c := cfg.(type) {
 	case postgres.PostgresConfig:
-		return mergePostgresConfigWithEnv(c)
+		return mergePostgresConfigWithEnv(c, getenv)
 	case cockroach.CockroachConfig:
-		return mergeCockroachConfigWithEnv(c)
+		return mergeCockroachConfigWithEnv(c, getenv)
 	case mysql.MySQLConfig:
-		return mergeMySQLConfigWithEnv(c)
+		return mergeMySQLConfigWithEnv(c, getenv)
 	case mssql.MSSQLConfig:
-		return mergeMSSQLConfigWithEnv(c)
+		return mergeMSSQLConfigWithEnv(c, getenv)
 	default:
 		return cfg, nil
 	}
 }
 
-func mergePostgresConfigWithEnv(cfg postgres.PostgresConfig) (postgres.PostgresConfig, error) {
+func mergePostgresConfigWithEnv(cfg postgres.PostgresConfig, getenv func(string) string) (postgres.PostgresConfig, error) {
 	if cfg.DSN != "" {
 		return cfg, nil
 	}
 
 	if !hasPostgresStructuredFields(cfg) {
-		cfg.DSN = os.Getenv("DB_DSN")
+		dsn, err := envSecret(getenv, "DB_DSN")
+		if err != nil {
+			return postgres.PostgresConfig{}, err
+		}
+		cfg.DSN = dsn
+	}
+	cfg.Host = firstNonEmpty(cfg.Host, getenv("DB_HOST"))
+	creds, err := resolveEnvCredentials(getenv, cfg.User, cfg.Password)
+	if err != nil {
+		return postgres.PostgresConfig{}, err
 	}
-	cfg.Host = firstNonEmpty(cfg.Host, os.Getenv("DB_HOST"))
-	cfg.User = firstNonEmpty(cfg.User, os.Getenv("DB_USER"))
-	cfg.Password = firstNonEmpty(cfg.Password, os.Getenv("DB_PASSWORD"))
-	cfg.Database = firstNonEmpty(cfg.Database, envFirst("DB_DATABASE", "DB_NAME"))
-	cfg.SSLMode = firstNonEmpty(cfg.SSLMode, os.Getenv("DB_SSLMODE"))
-	cfg.SearchPath = firstNonEmpty(cfg.SearchPath, os.Getenv("DB_SEARCH_PATH"))
+	cfg.User, cfg.Password = creds.User, creds.Password
+	if cfg.Credentials == nil {
+		cfg.Credentials = creds.Provider
+	}
+	cfg.Database = firstNonEmpty(cfg.Database, envFirst(getenv, "DB_DATABASE", "DB_NAME"))
+	cfg.SSLMode = firstNonEmpty(cfg.SSLMode, getenv("DB_SSLMODE"))
+	cfg.SearchPath = firstNonEmpty(cfg.SearchPath, getenv("DB_SEARCH_PATH"))
 
-	port, err := mergeEnvPort(cfg.Port)
+	port, err := mergeEnvPort(getenv, cfg.Port)
 	if err != nil {
 		return postgres.PostgresConfig{}, err
 	}
@@ -118,22 +143,32 @@ func mergePostgresConfigWithEnv(cfg postgres.PostgresConfig) (postgres.PostgresC
 	return cfg, nil
 }
 
-func mergeCockroachConfigWithEnv(cfg cockroach.CockroachConfig) (cockroach.CockroachConfig, error) {
+func mergeCockroachConfigWithEnv(cfg cockroach.CockroachConfig, getenv func(string) string) (cockroach.CockroachConfig, error) {
 	if cfg.DSN != "" {
 		return cfg, nil
 	}
 
 	if !hasCockroachStructuredFields(cfg) {
-		cfg.DSN = os.Getenv("DB_DSN")
+		dsn, err := envSecret(getenv, "DB_DSN")
+		if err != nil {
+			return cockroach.CockroachConfig{}, err
+		}
+		cfg.DSN = dsn
+	}
+	cfg.Host = firstNonEmpty(cfg.Host, getenv("DB_HOST"))
+	creds, err := resolveEnvCredentials(getenv, cfg.User, cfg.Password)
+	if err != nil {
+		return cockroach.CockroachConfig{}, err
+	}
+	cfg.User, cfg.Password = creds.User, creds.Password
+	if cfg.Credentials == nil {
+		cfg.Credentials = creds.Provider
 	}
-	cfg.Host = firstNonEmpty(cfg.Host, os.Getenv("DB_HOST"))
-	cfg.User = firstNonEmpty(cfg.User, os.Getenv("DB_USER"))
-	cfg.Password = firstNonEmpty(cfg.Password, os.Getenv("DB_PASSWORD"))
-	cfg.Database = firstNonEmpty(cfg.Database, envFirst("DB_DATABASE", "DB_NAME"))
-	cfg.SSLMode = firstNonEmpty(cfg.SSLMode, os.Getenv("DB_SSLMODE"))
-	cfg.SearchPath = firstNonEmpty(cfg.SearchPath, os.Getenv("DB_SEARCH_PATH"))
+	cfg.Database = firstNonEmpty(cfg.Database, envFirst(getenv, "DB_DATABASE", "DB_NAME"))
+	cfg.SSLMode = firstNonEmpty(cfg.SSLMode, getenv("DB_SSLMODE"))
+	cfg.SearchPath = firstNonEmpty(cfg.SearchPath, getenv("DB_SEARCH_PATH"))
 
-	port, err := mergeEnvPort(cfg.Port)
+	port, err := mergeEnvPort(getenv, cfg.Port)
 	if err != nil {
 		return cockroach.CockroachConfig{}, err
 	}
@@ -142,20 +177,30 @@ func mergeCockroachConfigWithEnv(cfg cockroach.CockroachConfig) (cockroach.Cockr
 	return cfg, nil
 }
 
-func mergeMySQLConfigWithEnv(cfg mysql.MySQLConfig) (mysql.MySQLConfig, error) {
+func mergeMySQLConfigWithEnv(cfg mysql.MySQLConfig, getenv func(string) string) (mysql.MySQLConfig, error) {
 	if cfg.DSN != "" {
 		return cfg, nil
 	}
 
 	if !hasMySQLStructuredFields(cfg) {
-		cfg.DSN = os.Getenv("DB_DSN")
+		dsn, err := envSecret(getenv, "DB_DSN")
+		if err != nil {
+			return mysql.MySQLConfig{}, err
+		}
+		cfg.DSN = dsn
+	}
+	cfg.Host = firstNonEmpty(cfg.Host, getenv("DB_HOST"))
+	creds, err := resolveEnvCredentials(getenv, cfg.User, cfg.Password)
+	if err != nil {
+		return mysql.MySQLConfig{}, err
+	}
+	cfg.User, cfg.Password = creds.User, creds.Password
+	if cfg.Credentials == nil {
+		cfg.Credentials = creds.Provider
 	}
-	cfg.Host = firstNonEmpty(cfg.Host, os.Getenv("DB_HOST"))
-	cfg.User = firstNonEmpty(cfg.User, os.Getenv("DB_USER"))
-	cfg.Password = firstNonEmpty(cfg.Password, os.Getenv("DB_PASSWORD"))
-	cfg.Database = firstNonEmpty(cfg.Database, envFirst("DB_DATABASE", "DB_NAME"))
+	cfg.Database = firstNonEmpty(cfg.Database, envFirst(getenv, "DB_DATABASE", "DB_NAME"))
 
-	port, err := mergeEnvPort(cfg.Port)
+	port, err := mergeEnvPort(getenv, cfg.Port)
 	if err != nil {
 		return mysql.MySQLConfig{}, err
 	}
@@ -164,21 +209,31 @@ func mergeMySQLConfigWithEnv(cfg mysql.MySQLConfig) (mysql.MySQLConfig, error) {
 	return cfg, nil
 }
 
-func mergeMSSQLConfigWithEnv(cfg mssql.MSSQLConfig) (mssql.MSSQLConfig, error) {
+func mergeMSSQLConfigWithEnv(cfg mssql.MSSQLConfig, getenv func(string) string) (mssql.MSSQLConfig, error) {
 	if cfg.DSN != "" {
 		return cfg, nil
 	}
 
 	if !hasMSSQLStructuredFields(cfg) {
-		cfg.DSN = os.Getenv("DB_DSN")
+		dsn, err := envSecret(getenv, "DB_DSN")
+		if err != nil {
+			return mssql.MSSQLConfig{}, err
+		}
+		cfg.DSN = dsn
+	}
+	cfg.Host = firstNonEmpty(cfg.Host, getenv("DB_HOST"))
+	creds, err := resolveEnvCredentials(getenv, cfg.User, cfg.Password)
+	if err != nil {
+		return mssql.MSSQLConfig{}, err
+	}
+	cfg.User, cfg.Password = creds.User, creds.Password
+	if cfg.Credentials == nil {
+		cfg.Credentials = creds.Provider
 	}
-	cfg.Host = firstNonEmpty(cfg.Host, os.Getenv("DB_HOST"))
-	cfg.User = firstNonEmpty(cfg.User, os.Getenv("DB_USER"))
-	cfg.Password = firstNonEmpty(cfg.Password, os.Getenv("DB_PASSWORD"))
-	cfg.Database = firstNonEmpty(cfg.Database, envFirst("DB_DATABASE", "DB_NAME"))
-	cfg.DefaultSchema = firstNonEmpty(cfg.DefaultSchema, os.Getenv("DB_DEFAULT_SCHEMA"))
+	cfg.Database = firstNonEmpty(cfg.Database, envFirst(getenv, "DB_DATABASE", "DB_NAME"))
+	cfg.DefaultSchema = firstNonEmpty(cfg.DefaultSchema, getenv("DB_DEFAULT_SCHEMA"))
 
-	port, err := mergeEnvPort(cfg.Port)
+	port, err := mergeEnvPort(getenv, cfg.Port)
 	if err != nil {
 		return mssql.MSSQLConfig{}, err
 	}
@@ -187,11 +242,11 @@ func mergeMSSQLConfigWithEnv(cfg mssql.MSSQLConfig) (mssql.MSSQLConfig, error) {
 	return cfg, nil
 }
 
-func mergeEnvPort(explicitPort int) (int, error) {
+func mergeEnvPort(getenv func(string) string, explicitPort int) (int, error) {
 	if explicitPort != 0 {
 		return explicitPort, nil
 	}
-	return envInt("DB_PORT")
+	return envInt(getenv, "DB_PORT")
 }
 
 func hasPostgresStructuredFields(cfg postgres.PostgresConfig) bool {
@@ -231,6 +286,58 @@ func hasMSSQLStructuredFields(cfg mssql.MSSQLConfig) bool {
 		cfg.DefaultSchema != ""
 }
 
+// envCredentials are the user and password of an env config. Provider is
+// set when the password comes from a secret file, so a rotated file reaches
+// new connections.
+type envCredentials struct {
+	User     string
+	Password string
+	Provider database.CredentialsProvider
+}
+
+// resolveEnvCredentials fills user and password, when empty, from DB_USER
+// and DB_PASSWORD or from the files named by DB_USER_FILE and
+// DB_PASSWORD_FILE.
+func resolveEnvCredentials(getenv func(string) string, user, password string) (envCredentials, error) {
+	user = firstNonEmpty(user, getenv("DB_USER"))
+	password = firstNonEmpty(password, getenv("DB_PASSWORD"))
+	userFile := getenv("DB_USER_FILE")
+	if user != "" {
+		userFile = ""
+	}
+
+	if passwordFile := getenv("DB_PASSWORD_FILE"); password == "" && passwordFile != "" {
+		provider, err := database.NewFileCredentials(userFile, passwordFile)
+		if err != nil {
+			return envCredentials{}, err
+		}
+		creds, err := provider.Credentials(context.Background())
+		if err != nil {
+			return envCredentials{}, err
+		}
+		return envCredentials{User: firstNonEmpty(user, creds.User), Password: creds.Password, Provider: provider}, nil
+	}
+
+	if userFile != "" {
+		var err error
+		if user, err = database.ReadSecretFile(userFile); err != nil {
+			return envCredentials{}, err
+		}
+	}
+	return envCredentials{User: user, Password: password}, nil
+}
+
+// envSecret returns key, or the content of the file named by key_FILE.
+func envSecret(getenv func(string) string, key string) (string, error) {
+	if value := getenv(key); value != "" {
+		return value, nil
+	}
+	if file := getenv(key + "_FILE"); file != "" {
+		return database.ReadSecretFile(file)
+	}
+	return "", nil
+}
+
 func firstNonEmpty(explicit, fallback string) string {
 	if explicit != "" {
 		return explicit
@@ -238,17 +345,17 @@ func firstNonEmpty(explicit, fallback string) string {
 	return fallback
 }
 
-func envFirst(keys ...string) string {
+func envFirst(getenv func(string) string, keys ...string) string {
 	for _, key := range keys {
-		if value := os.Getenv(key); value != "" {
+		if value := getenv(key); value != "" {
 			return value
 		}
 	}
 	return ""
 }
 
-func envInt(key string) (int, error) {
-	raw := strings.TrimSpace(os.Getenv(key))
+func envInt(getenv func(string) string, key string) (int, error) {
+	raw := strings.TrimSpace(getenv(key))
 	if raw == "" {
 		return 0, nil
 	}
@@ -0,0 +1,85 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/mysql"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/postgres"
+)
+
+func secretFile(t *testing.T, value string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte(value+"\n"), 0o600))
+	return path
+}
+
+func TestResolveEnvConfig_PasswordFileBecomesProvider(t *testing.T) {
+	t.Setenv("DB_DRIVER", "postgres")
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_DATABASE", "app")
+	t.Setenv("DB_USER_FILE", secretFile(t, "vault-user"))
+	t.Setenv("DB_PASSWORD_FILE", secretFile(t, "vault-pass"))
+
+	cfg, err := resolveEnvConfig(os.Getenv)
+	require.NoError(t, err)
+	pg := cfg.(postgres.PostgresConfig)
+	require.Equal(t, "vault-user", pg.User)
+	require.Equal(t, "vault-pass", pg.Password)
+	require.NotNil(t, pg.Credentials, "o arquivo de senha deve ser relido a cada nova conexão")
+
+	creds, err := pg.Credentials.Credentials(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, database.Credentials{User: "vault-user", Password: "vault-pass"}, creds)
+}
+
+func TestResolveEnvConfig_ExplicitValuesWinOverFiles(t *testing.T) {
+	t.Setenv("DB_DRIVER", "mysql")
+	t.Setenv("DB_DSN_FILE", secretFile(t, "app:from-file@tcp(db:3306)/app"))
+	t.Setenv("DB_USER", "app")
+	t.Setenv("DB_PASSWORD", "from-env")
+	t.Setenv("DB_PASSWORD_FILE", secretFile(t, "ignored"))
+
+	cfg, err := resolveEnvConfig(os.Getenv)
+	require.NoError(t, err)
+	my := cfg.(mysql.MySQLConfig)
+	require.Equal(t, "app:from-file@tcp(db:3306)/app", my.DSN)
+	require.Equal(t, "from-env", my.Password)
+	require.Nil(t, my.Credentials)
+}
+
+func TestResolveEnvConfig_MissingSecretFile(t *testing.T) {
+	t.Setenv("DB_DRIVER", "postgres")
+	t.Setenv("DB_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	_, err := resolveEnvConfig(os.Getenv)
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+}
+
+func TestMergeConfigWithEnvDefaults_PrefixedPasswordFile(t *testing.T) {
+	t.Setenv("DB_ORDERS_PASSWORD_FILE", secretFile(t, "orders-pass"))
+
+	cfg, err := mergeConfigWithEnvDefaults(postgres.PostgresConfig{Host: "db", User: "app", Database: "orders"}, prefixedEnv("orders"))
+	require.NoError(t, err)
+	pg := cfg.(postgres.PostgresConfig)
+	require.Equal(t, "orders-pass", pg.Password)
+	require.NotNil(t, pg.Credentials)
+}
+
+func TestResolveMigrationDSN_UsesProviderCredentials(t *testing.T) {
+	dsn, err := resolveMigrationDSN(postgres.PostgresConfig{
+		Host:     "db",
+		Database: "app",
+		Credentials: database.CredentialsFunc(func(context.Context) (database.Credentials, error) {
+			return database.Credentials{User: "dyn", Password: "token"}, nil
+		}),
+	})
+	require.NoError(t, err)
+	require.Contains(t, dsn, "dyn:token@db")
+}
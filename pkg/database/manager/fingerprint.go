@@ -0,0 +1,189 @@
+package manager
+
+import (
+	"bytes"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	inListPattern     = regexp.MustCompile(`(?i)\b(IN ?)\(\?(?:, ?\?)*\)`)
+	valuesListPattern = regexp.MustCompile(`(?i)\b(VALUES ?)(\(\?(?:, ?\?)*\))(?:, ?\(\?(?:, ?\?)*\))+`)
+)
+
+// normalizeQuery reduces query to a shape shared by every execution of the
+// same statement: literals and bind markers become ?, comments are dropped,
+// whitespace is collapsed and IN/VALUES lists of markers fold to one entry.
+// Quoted identifiers are kept.
+func normalizeQuery(query string) string {
+	out := make([]byte, 0, len(query))
+	var last byte
+	emit := func(s string) {
+		out = append(out, s...)
+		last = s[len(s)-1]
+	}
+	space := func() {
+		if len(out) > 0 && last != ' ' {
+			emit(" ")
+		}
+	}
+	// dropSign folds a unary minus into the literal that follows it.
+	dropSign := func() {
+		k := len(out)
+		if k > 0 && out[k-1] == ' ' {
+			k--
+		}
+		if k == 0 || out[k-1] != '-' || !unaryContext(out[:k-1]) {
+			return
+		}
+		out = out[:k-1]
+		last = 0
+		if len(out) > 0 {
+			last = out[len(out)-1]
+		}
+	}
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space()
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				i = len(query)
+			} else {
+				i += end
+			}
+			space()
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				i = len(query)
+			} else {
+				i += end + 3
+			}
+			space()
+		case c == '\'':
+			j := i + 1
+			for j < len(query) {
+				if query[j] == '\'' {
+					if j+1 < len(query) && query[j+1] == '\'' {
+						j += 2
+						continue
+					}
+					break
+				}
+				j++
+			}
+			emit("?")
+			i = j
+		case c == '"' || c == '`' || c == '[':
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			end := strings.IndexByte(query[i+1:], closing)
+			if end < 0 {
+				emit(query[i:])
+				i = len(query)
+			} else {
+				emit(query[i : i+end+2])
+				i += end + 1
+			}
+		case c == '$' && !isIdentChar(last) && dollarTagEnd(query, i) > 0:
+			tagEnd := dollarTagEnd(query, i)
+			end := strings.Index(query[tagEnd:], query[i:tagEnd])
+			if end < 0 {
+				i = len(query)
+			} else {
+				i = tagEnd + end + (tagEnd - i) - 1
+			}
+			emit("?")
+		case c == ':' && i+1 < len(query) && query[i+1] == ':':
+			emit("::")
+			i++
+		case (c == '$' || c == ':' || c == '@') && i+1 < len(query) && isIdentChar(query[i+1]) && !isIdentChar(last):
+			j := i + 1
+			for j < len(query) && isIdentChar(query[j]) {
+				j++
+			}
+			emit("?")
+			i = j - 1
+		case c == '?':
+			emit("?")
+		case isDigit(c) && !isIdentChar(last):
+			dropSign()
+			j := i + 1
+			for j < len(query) && (isIdentChar(query[j]) || query[j] == '.' ||
+				((query[j] == '+' || query[j] == '-') && (query[j-1] == 'e' || query[j-1] == 'E'))) {
+				j++
+			}
+			emit("?")
+			i = j - 1
+		default:
+			out = append(out, c)
+			last = c
+		}
+	}
+
+	normalized := strings.TrimRight(string(out), " ")
+	normalized = inListPattern.ReplaceAllString(normalized, "${1}(?)")
+	return valuesListPattern.ReplaceAllString(normalized, "${1}${2}")
+}
+
+// fingerprint returns a short stable identifier for a normalized query.
+func fingerprint(normalized string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(normalized))
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// dollarTagEnd returns the end of a Postgres $tag$ opening at i, or 0.
+func dollarTagEnd(query string, i int) int {
+	j := i + 1
+	if j < len(query) && !isDigit(query[j]) {
+		for j < len(query) && isIdentChar(query[j]) {
+			j++
+		}
+	}
+	if j < len(query) && query[j] == '$' {
+		return j + 1
+	}
+	return 0
+}
+
+// unaryContext reports whether a minus after before is a sign, not a subtraction.
+func unaryContext(before []byte) bool {
+	before = bytes.TrimRight(before, " ")
+	if len(before) == 0 {
+		return true
+	}
+	prev := before[len(before)-1]
+	switch {
+	case prev == ')' || prev == '?' || prev == '"' || prev == '`' || prev == ']':
+		return false
+	case !isIdentChar(prev):
+		return true
+	}
+	start := len(before)
+	for start > 0 && isIdentChar(before[start-1]) {
+		start--
+	}
+	_, keyword := signKeywords[strings.ToUpper(string(before[start:]))]
+	return keyword
+}
+
+var signKeywords = map[string]struct{}{
+	"SELECT": {}, "WHERE": {}, "AND": {}, "OR": {}, "NOT": {}, "ON": {}, "SET": {},
+	"WHEN": {}, "THEN": {}, "ELSE": {}, "CASE": {}, "RETURN": {}, "LIMIT": {}, "OFFSET": {},
+	"BY": {}, "IS": {}, "LIKE": {}, "BETWEEN": {}, "IN": {}, "VALUES": {}, "INTERVAL": {},
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isIdentChar(c byte) bool {
+	return c == '_' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
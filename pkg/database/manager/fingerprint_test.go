@@ -0,0 +1,48 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"literais", "SELECT * FROM users WHERE id = 42 AND name = 'ana' AND score > 1.5e3", "SELECT * FROM users WHERE id = ? AND name = ? AND score > ?"},
+		{"aspas escapadas", "SELECT 'it''s' FROM t", "SELECT ? FROM t"},
+		{"placeholders por driver", "UPDATE t SET a = $1 WHERE b = @p2 AND c = ? AND d = :name", "UPDATE t SET a = ? WHERE b = ? AND c = ? AND d = ?"},
+		{"lista IN", "SELECT id FROM t WHERE id IN (1, 2, 3) OR k in ($1,$2)", "SELECT id FROM t WHERE id IN (?) OR k in (?)"},
+		{"VALUES multi-linha", "INSERT INTO t (a, b) VALUES (?, ?), (?, ?), (?, ?)", "INSERT INTO t (a, b) VALUES (?, ?)"},
+		{"comentários e espaços", "SELECT  1 -- total\n FROM /* hint */ t\n", "SELECT ? FROM t"},
+		{"números negativos", "SELECT -1, a - 2 FROM t WHERE b = -3.5 AND c IN (-4, 5) LIMIT -1", "SELECT ?, a - ? FROM t WHERE b = ? AND c IN (?) LIMIT ?"},
+		{"subtração após parêntese", "SELECT (a) -1, f(x)- 2 FROM t", "SELECT (a) -?, f(x)- ? FROM t"},
+		{"dollar quoting", "SELECT $$it's $1$$, $fn$ body $$ x $fn$ FROM t WHERE a = $1", "SELECT ?, ? FROM t WHERE a = ?"},
+		{"identificadores preservados", `SELECT "col1", t2.x, [order] FROM tbl_9 WHERE v::int = 7`, `SELECT "col1", t2.x, [order] FROM tbl_9 WHERE v::int = ?`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, normalizeQuery(tc.query))
+		})
+	}
+}
+
+func TestFingerprint_StableAcrossLiterals(t *testing.T) {
+	a := fingerprint(normalizeQuery("SELECT * FROM users WHERE id IN (1, 2)"))
+	b := fingerprint(normalizeQuery("select * from users where id in (3)"))
+	c := fingerprint(normalizeQuery("SELECT * FROM users WHERE id IN (4,5,6)"))
+
+	require.Equal(t, a, c)
+	require.NotEqual(t, a, b, "o fingerprint diferencia maiúsculas")
+	require.NotEmpty(t, a)
+
+	// Sinal negativo e corpo em dollar quoting não criam fingerprints novos.
+	require.Equal(t,
+		fingerprint(normalizeQuery("SELECT * FROM t WHERE a = 1 AND b = $$x$$")),
+		fingerprint(normalizeQuery("SELECT * FROM t WHERE a = -1 AND b = $tag$y$tag$")),
+	)
+}
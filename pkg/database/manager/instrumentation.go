@@ -2,8 +2,10 @@ package manager
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,13 +14,21 @@ import (
 	"github.com/JailtonJunior94/devkit-go/pkg/observability/noop"
 )
 
+// tenantAttr tags the spans of operations issued for a tenant
+// (database.WithTenant). Metrics leave it out: the ctx value is not validated
+// here and would let callers grow the metric cardinality at will.
+const tenantAttr = "db.tenant"
+
 type instrumentation struct {
 	driver        database.Driver
 	attrs         []observability.Field
 	obs           observability.Observability
+	observed      bool
 	fallback      *slog.Logger
 	sqlLogging    bool
 	queryDuration observability.Histogram
+	rowsCopied    observability.Counter
+	slowThreshold time.Duration
 }
 
 func newInstrumentation(
@@ -36,6 +46,7 @@ func newInstrumentation(
 		driver:        driver,
 		attrs:         cloneFields(attrs),
 		obs:           obs,
+		observed:      !isNoopObservability(obs),
 		fallback:      fallback,
 		sqlLogging:    sqlLogging,
 		queryDuration: obs.Metrics().HistogramWithBuckets(
@@ -44,9 +55,21 @@ func newInstrumentation(
 			"ms",
 			[]float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000},
 		),
+		rowsCopied: obs.Metrics().Counter(
+			"database.copy.rows",
+			"Rows written by bulk copy",
+			"{row}",
+		),
 	}
 }
 
+// withSlowQueryThreshold enables the slow-query log for operations that take
+// at least d. Zero disables it.
+func (i instrumentation) withSlowQueryThreshold(d time.Duration) instrumentation {
+	i.slowThreshold = d
+	return i
+}
+
 func (i instrumentation) WrapDBTX(dbtx database.DBTX) database.DBTX {
 	return &instrumentedDBTX{base: dbtx, inst: i}
 }
@@ -58,24 +81,60 @@ func (i instrumentation) WrapTx(tx database.Tx) database.Tx {
 	}
 }
 
-func (i instrumentation) start(ctx context.Context, op string) (context.Context, observability.Span, time.Time) {
-	spanName := "db." + string(i.driver) + "." + op
+// queryCall carries one instrumented operation from start to finish. The
+// statement and fingerprint are only computed when something records them.
+type queryCall struct {
+	ctx         context.Context
+	span        observability.Span
+	op          string
+	query       string
+	args        []any
+	statement   string
+	fingerprint string
+	start       time.Time
+	callers     []uintptr
+}
+
+func (i instrumentation) start(ctx context.Context, op, query string, args []any) *queryCall {
+	call := &queryCall{op: op, query: query}
+	if i.sqlLogging {
+		call.args = append([]any(nil), args...)
+	}
 	fields := append(cloneFields(i.attrs), observability.String("db.operation", op))
-	ctx, span := i.obs.Tracer().Start(ctx, spanName, observability.WithAttributes(fields...))
-	return ctx, span, time.Now()
-}
-
-func (i instrumentation) finish(
-	ctx context.Context,
-	span observability.Span,
-	op string,
-	query string,
-	args []any,
-	start time.Time,
-	err error,
-) {
-	duration := time.Since(start)
-	metricFields := append(cloneFields(i.attrs), observability.String("db.operation", op))
+	if tenant, ok := database.TenantFromContext(ctx); ok {
+		fields = append(fields, observability.String(tenantAttr, tenant))
+	}
+	if i.observed && query != "" {
+		call.normalize()
+		fields = append(fields,
+			observability.String("db.statement", call.statement),
+			observability.String("db.query.fingerprint", call.fingerprint),
+		)
+	}
+	if i.slowThreshold > 0 {
+		call.callers = captureCallers()
+	}
+	call.ctx, call.span = i.obs.Tracer().Start(ctx, "db."+string(i.driver)+"."+op, observability.WithAttributes(fields...))
+	call.start = time.Now()
+	return call
+}
+
+func (c *queryCall) normalize() {
+	if c.statement == "" {
+		c.statement = normalizeQuery(c.query)
+		c.fingerprint = fingerprint(c.statement)
+	}
+}
+
+// finish ends the span, records latency and emits the SQL and slow-query
+// logs. rows is the number of rows written or read, or -1 when unknown.
+func (i instrumentation) finish(call *queryCall, rows int64, err error) {
+	ctx, span := call.ctx, call.span
+	duration := time.Since(call.start)
+	metricFields := append(cloneFields(i.attrs), observability.String("db.operation", call.op))
+	if call.fingerprint != "" {
+		metricFields = append(metricFields, observability.String("db.query.fingerprint", call.fingerprint))
+	}
 	i.queryDuration.Record(ctx, float64(duration.Milliseconds()), metricFields...)
 
 	if err != nil {
@@ -86,27 +145,68 @@ func (i instrumentation) finish(
 	}
 	span.End()
 
+	if i.slowThreshold > 0 && duration >= i.slowThreshold {
+		i.logSlowQuery(call, duration, rows, err)
+	}
+
 	if !i.sqlLogging {
 		return
 	}
 
 	fields := append(
-		append(cloneFields(i.attrs), observability.String("db.operation", op)),
-		observability.String("query", query),
-		observability.Any("args", redactedArgs(args)),
+		append(cloneFields(i.attrs), observability.String("db.operation", call.op)),
+		observability.String("query", call.query),
+		observability.Any("args", redactedArgs(call.args)),
+		observability.Int64("duration_ms", duration.Milliseconds()),
+	)
+	if err != nil {
+		fields = append(fields, observability.Error(err))
+	}
+	i.log(ctx, observability.LogLevelDebug, "database query executed", fields)
+}
+
+func (i instrumentation) logSlowQuery(call *queryCall, duration time.Duration, rows int64, err error) {
+	fields := append(cloneFields(i.attrs), observability.String("db.operation", call.op))
+	if call.query != "" {
+		call.normalize()
+		fields = append(fields,
+			observability.String("db.statement", call.statement),
+			observability.String("db.query.fingerprint", call.fingerprint),
+		)
+	}
+	fields = append(fields,
 		observability.Int64("duration_ms", duration.Milliseconds()),
+		observability.Int64("threshold_ms", i.slowThreshold.Milliseconds()),
 	)
+	if rows >= 0 {
+		fields = append(fields, observability.Int64("rows", rows))
+	}
+	if caller := resolveCaller(call.callers); caller != "" {
+		fields = append(fields, observability.String("caller", caller))
+	}
 	if err != nil {
 		fields = append(fields, observability.Error(err))
 	}
+	i.log(call.ctx, observability.LogLevelWarn, "database slow query", fields)
+}
 
+func (i instrumentation) log(ctx context.Context, level observability.LogLevel, msg string, fields []observability.Field) {
 	logger := i.obs.Logger()
 	if logger != nil && !isNoopObservability(i.obs) {
-		logger.Debug(ctx, "database query executed", fields...)
+		if level == observability.LogLevelWarn {
+			logger.Warn(ctx, msg, fields...)
+		} else {
+			logger.Debug(ctx, msg, fields...)
+		}
 		return
 	}
-	if i.fallback != nil {
-		i.fallback.DebugContext(ctx, "database query executed", slogFields(fields)...)
+	if i.fallback == nil {
+		return
+	}
+	if level == observability.LogLevelWarn {
+		i.fallback.WarnContext(ctx, msg, slogFields(fields)...)
+	} else {
+		i.fallback.DebugContext(ctx, msg, slogFields(fields)...)
 	}
 }
 
@@ -116,49 +216,56 @@ type instrumentedDBTX struct {
 }
 
 func (d *instrumentedDBTX) ExecContext(ctx context.Context, query string, args ...any) (database.Result, error) {
-	ctx, span, start := d.inst.start(ctx, "exec")
-	result, err := d.base.ExecContext(ctx, query, args...)
-	d.inst.finish(ctx, span, "exec", query, args, start, err)
+	call := d.inst.start(ctx, "exec", query, args)
+	result, err := d.base.ExecContext(call.ctx, query, args...)
+	rows := int64(-1)
+	if err == nil && result != nil {
+		if n, affectedErr := result.RowsAffected(); affectedErr == nil {
+			rows = n
+		}
+	}
+	d.inst.finish(call, rows, err)
 	return result, err
 }
 
 func (d *instrumentedDBTX) QueryContext(ctx context.Context, query string, args ...any) (database.Rows, error) {
-	ctx, span, start := d.inst.start(ctx, "query")
-	rows, err := d.base.QueryContext(ctx, query, args...)
+	call := d.inst.start(ctx, "query", query, args)
+	rows, err := d.base.QueryContext(call.ctx, query, args...)
 	if err != nil {
-		d.inst.finish(ctx, span, "query", query, args, start, err)
+		d.inst.finish(call, -1, err)
 		return nil, err
 	}
-	return &instrumentedRows{
-		base:  rows,
-		ctx:   ctx,
-		query: query,
-		args:  append([]any(nil), args...),
-		op:    "query",
-		start: start,
-		span:  span,
-		inst:  d.inst,
-	}, nil
+	return &instrumentedRows{base: rows, call: call, inst: d.inst}, nil
 }
 
 func (d *instrumentedDBTX) QueryRowContext(ctx context.Context, query string, args ...any) database.Row {
-	ctx, span, start := d.inst.start(ctx, "query_row")
-	row := d.base.QueryRowContext(ctx, query, args...)
-	state := &rowSpanState{
-		ctx:   ctx,
-		query: query,
-		args:  append([]any(nil), args...),
-		op:    "query_row",
-		start: start,
-		span:  span,
-		inst:  d.inst,
-		once:  &sync.Once{},
-	}
+	call := d.inst.start(ctx, "query_row", query, args)
+	row := d.base.QueryRowContext(call.ctx, query, args...)
+	state := &rowSpanState{call: call, inst: d.inst, once: &sync.Once{}}
 	r := &instrumentedRow{base: row, state: state}
 	r.cleanup = runtime.AddCleanup(r, finishOrphanRowSpan, state)
 	return r
 }
 
+func (d *instrumentedDBTX) CopyFrom(ctx context.Context, table string, columns []string, src database.CopySource) (int64, error) {
+	copier, ok := d.base.(database.Copier)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", database.ErrCopyUnsupported, d.inst.driver)
+	}
+	call := d.inst.start(ctx, "copy", "COPY "+table+" ("+strings.Join(columns, ", ")+")", nil)
+	n, err := copier.CopyFrom(call.ctx, table, columns, src)
+	call.span.SetAttributes(
+		observability.String("db.sql.table", table),
+		observability.Int64("db.rows_written", n),
+	)
+	if n > 0 {
+		fields := append(cloneFields(d.inst.attrs), observability.String("db.sql.table", table))
+		d.inst.rowsCopied.Add(call.ctx, n, fields...)
+	}
+	d.inst.finish(call, n, err)
+	return n, err
+}
+
 type instrumentedTx struct {
 	base database.Tx
 	dbtx instrumentedDBTX
@@ -176,44 +283,54 @@ func (t *instrumentedTx) QueryRowContext(ctx context.Context, query string, args
 	return t.dbtx.QueryRowContext(ctx, query, args...)
 }
 
+func (t *instrumentedTx) CopyFrom(ctx context.Context, table string, columns []string, src database.CopySource) (int64, error) {
+	return t.dbtx.CopyFrom(ctx, table, columns, src)
+}
+
 func (t *instrumentedTx) Commit(ctx context.Context) error {
-	ctx, span, start := t.dbtx.inst.start(ctx, "commit")
-	err := t.base.Commit(ctx)
-	t.dbtx.inst.finish(ctx, span, "commit", "", nil, start, err)
+	call := t.dbtx.inst.start(ctx, "commit", "", nil)
+	err := t.base.Commit(call.ctx)
+	t.dbtx.inst.finish(call, -1, err)
 	return err
 }
 
 func (t *instrumentedTx) Rollback(ctx context.Context) error {
-	ctx, span, start := t.dbtx.inst.start(ctx, "rollback")
-	err := t.base.Rollback(ctx)
-	t.dbtx.inst.finish(ctx, span, "rollback", "", nil, start, err)
+	call := t.dbtx.inst.start(ctx, "rollback", "", nil)
+	err := t.base.Rollback(call.ctx)
+	t.dbtx.inst.finish(call, -1, err)
 	return err
 }
 
 type instrumentedRows struct {
-	base  database.Rows
-	ctx   context.Context
-	query string
-	args  []any
-	op    string
-	start time.Time
-	span  observability.Span
-	inst  instrumentation
-	once  sync.Once
+	base database.Rows
+	call *queryCall
+	inst instrumentation
+	read int64
+	once sync.Once
 }
 
 func (r *instrumentedRows) Next() bool {
 	next := r.base.Next()
 	if !next {
 		r.finish(r.base.Err())
+		return false
 	}
-	return next
+	r.read++
+	return true
 }
 
 func (r *instrumentedRows) Scan(dest ...any) error {
 	return r.base.Scan(dest...)
 }
 
+func (r *instrumentedRows) Columns() ([]string, error) {
+	lister, ok := r.base.(interface{ Columns() ([]string, error) })
+	if !ok {
+		return nil, database.ErrColumnsUnavailable
+	}
+	return lister.Columns()
+}
+
 func (r *instrumentedRows) Close() error {
 	err := r.base.Close()
 	r.finish(err)
@@ -230,19 +347,14 @@ func (r *instrumentedRows) Err() error {
 
 func (r *instrumentedRows) finish(err error) {
 	r.once.Do(func() {
-		r.inst.finish(r.ctx, r.span, r.op, r.query, r.args, r.start, err)
+		r.inst.finish(r.call, r.read, err)
 	})
 }
 
 type rowSpanState struct {
-	ctx   context.Context
-	query string
-	args  []any
-	op    string
-	start time.Time
-	span  observability.Span
-	inst  instrumentation
-	once  *sync.Once
+	call *queryCall
+	inst instrumentation
+	once *sync.Once
 }
 
 type instrumentedRow struct {
@@ -254,7 +366,11 @@ type instrumentedRow struct {
 func (r *instrumentedRow) Scan(dest ...any) error {
 	err := r.base.Scan(dest...)
 	r.state.once.Do(func() {
-		r.state.inst.finish(r.state.ctx, r.state.span, r.state.op, r.state.query, r.state.args, r.state.start, err)
+		rows := int64(0)
+		if err == nil {
+			rows = 1
+		}
+		r.state.inst.finish(r.state.call, rows, err)
 	})
 	r.cleanup.Stop()
 	return err
@@ -262,7 +378,7 @@ func (r *instrumentedRow) Scan(dest ...any) error {
 
 func finishOrphanRowSpan(state *rowSpanState) {
 	state.once.Do(func() {
-		state.inst.finish(state.ctx, state.span, state.op, state.query, state.args, state.start, nil)
+		state.inst.finish(state.call, -1, nil)
 	})
 }
 
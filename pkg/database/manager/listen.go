@@ -0,0 +1,94 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+)
+
+var _ database.Listener = (*dbManager)(nil)
+
+// listenSet tracks open subscriptions so Shutdown can close them; each one
+// pins a connection that would otherwise keep the pool from closing.
+type listenSet struct {
+	mu   sync.Mutex
+	subs map[*managedSubscription]struct{}
+}
+
+// Listen subscribes a pinned connection to the postgres channels. Other
+// drivers return database.ErrListenUnsupported.
+func (m *dbManager) Listen(ctx context.Context, channels ...string) (database.Subscription, error) {
+	m.mu.RLock()
+	closed := m.closed
+	m.mu.RUnlock()
+	if closed {
+		return nil, database.ErrManagerClosed
+	}
+	listener, ok := m.adapterListener()
+	if !ok {
+		return nil, database.ErrListenUnsupported
+	}
+
+	ctx, span := m.inst.obs.Tracer().Start(ctx, "db."+string(m.inst.driver)+".listen", observability.WithAttributes(
+		append(cloneFields(m.inst.attrs), observability.String("db.listen.channels", strings.Join(channels, ",")))...,
+	))
+	defer span.End()
+
+	sub, err := listener.Listen(ctx, channels...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(observability.StatusCodeError, err.Error())
+		return nil, err
+	}
+	span.SetStatus(observability.StatusCodeOK, "ok")
+
+	managed := &managedSubscription{Subscription: sub, set: &m.listens}
+	m.listens.mu.Lock()
+	if m.listens.subs == nil {
+		m.listens.subs = map[*managedSubscription]struct{}{}
+	}
+	m.listens.subs[managed] = struct{}{}
+	m.listens.mu.Unlock()
+	return managed, nil
+}
+
+func (m *dbManager) adapterListener() (database.Listener, bool) {
+	var adapter any = m.adapter
+	if ext, ok := m.adapter.(*externalAdapter); ok {
+		adapter = ext.DriverAdapter
+	}
+	listener, ok := adapter.(database.Listener)
+	return listener, ok
+}
+
+// closeListens closes the subscriptions still open.
+func (m *dbManager) closeListens(ctx context.Context) error {
+	m.listens.mu.Lock()
+	subs := make([]*managedSubscription, 0, len(m.listens.subs))
+	for sub := range m.listens.subs {
+		subs = append(subs, sub)
+	}
+	m.listens.mu.Unlock()
+
+	var errs []error
+	for _, sub := range subs {
+		errs = append(errs, sub.Close(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+type managedSubscription struct {
+	database.Subscription
+	set *listenSet
+}
+
+func (s *managedSubscription) Close(ctx context.Context) error {
+	s.set.mu.Lock()
+	delete(s.set.subs, s)
+	s.set.mu.Unlock()
+	return s.Subscription.Close(ctx)
+}
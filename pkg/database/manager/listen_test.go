@@ -0,0 +1,63 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability/fake"
+)
+
+// listeningAdapter entrega assinaturas que apenas registram o Close.
+type listeningAdapter struct {
+	*mockAdapter
+	channels []string
+}
+
+func (a *listeningAdapter) Listen(_ context.Context, channels ...string) (database.Subscription, error) {
+	a.channels = channels
+	return &closeRecordingSubscription{}, nil
+}
+
+type closeRecordingSubscription struct{ closes int }
+
+func (s *closeRecordingSubscription) Next(context.Context) (database.Notification, error) {
+	return database.Notification{}, database.ErrSubscriptionClosed
+}
+func (s *closeRecordingSubscription) Close(context.Context) error {
+	s.closes++
+	return nil
+}
+
+func TestListen_UnsupportedByAdapter(t *testing.T) {
+	mgr := newTestManager(&mockAdapter{driver: database.DriverMySQL, dbtx: &stubDBTX{}})
+
+	_, err := mgr.Listen(context.Background(), "orders")
+	require.ErrorIs(t, err, database.ErrListenUnsupported)
+}
+
+func TestListen_EmitsSpanAndShutdownClosesSubscriptions(t *testing.T) {
+	obs := fake.NewProvider()
+	adapter := &listeningAdapter{mockAdapter: &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}}
+	mgr := newTestManager(adapter, WithObservability(obs))
+
+	open, err := mgr.Listen(context.Background(), "orders", "payments")
+	require.NoError(t, err)
+	closed, err := mgr.Listen(context.Background(), "orders")
+	require.NoError(t, err)
+	require.Equal(t, []string{"orders"}, adapter.channels)
+	require.NoError(t, closed.Close(context.Background()))
+
+	spans := obs.Tracer().(*fake.FakeTracer).GetSpans()
+	require.Equal(t, "db.postgres.listen", spans[0].Name)
+
+	require.NoError(t, mgr.Shutdown(context.Background()))
+	require.Equal(t, 1, open.(*managedSubscription).Subscription.(*closeRecordingSubscription).closes)
+	require.Equal(t, 1, closed.(*managedSubscription).Subscription.(*closeRecordingSubscription).closes,
+		"assinaturas já fechadas não são fechadas de novo")
+
+	_, err = mgr.Listen(context.Background(), "orders")
+	require.ErrorIs(t, err, database.ErrManagerClosed)
+}
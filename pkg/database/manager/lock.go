@@ -0,0 +1,129 @@
+package manager
+
+import (
+	"context"
+	"errors"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/locking"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+)
+
+// lockSupport builds the Locker of the adapter: session locks on a pinned
+// connection, or a lease table for cockroach. Drivers without locks keep the
+// reason in err and report it on every call.
+type lockSupport struct {
+	locker *locking.Locker
+	err    error
+	held   observability.UpDownCounter
+}
+
+func newLockSupport(adapter driverAdapter, inst instrumentation) lockSupport {
+	held := inst.obs.Metrics().UpDownCounter(
+		"database.lock.held",
+		"Distributed locks currently held",
+		"{lock}",
+	)
+	cfg := locking.Config{
+		Driver: adapter.Driver(),
+		DB:     adapter.DBTX(),
+		OnRelease: func(name string) {
+			held.Add(context.Background(), -1, lockFields(inst, name)...)
+		},
+	}
+	if pinner, ok := adapter.(locking.Pinner); ok {
+		cfg.Pin = pinner.Conn
+	}
+	locker, err := locking.New(cfg)
+	return lockSupport{locker: locker, err: err, held: held}
+}
+
+func lockFields(inst instrumentation, name string) []observability.Field {
+	return append(cloneFields(inst.attrs), observability.String("db.lock.name", name))
+}
+
+func (m *dbManager) TryLock(ctx context.Context, name string) (database.Lock, bool, error) {
+	return m.acquireLock(ctx, name, false)
+}
+
+func (m *dbManager) Lock(ctx context.Context, name string) (database.Lock, error) {
+	lock, _, err := m.acquireLock(ctx, name, true)
+	return lock, err
+}
+
+func (m *dbManager) WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	return database.WithLock(ctx, m, name, fn)
+}
+
+func (m *dbManager) acquireLock(ctx context.Context, name string, wait bool) (database.Lock, bool, error) {
+	m.mu.RLock()
+	closed := m.closed
+	m.mu.RUnlock()
+	if closed {
+		return nil, false, database.ErrManagerClosed
+	}
+	if m.locks.err != nil {
+		return nil, false, m.locks.err
+	}
+
+	fields := lockFields(m.inst, name)
+	ctx, span := m.inst.obs.Tracer().Start(ctx, "db."+string(m.inst.driver)+".lock.acquire", observability.WithAttributes(
+		append(fields, observability.Bool("db.lock.wait", wait))...,
+	))
+	defer span.End()
+
+	var (
+		lock database.Lock
+		ok   bool
+		err  error
+	)
+	if wait {
+		lock, err = m.locks.locker.Lock(ctx, name)
+		ok = err == nil
+	} else {
+		lock, ok, err = m.locks.locker.TryLock(ctx, name)
+	}
+	span.SetAttributes(observability.Bool("db.lock.acquired", ok))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(observability.StatusCodeError, err.Error())
+		return nil, false, err
+	}
+	span.SetStatus(observability.StatusCodeOK, "ok")
+	if !ok {
+		return nil, false, nil
+	}
+	m.locks.held.Add(ctx, 1, fields...)
+	return &instrumentedLock{Lock: lock, inst: m.inst}, true, nil
+}
+
+// closeLocks releases the locks still held so that their pinned connections
+// go back to the pool before it closes.
+func (m *dbManager) closeLocks(ctx context.Context) error {
+	if m.locks.locker == nil {
+		return nil
+	}
+	return m.locks.locker.Close(ctx)
+}
+
+type instrumentedLock struct {
+	database.Lock
+	inst instrumentation
+}
+
+func (l *instrumentedLock) Unlock(ctx context.Context) error {
+	ctx, span := l.inst.obs.Tracer().Start(ctx, "db."+string(l.inst.driver)+".lock.release", observability.WithAttributes(
+		lockFields(l.inst, l.Name())...,
+	))
+	defer span.End()
+
+	err := l.Lock.Unlock(ctx)
+	if err != nil && !errors.Is(err, database.ErrLockNotHeld) {
+		span.RecordError(err)
+		span.SetStatus(observability.StatusCodeError, err.Error())
+		return err
+	}
+	span.SetAttributes(observability.Bool("db.lock.lost", err != nil))
+	span.SetStatus(observability.StatusCodeOK, "ok")
+	return err
+}
@@ -0,0 +1,91 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/locking"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability/fake"
+)
+
+// pinningAdapter fixa conexões em que toda função de lock do postgres
+// responde true.
+type pinningAdapter struct {
+	*mockAdapter
+}
+
+func (a *pinningAdapter) Conn(context.Context) (locking.Conn, error) { return &lockConn{}, nil }
+
+type lockConn struct{ stubDBTX }
+
+func (c *lockConn) ExecContext(context.Context, string, ...any) (database.Result, error) {
+	return stubResult{}, nil
+}
+func (c *lockConn) QueryRowContext(context.Context, string, ...any) database.Row { return trueRow{} }
+func (c *lockConn) Release()                                                     {}
+func (c *lockConn) Destroy()                                                     {}
+
+type trueRow struct{}
+
+func (trueRow) Scan(dest ...any) error {
+	*dest[0].(*bool) = true
+	return nil
+}
+
+func TestLock_UnsupportedWithoutPinnedConnections(t *testing.T) {
+	mgr := newTestManager(&mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}})
+
+	_, _, err := mgr.TryLock(context.Background(), "jobs")
+	require.ErrorIs(t, err, database.ErrLockUnsupported)
+	_, err = mgr.Lock(context.Background(), "jobs")
+	require.ErrorIs(t, err, database.ErrLockUnsupported)
+	err = mgr.WithLock(context.Background(), "jobs", func(context.Context) error { return nil })
+	require.ErrorIs(t, err, database.ErrLockUnsupported)
+}
+
+func TestLock_EmitsSpansAndHeldGauge(t *testing.T) {
+	obs := fake.NewProvider()
+	adapter := &pinningAdapter{&mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}}
+	mgr := newTestManager(adapter, WithObservability(obs))
+
+	lock, ok, err := mgr.TryLock(context.Background(), "jobs")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, lock.Unlock(context.Background()))
+
+	spans := obs.Tracer().(*fake.FakeTracer).GetSpans()
+	require.Len(t, spans, 2)
+	require.Equal(t, "db.postgres.lock.acquire", spans[0].Name)
+	require.Contains(t, spans[0].Attributes, observability.String("db.lock.name", "jobs"))
+	require.Contains(t, spans[0].Attributes, observability.Bool("db.lock.acquired", true))
+	require.Equal(t, "db.postgres.lock.release", spans[1].Name)
+	require.Contains(t, spans[1].Attributes, observability.Bool("db.lock.lost", false))
+
+	held := obs.Metrics().(*fake.FakeMetrics).GetUpDownCounter("database.lock.held")
+	require.NotNil(t, held)
+	values := held.GetValues()
+	require.Len(t, values, 2)
+	require.Equal(t, int64(1), values[0].Value)
+	require.Equal(t, int64(-1), values[1].Value)
+}
+
+func TestShutdown_ReleasesHeldLocks(t *testing.T) {
+	adapter := &pinningAdapter{&mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}}
+	mgr := newTestManager(adapter)
+
+	lock, err := mgr.Lock(context.Background(), "jobs")
+	require.NoError(t, err)
+	require.NoError(t, mgr.Shutdown(context.Background()))
+
+	select {
+	case <-lock.Done():
+	default:
+		t.Fatal("o Shutdown deve liberar os locks antes de fechar o pool")
+	}
+	_, err = mgr.Lock(context.Background(), "jobs")
+	require.ErrorIs(t, err, database.ErrManagerClosed)
+}
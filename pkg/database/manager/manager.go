@@ -2,8 +2,10 @@ package manager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 	"sync"
 	"time"
 
@@ -28,6 +30,13 @@ type Manager interface {
 	BeginTx(ctx context.Context, opts database.TxOptions) (database.Tx, error)
 	Ping(ctx context.Context) error
 
+	// TryLock and Lock take named locks shared by every process connected
+	// to the same database. Drivers without them return
+	// database.ErrLockUnsupported.
+	database.Locker
+	// WithLock runs fn while holding name; see database.WithLock.
+	WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error
+
 	Shutdown(ctx context.Context) error
 }
 
@@ -50,12 +59,19 @@ type dbManager struct {
 	scraper  *internalpool.Scraper
 	inst     instrumentation
 	poolDBTX database.DBTX
+	replicas *replicaSet
+	locks    lockSupport
+	listens  listenSet
 }
 
 var closedDBTXSingleton database.DBTX = &closedDBTX{}
 
 func New(cfg DriverConfig, opts ...Option) (Manager, error) {
-	resolvedCfg, err := resolveConfig(cfg)
+	return newManager(cfg, os.Getenv, opts)
+}
+
+func newManager(cfg DriverConfig, getenv func(string) string, opts []Option) (Manager, error) {
+	resolvedCfg, err := resolveConfig(cfg, getenv)
 	if err != nil {
 		return nil, err
 	}
@@ -72,21 +88,29 @@ func New(cfg DriverConfig, opts ...Option) (Manager, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := runStartupMigrationsFunc(resolvedCfg, adapter.Driver(), o); err != nil {
+	if err := runStartupMigrationsFunc(resolvedCfg, adapter.Driver(), adapter.DBTX(), o); err != nil {
+		_ = adapter.Close(context.Background())
+		return nil, err
+	}
+	replicas, err := buildReplicas(adapter.Driver(), o)
+	if err != nil {
 		_ = adapter.Close(context.Background())
 		return nil, err
 	}
 
+	attrs := withRole(adapter.Attributes(), rolePrimary)
 	fallbackLogger := resolveLogger(o)
 	mgr := &dbManager{
-		adapter: adapter,
-		opts:    o,
-		logger:  fallbackLogger,
-		inst:    newInstrumentation(adapter.Driver(), adapter.Attributes(), o.observability, fallbackLogger, o.sqlLogging),
+		adapter:  adapter,
+		opts:     o,
+		logger:   fallbackLogger,
+		inst:     newInstrumentation(adapter.Driver(), attrs, o.observability, fallbackLogger, o.sqlLogging).withSlowQueryThreshold(o.slowQueryThreshold),
+		replicas: replicas,
 	}
 	mgr.poolDBTX = mgr.inst.WrapDBTX(adapter.DBTX())
+	mgr.locks = newLockSupport(adapter, mgr.inst)
 	if !isNoopObservability(o.observability) {
-		mgr.scraper = internalpool.NewScraper(adapter.Stats, o.observability.Metrics(), resolvePoolStatsInterval(o), adapter.Attributes()...)
+		mgr.scraper = internalpool.NewScraper(adapter.Stats, o.observability.Metrics(), resolvePoolStatsInterval(o), attrs...)
 	}
 	return mgr, nil
 }
@@ -121,17 +145,22 @@ func NewFromAdapter(adapter DriverAdapter, opts ...Option) (Manager, error) {
 	for _, opt := range opts {
 		opt(&o)
 	}
+	if len(o.replicas) > 0 {
+		return nil, fmt.Errorf("%w: replicas need a DriverConfig; use New with WithReplicas", database.ErrInvalidConfig)
+	}
 	internalAdapter := &externalAdapter{DriverAdapter: adapter}
+	attrs := withRole(adapter.Attributes(), rolePrimary)
 	fallbackLogger := resolveLogger(o)
 	mgr := &dbManager{
 		adapter: internalAdapter,
 		opts:    o,
 		logger:  fallbackLogger,
-		inst:    newInstrumentation(adapter.Driver(), adapter.Attributes(), o.observability, fallbackLogger, o.sqlLogging),
+		inst:    newInstrumentation(adapter.Driver(), attrs, o.observability, fallbackLogger, o.sqlLogging).withSlowQueryThreshold(o.slowQueryThreshold),
 	}
 	mgr.poolDBTX = mgr.inst.WrapDBTX(adapter.DBTX())
+	mgr.locks = newLockSupport(adapter, mgr.inst)
 	if !isNoopObservability(o.observability) {
-		mgr.scraper = internalpool.NewScraper(adapter.Stats, o.observability.Metrics(), resolvePoolStatsInterval(o), adapter.Attributes()...)
+		mgr.scraper = internalpool.NewScraper(adapter.Stats, o.observability.Metrics(), resolvePoolStatsInterval(o), attrs...)
 	}
 	return mgr, nil
 }
@@ -141,7 +170,7 @@ type externalAdapter struct {
 }
 
 func resolveLogger(o options) *slog.Logger {
-	if !o.sqlLogging || !isNoopObservability(o.observability) {
+	if (!o.sqlLogging && o.slowQueryThreshold <= 0) || !isNoopObservability(o.observability) {
 		return nil
 	}
 	return slog.Default()
@@ -168,6 +197,9 @@ func (m *dbManager) DBTX(ctx context.Context) database.DBTX {
 		return closedDBTXSingleton
 	}
 	m.mu.RUnlock()
+	if r := m.readReplica(database.IsReadOnly(ctx)); r != nil {
+		return r.dbtx
+	}
 	return m.poolDBTX
 }
 
@@ -185,12 +217,25 @@ func (m *dbManager) BeginTx(ctx context.Context, opts database.TxOptions) (datab
 		effectiveOpts.ReadOnly = true
 	}
 
-	tx, err := m.adapter.BeginTx(ctx, effectiveOpts)
+	adapter, inst := m.adapter, m.inst
+	if r := m.readReplica(effectiveOpts.ReadOnly); r != nil {
+		adapter, inst = r.adapter, r.inst
+	}
+
+	tx, err := adapter.BeginTx(ctx, effectiveOpts)
 	if err != nil {
 		m.activeTx.Done()
 		return nil, err
 	}
-	return &trackedTx{Tx: m.inst.WrapTx(tx), wg: &m.activeTx}, nil
+	return &trackedTx{Tx: inst.WrapTx(tx), wg: &m.activeTx}, nil
+}
+
+// readReplica routes DBTX and BeginTx alike; a WithReadOnly manager reads only from replicas.
+func (m *dbManager) readReplica(readOnly bool) *replica {
+	if !readOnly && !m.opts.readOnly {
+		return nil
+	}
+	return m.replicas.pick()
 }
 
 type trackedTx struct {
@@ -211,6 +256,10 @@ func (t *trackedTx) Rollback(ctx context.Context) error {
 	return err
 }
 
+func (t *trackedTx) CopyFrom(ctx context.Context, table string, columns []string, src database.CopySource) (int64, error) {
+	return database.CopyFrom(ctx, t.Tx, table, columns, src)
+}
+
 func (m *dbManager) Ping(ctx context.Context) error {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -226,6 +275,7 @@ func (m *dbManager) Shutdown(ctx context.Context) error {
 		m.mu.Lock()
 		m.closed = true
 		m.mu.Unlock()
+		m.replicas.stopHealthChecks()
 
 		shutdownCtx := ctx
 		cancel := func() {}
@@ -253,7 +303,8 @@ func (m *dbManager) Shutdown(ctx context.Context) error {
 
 		done := make(chan error, 1)
 		go func() {
-			done <- m.adapter.Close(shutdownCtx)
+			pinnedErr := errors.Join(m.closeLocks(shutdownCtx), m.closeListens(shutdownCtx))
+			done <- errors.Join(pinnedErr, m.adapter.Close(shutdownCtx), m.replicas.close(shutdownCtx))
 		}()
 
 		select {
@@ -280,6 +331,10 @@ func (c *closedDBTX) QueryRowContext(_ context.Context, _ string, _ ...any) data
 	return &closedRow{}
 }
 
+func (c *closedDBTX) CopyFrom(_ context.Context, _ string, _ []string, _ database.CopySource) (int64, error) {
+	return 0, database.ErrManagerClosed
+}
+
 type closedRow struct{}
 
 func (r *closedRow) Scan(_ ...any) error { return database.ErrManagerClosed }
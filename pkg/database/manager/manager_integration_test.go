@@ -214,3 +214,35 @@ func TestIntegration_BeginTx_RespectsMaxOpenConns(t *testing.T) {
 		t.Fatal("segunda tx não iniciou após a liberação da conexão do pool")
 	}
 }
+
+func TestIntegration_Listen_ReceivesNotifyAndShutdownClosesSubscription(t *testing.T) {
+	cfg := setupPostgresContainer(t)
+
+	mgr, err := manager.New(cfg)
+	require.NoError(t, err)
+
+	listener, ok := mgr.(database.Listener)
+	require.True(t, ok, "o manager do postgres deve implementar database.Listener")
+
+	ctx := context.Background()
+	sub, err := listener.Listen(ctx, "orders")
+	require.NoError(t, err)
+
+	_, err = mgr.DBTX(ctx).ExecContext(ctx, "SELECT pg_notify('orders', 'created')")
+	require.NoError(t, err)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	n, err := sub.Next(waitCtx)
+	require.NoError(t, err)
+	require.Equal(t, "orders", n.Channel)
+	require.Equal(t, "created", n.Payload)
+
+	next := make(chan error, 1)
+	go func() {
+		_, err := sub.Next(ctx)
+		next <- err
+	}()
+	require.NoError(t, mgr.Shutdown(ctx), "o Shutdown deve fechar a assinatura antes do pool")
+	require.ErrorIs(t, <-next, database.ErrSubscriptionClosed)
+}
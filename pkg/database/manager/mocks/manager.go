@@ -203,6 +203,74 @@ func (_c *MockManager_Driver_Call) RunAndReturn(run func() database.Driver) *Moc
 	return _c
 }
 
+// Lock provides a mock function for the type MockManager
+func (_mock *MockManager) Lock(ctx context.Context, name string) (database.Lock, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 database.Lock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (database.Lock, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) database.Lock); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(database.Lock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockManager_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type MockManager_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockManager_Expecter) Lock(ctx interface{}, name interface{}) *MockManager_Lock_Call {
+	return &MockManager_Lock_Call{Call: _e.mock.On("Lock", ctx, name)}
+}
+
+func (_c *MockManager_Lock_Call) Run(run func(ctx context.Context, name string)) *MockManager_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockManager_Lock_Call) Return(lock database.Lock, err error) *MockManager_Lock_Call {
+	_c.Call.Return(lock, err)
+	return _c
+}
+
+func (_c *MockManager_Lock_Call) RunAndReturn(run func(ctx context.Context, name string) (database.Lock, error)) *MockManager_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Ping provides a mock function for the type MockManager
 func (_mock *MockManager) Ping(ctx context.Context) error {
 	ret := _mock.Called(ctx)
@@ -304,3 +372,140 @@ func (_c *MockManager_Shutdown_Call) RunAndReturn(run func(ctx context.Context)
 	_c.Call.Return(run)
 	return _c
 }
+
+// TryLock provides a mock function for the type MockManager
+func (_mock *MockManager) TryLock(ctx context.Context, name string) (database.Lock, bool, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TryLock")
+	}
+
+	var r0 database.Lock
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (database.Lock, bool, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) database.Lock); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(database.Lock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, name)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockManager_TryLock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TryLock'
+type MockManager_TryLock_Call struct {
+	*mock.Call
+}
+
+// TryLock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockManager_Expecter) TryLock(ctx interface{}, name interface{}) *MockManager_TryLock_Call {
+	return &MockManager_TryLock_Call{Call: _e.mock.On("TryLock", ctx, name)}
+}
+
+func (_c *MockManager_TryLock_Call) Run(run func(ctx context.Context, name string)) *MockManager_TryLock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockManager_TryLock_Call) Return(lock database.Lock, b bool, err error) *MockManager_TryLock_Call {
+	_c.Call.Return(lock, b, err)
+	return _c
+}
+
+func (_c *MockManager_TryLock_Call) RunAndReturn(run func(ctx context.Context, name string) (database.Lock, bool, error)) *MockManager_TryLock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithLock provides a mock function for the type MockManager
+func (_mock *MockManager) WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	ret := _mock.Called(ctx, name, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithLock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, func(ctx context.Context) error) error); ok {
+		r0 = returnFunc(ctx, name, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockManager_WithLock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithLock'
+type MockManager_WithLock_Call struct {
+	*mock.Call
+}
+
+// WithLock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - fn func(ctx context.Context) error
+func (_e *MockManager_Expecter) WithLock(ctx interface{}, name interface{}, fn interface{}) *MockManager_WithLock_Call {
+	return &MockManager_WithLock_Call{Call: _e.mock.On("WithLock", ctx, name, fn)}
+}
+
+func (_c *MockManager_WithLock_Call) Run(run func(ctx context.Context, name string, fn func(ctx context.Context) error)) *MockManager_WithLock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 func(ctx context.Context) error
+		if args[2] != nil {
+			arg2 = args[2].(func(ctx context.Context) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockManager_WithLock_Call) Return(err error) *MockManager_WithLock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockManager_WithLock_Call) RunAndReturn(run func(ctx context.Context, name string, fn func(ctx context.Context) error) error) *MockManager_WithLock_Call {
+	_c.Call.Return(run)
+	return _c
+}
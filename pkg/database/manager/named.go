@@ -0,0 +1,13 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+// Named wraps the DBTX of ctx (the active transaction or the pool) for
+// `:name` parameters in the placeholder style of m's driver.
+func Named(ctx context.Context, m Manager) database.NamedDB {
+	return database.Named(m.DBTX(ctx), m.Driver())
+}
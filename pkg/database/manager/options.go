@@ -4,6 +4,8 @@ import (
 	"io/fs"
 	"time"
 
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+
 	"github.com/JailtonJunior94/devkit-go/pkg/observability"
 	"github.com/JailtonJunior94/devkit-go/pkg/observability/noop"
 )
@@ -14,20 +16,25 @@ const (
 )
 
 type options struct {
-	shutdownTimeout      time.Duration
-	sqlLogging           bool
-	observability        observability.Observability
-	readOnly             bool
-	poolStatsInterval    time.Duration
-	startupMigrationFS   fs.FS
-	startupMigrationRoot string
-	startupMigrationDir  string
+	shutdownTimeout       time.Duration
+	sqlLogging            bool
+	observability         observability.Observability
+	readOnly              bool
+	poolStatsInterval     time.Duration
+	startupMigrationFS    fs.FS
+	startupMigrationRoot  string
+	startupMigrationDir   string
+	startupDriftPolicy    database.DriftPolicy
+	replicas              []DriverConfig
+	replicaHealthInterval time.Duration
+	slowQueryThreshold    time.Duration
 }
 
 func defaultOptions() options {
 	return options{
-		shutdownTimeout: defaultShutdownTimeout,
-		observability:   noop.NewProvider(),
+		shutdownTimeout:    defaultShutdownTimeout,
+		observability:      noop.NewProvider(),
+		startupDriftPolicy: database.DriftIgnore,
 	}
 }
 
@@ -45,6 +52,15 @@ func WithSQLLogging(enabled bool) Option {
 	}
 }
 
+// WithSlowQueryThreshold logs, at warn level, every statement that takes at
+// least d together with its normalized text, duration, rows and caller. The
+// log is independent of WithSQLLogging. Zero or negative disables it.
+func WithSlowQueryThreshold(d time.Duration) Option {
+	return func(o *options) {
+		o.slowQueryThreshold = max(d, 0)
+	}
+}
+
 func WithObservability(obs observability.Observability) Option {
 	return func(o *options) {
 		if obs != nil {
@@ -84,3 +100,27 @@ func WithStartupMigrationDir(dir string) Option {
 		}
 	}
 }
+
+// WithStartupMigrationDriftPolicy turns on checksum tracking for startup
+// migrations and selects what they do when an already applied file was
+// modified, removed or added below the current version. The default,
+// database.DriftIgnore, neither verifies nor records checksums.
+func WithStartupMigrationDriftPolicy(policy database.DriftPolicy) Option {
+	return func(o *options) {
+		o.startupDriftPolicy = policy
+	}
+}
+
+func WithReplicas(cfgs ...DriverConfig) Option {
+	return func(o *options) {
+		o.replicas = append(o.replicas, cfgs...)
+	}
+}
+
+func WithReplicaHealthCheckInterval(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.replicaHealthInterval = d
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/postgres"
 	"github.com/JailtonJunior94/devkit-go/pkg/observability/noop"
 	"github.com/stretchr/testify/require"
 )
@@ -75,6 +77,31 @@ func TestWithPoolStatsInterval_ZeroOrNegative_Ignored(t *testing.T) {
 	require.Equal(t, time.Duration(0), o.poolStatsInterval)
 }
 
+func TestWithReplicas_AppendsConfigs(t *testing.T) {
+	o := defaultOptions()
+	WithReplicas(postgres.PostgresConfig{DSN: "a"})(&o)
+	WithReplicas(postgres.PostgresConfig{DSN: "b"}, postgres.PostgresConfig{DSN: "c"})(&o)
+	require.Len(t, o.replicas, 3)
+}
+
+func TestWithReplicaHealthCheckInterval_ZeroOrNegative_Ignored(t *testing.T) {
+	o := defaultOptions()
+	WithReplicaHealthCheckInterval(-time.Second)(&o)
+	require.Equal(t, time.Duration(0), o.replicaHealthInterval)
+
+	WithReplicaHealthCheckInterval(2 * time.Second)(&o)
+	require.Equal(t, 2*time.Second, o.replicaHealthInterval)
+}
+
+func TestWithSlowQueryThreshold_NegativeDisables(t *testing.T) {
+	o := defaultOptions()
+	WithSlowQueryThreshold(200 * time.Millisecond)(&o)
+	require.Equal(t, 200*time.Millisecond, o.slowQueryThreshold)
+
+	WithSlowQueryThreshold(-time.Second)(&o)
+	require.Equal(t, time.Duration(0), o.slowQueryThreshold)
+}
+
 // --- resolveLogger ---
 
 func TestResolveLogger_SQLLoggingDisabled_ReturnsNil(t *testing.T) {
@@ -98,3 +125,16 @@ func TestResolveLogger_SQLLoggingEnabled_NoopObs_FallbackSlogDefault(t *testing.
 	require.NotNil(t, logger, "esperava o fallback slog.Default() quando o obs é noop")
 	require.Equal(t, slog.Default(), logger)
 }
+
+func TestResolveLogger_SlowQueryThreshold_NoopObs_FallbackSlogDefault(t *testing.T) {
+	o := defaultOptions()
+	WithSlowQueryThreshold(time.Second)(&o)
+	require.Equal(t, slog.Default(), resolveLogger(o))
+}
+
+func TestWithStartupMigrationDriftPolicy(t *testing.T) {
+	o := defaultOptions()
+	require.Equal(t, database.DriftIgnore, o.startupDriftPolicy, "a verificação de checksum é opt-in")
+	WithStartupMigrationDriftPolicy(database.DriftFail)(&o)
+	require.Equal(t, database.DriftFail, o.startupDriftPolicy)
+}
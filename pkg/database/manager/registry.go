@@ -23,9 +23,22 @@ type DriverAdapter interface {
 
 type AdapterFactory func(cfg DriverConfig, obs observability.Observability) (DriverAdapter, error)
 
+// EnvConfigLoader builds the DriverConfig of a registered driver from DB_*
+// variables read through getenv. It backs New(nil) when DB_DRIVER names a
+// driver that is not built into this package.
+type EnvConfigLoader func(getenv func(string) string) (DriverConfig, error)
+
+// MigrationDSNProvider is implemented by configs of registered drivers that
+// support startup migrations. The DSN must use a scheme known to
+// golang-migrate.
+type MigrationDSNProvider interface {
+	MigrationDSN() string
+}
+
 var (
 	registryMu sync.RWMutex
 	registry   = map[string]AdapterFactory{}
+	envLoaders = map[database.Driver]EnvConfigLoader{}
 )
 
 func RegisterDriverFactory(cfg DriverConfig, factory AdapterFactory) {
@@ -46,6 +59,22 @@ func lookupDriverFactory(cfg DriverConfig) (AdapterFactory, bool) {
 	return f, ok
 }
 
+func RegisterEnvConfig(driver database.Driver, loader EnvConfigLoader) {
+	if driver == "" || loader == nil {
+		return
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	envLoaders[driver] = loader
+}
+
+func lookupEnvConfig(driver database.Driver) (EnvConfigLoader, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	loader, ok := envLoaders[driver]
+	return loader, ok
+}
+
 func typeKey(cfg DriverConfig) string {
 	t := reflect.TypeOf(cfg)
 	if t == nil {
@@ -3,9 +3,11 @@ package manager
 import (
 	"context"
 	"errors"
+	"os"
 	"testing"
 
 	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/postgres"
 	"github.com/JailtonJunior94/devkit-go/pkg/observability"
 	"github.com/stretchr/testify/require"
 )
@@ -34,7 +36,7 @@ func TestRegisterDriverFactory_RouteToCustomFactory(t *testing.T) {
 	})
 
 	originalRunStartupMigrationsFunc := runStartupMigrationsFunc
-	runStartupMigrationsFunc = func(_ DriverConfig, _ database.Driver, _ options) error { return nil }
+	runStartupMigrationsFunc = func(_ DriverConfig, _ database.Driver, _ database.DBTX, _ options) error { return nil }
 	t.Cleanup(func() { runStartupMigrationsFunc = originalRunStartupMigrationsFunc })
 
 	mgr, err := New(customConfig{})
@@ -67,3 +69,48 @@ func TestNewFromAdapter_NilAdapterReturnsError(t *testing.T) {
 	require.Nil(t, mgr)
 	require.ErrorIs(t, err, database.ErrInvalidConfig)
 }
+
+func TestNewFromAdapter_RejectsReplicas(t *testing.T) {
+	adapter := &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}
+	_, err := NewFromAdapter(adapter, WithReplicas(postgres.PostgresConfig{DSN: "postgres://replica"}))
+	require.ErrorIs(t, err, database.ErrInvalidConfig, "réplicas não podem ser ignoradas em silêncio")
+	require.Zero(t, adapter.closeCalls)
+}
+
+type dsnConfig struct{ customConfig }
+
+func (dsnConfig) MigrationDSN() string { return "custom://migrations" }
+
+func TestRegisterEnvConfig_ResolvesRegisteredDriver(t *testing.T) {
+	RegisterEnvConfig(database.Driver("custom"), func(getenv func(string) string) (DriverConfig, error) {
+		return customConfig{failValidate: getenv("DB_DATABASE") == "invalid"}, nil
+	})
+	t.Cleanup(func() {
+		registryMu.Lock()
+		delete(envLoaders, database.Driver("custom"))
+		registryMu.Unlock()
+	})
+
+	t.Setenv("DB_DRIVER", "CUSTOM")
+	t.Setenv("DB_DATABASE", "invalid")
+
+	cfg, err := resolveEnvConfig(os.Getenv)
+	require.NoError(t, err)
+	require.Equal(t, customConfig{failValidate: true}, cfg)
+}
+
+func TestRegisterEnvConfig_NilArgsAreIgnored(t *testing.T) {
+	before := len(envLoaders)
+	RegisterEnvConfig("", nil)
+	RegisterEnvConfig(database.Driver("custom"), nil)
+	require.Equal(t, before, len(envLoaders))
+}
+
+func TestResolveMigrationDSN_UsesMigrationDSNProvider(t *testing.T) {
+	dsn, err := resolveMigrationDSN(dsnConfig{})
+	require.NoError(t, err)
+	require.Equal(t, "custom://migrations", dsn)
+
+	_, err = resolveMigrationDSN(customConfig{})
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+}
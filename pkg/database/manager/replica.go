@@ -0,0 +1,205 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	internalpool "github.com/JailtonJunior94/devkit-go/pkg/database/internal/pool"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+)
+
+const (
+	roleAttr    = "db.role"
+	rolePrimary = "primary"
+	roleReplica = "replica"
+
+	defaultReplicaHealthInterval = 5 * time.Second
+)
+
+type replica struct {
+	index   int
+	adapter driverAdapter
+	inst    instrumentation
+	dbtx    database.DBTX
+	scraper *internalpool.Scraper
+	healthy atomic.Bool
+}
+
+type replicaSet struct {
+	replicas []*replica
+	next     atomic.Uint64
+	obs      observability.Observability
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func buildReplicas(driver database.Driver, o options) (*replicaSet, error) {
+	if len(o.replicas) == 0 {
+		return nil, nil
+	}
+
+	set := &replicaSet{
+		obs:      o.observability,
+		interval: o.replicaHealthInterval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if set.interval <= 0 {
+		set.interval = defaultReplicaHealthInterval
+	}
+
+	for idx, cfg := range o.replicas {
+		adapter, err := buildReplicaAdapter(idx, cfg, driver, o)
+		if err != nil {
+			_ = set.closeAdapters(context.Background())
+			return nil, err
+		}
+
+		attrs := withRole(adapter.Attributes(), roleReplica)
+		r := &replica{
+			index:   idx,
+			adapter: adapter,
+			inst:    newInstrumentation(adapter.Driver(), attrs, o.observability, resolveLogger(o), o.sqlLogging).withSlowQueryThreshold(o.slowQueryThreshold),
+		}
+		r.dbtx = r.inst.WrapDBTX(adapter.DBTX())
+		if !isNoopObservability(o.observability) {
+			r.scraper = internalpool.NewScraper(adapter.Stats, o.observability.Metrics(), resolvePoolStatsInterval(o), attrs...)
+		}
+		set.replicas = append(set.replicas, r)
+	}
+
+	set.pingAll(context.Background())
+	go set.run()
+	return set, nil
+}
+
+func buildReplicaAdapter(idx int, cfg DriverConfig, driver database.Driver, o options) (driverAdapter, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: replica %d: config is nil", database.ErrInvalidConfig, idx)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: replica %d: %w", database.ErrInvalidConfig, idx, err)
+	}
+
+	adapter, err := buildAdapterFunc(cfg, o)
+	if err != nil {
+		return nil, fmt.Errorf("replica %d: %w", idx, err)
+	}
+	if adapter.Driver() != driver {
+		_ = adapter.Close(context.Background())
+		return nil, fmt.Errorf("%w: replica %d: driver %q does not match primary driver %q",
+			database.ErrInvalidConfig, idx, adapter.Driver(), driver)
+	}
+	return adapter, nil
+}
+
+func (s *replicaSet) pick() *replica {
+	if s == nil {
+		return nil
+	}
+	n := len(s.replicas)
+	start := s.next.Add(1)
+	for i := range n {
+		r := s.replicas[(start+uint64(i))%uint64(n)]
+		if r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+func (s *replicaSet) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.checkAll(context.Background())
+		}
+	}
+}
+
+// pingAll sets the initial health of the replicas, so one that is down at
+// startup gets no reads until a health check sees it up.
+func (s *replicaSet) pingAll(ctx context.Context) {
+	for _, r := range s.replicas {
+		err := r.ping(ctx)
+		r.healthy.Store(err == nil)
+		if err != nil {
+			s.obs.Logger().Warn(ctx, "database replica removed from rotation", append(r.fields(), observability.Error(err))...)
+		}
+	}
+}
+
+func (s *replicaSet) checkAll(ctx context.Context) {
+	for _, r := range s.replicas {
+		err := r.ping(ctx)
+		healthy := err == nil
+		if r.healthy.Swap(healthy) == healthy {
+			continue
+		}
+
+		if healthy {
+			s.obs.Logger().Info(ctx, "database replica back in rotation", r.fields()...)
+			continue
+		}
+		s.obs.Logger().Warn(ctx, "database replica removed from rotation", append(r.fields(), observability.Error(err))...)
+	}
+}
+
+func (r *replica) ping(ctx context.Context) error {
+	pingCtx, cancel := context.WithTimeout(ctx, pingInitTimeout)
+	defer cancel()
+	return r.adapter.Ping(pingCtx)
+}
+
+func (r *replica) fields() []observability.Field {
+	return append(withRole(r.adapter.Attributes(), roleReplica), observability.Int("replica", r.index))
+}
+
+func (s *replicaSet) stopHealthChecks() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+	})
+}
+
+func (s *replicaSet) close(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	s.stopHealthChecks()
+	return s.closeAdapters(ctx)
+}
+
+func (s *replicaSet) closeAdapters(ctx context.Context) error {
+	var errs []error
+	for _, r := range s.replicas {
+		if r.scraper != nil {
+			r.scraper.Stop()
+		}
+		if err := r.adapter.Close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("replica %d: %w", r.index, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func withRole(attrs []observability.Field, role string) []observability.Field {
+	return append(cloneFields(attrs), observability.String(roleAttr, role))
+}
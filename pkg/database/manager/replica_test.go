@@ -0,0 +1,231 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/mysql"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/postgres"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability/fake"
+	"github.com/stretchr/testify/require"
+)
+
+// namedDBTX permite distinguir primary e réplicas por identidade.
+type namedDBTX struct {
+	stubDBTX
+	name string
+}
+
+func newReplicaTestManager(t *testing.T, primary *mockAdapter, replicas []*mockAdapter, opts ...Option) Manager {
+	t.Helper()
+
+	originalBuildAdapterFunc := buildAdapterFunc
+	originalRunStartupMigrationsFunc := runStartupMigrationsFunc
+	t.Cleanup(func() {
+		buildAdapterFunc = originalBuildAdapterFunc
+		runStartupMigrationsFunc = originalRunStartupMigrationsFunc
+	})
+
+	calls := 0
+	buildAdapterFunc = func(_ DriverConfig, _ options) (driverAdapter, error) {
+		defer func() { calls++ }()
+		if calls == 0 {
+			return primary, nil
+		}
+		return replicas[calls-1], nil
+	}
+	runStartupMigrationsFunc = func(_ DriverConfig, _ database.Driver, _ database.DBTX, _ options) error { return nil }
+
+	cfgs := make([]DriverConfig, len(replicas))
+	for i := range replicas {
+		cfgs[i] = postgres.PostgresConfig{DSN: "postgres://replica"}
+	}
+
+	mgr, err := New(postgres.PostgresConfig{DSN: "postgres://primary"}, append(opts, WithReplicas(cfgs...))...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mgr.Shutdown(context.Background()) })
+	return mgr
+}
+
+func baseDBTX(dbtx database.DBTX) database.DBTX {
+	if inst, ok := dbtx.(*instrumentedDBTX); ok {
+		return inst.base
+	}
+	return dbtx
+}
+
+func TestReplicas_DBTX_RoutesReadOnlyContextToReplica(t *testing.T) {
+	primaryDBTX := &namedDBTX{name: "primary"}
+	replicaDBTX := &namedDBTX{name: "replica"}
+	primary := &mockAdapter{driver: database.DriverPostgres, dbtx: primaryDBTX}
+	rep := &mockAdapter{driver: database.DriverPostgres, dbtx: replicaDBTX}
+
+	mgr := newReplicaTestManager(t, primary, []*mockAdapter{rep})
+
+	require.Same(t, primaryDBTX, baseDBTX(mgr.DBTX(context.Background())))
+	require.Same(t, replicaDBTX, baseDBTX(mgr.DBTX(database.WithReadOnly(context.Background()))))
+}
+
+func TestReplicas_DBTX_TxInContextWins(t *testing.T) {
+	rep := &mockAdapter{driver: database.DriverPostgres, dbtx: &namedDBTX{name: "replica"}}
+	mgr := newReplicaTestManager(t, &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}, []*mockAdapter{rep})
+
+	tx := &stubTx{}
+	ctx := database.WithReadOnly(database.WithTx(context.Background(), tx))
+
+	require.Same(t, tx, mgr.DBTX(ctx))
+}
+
+func TestReplicas_BeginTx_ReadOnlyGoesToReplicaWritesToPrimary(t *testing.T) {
+	primary := &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}
+	rep := &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}
+	mgr := newReplicaTestManager(t, primary, []*mockAdapter{rep})
+
+	tx, err := mgr.BeginTx(context.Background(), database.TxOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(context.Background()))
+
+	roTx, err := mgr.BeginTx(context.Background(), database.TxOptions{ReadOnly: true})
+	require.NoError(t, err)
+	require.NoError(t, roTx.Rollback(context.Background()))
+
+	require.Equal(t, 1, primary.beginCalls)
+	require.Equal(t, 1, rep.beginCalls)
+	require.True(t, rep.lastTxOpts.ReadOnly)
+}
+
+func TestReplicas_ManagerReadOnly_RoutesDBTXAndBeginTxAlike(t *testing.T) {
+	primaryDBTX := &namedDBTX{name: "primary"}
+	replicaDBTX := &namedDBTX{name: "replica"}
+	primary := &mockAdapter{driver: database.DriverPostgres, dbtx: primaryDBTX}
+	rep := &mockAdapter{driver: database.DriverPostgres, dbtx: replicaDBTX}
+	mgr := newReplicaTestManager(t, primary, []*mockAdapter{rep}, WithReadOnly(true))
+
+	// Com WithReadOnly no manager, DBTX e BeginTx leem do mesmo nó.
+	require.Same(t, replicaDBTX, baseDBTX(mgr.DBTX(context.Background())))
+
+	tx, err := mgr.BeginTx(context.Background(), database.TxOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback(context.Background()))
+
+	require.Zero(t, primary.beginCalls)
+	require.Equal(t, 1, rep.beginCalls)
+	require.True(t, rep.lastTxOpts.ReadOnly)
+}
+
+func TestReplicas_RoundRobinAcrossHealthyReplicas(t *testing.T) {
+	r1 := &mockAdapter{driver: database.DriverPostgres, dbtx: &namedDBTX{name: "r1"}}
+	r2 := &mockAdapter{driver: database.DriverPostgres, dbtx: &namedDBTX{name: "r2"}}
+	mgr := newReplicaTestManager(t, &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}, []*mockAdapter{r1, r2})
+
+	ctx := database.WithReadOnly(context.Background())
+	seen := map[database.DBTX]int{}
+	for range 4 {
+		seen[baseDBTX(mgr.DBTX(ctx))]++
+	}
+
+	require.Equal(t, 2, seen[r1.dbtx])
+	require.Equal(t, 2, seen[r2.dbtx])
+}
+
+func TestReplicas_UnhealthyReplicaDroppedAndRestored(t *testing.T) {
+	primaryDBTX := &namedDBTX{name: "primary"}
+	replicaDBTX := &namedDBTX{name: "replica"}
+	rep := &mockAdapter{driver: database.DriverPostgres, dbtx: replicaDBTX, pingErr: errors.New("down")}
+	obs := fake.NewProvider()
+	mgr := newReplicaTestManager(t, &mockAdapter{driver: database.DriverPostgres, dbtx: primaryDBTX}, []*mockAdapter{rep},
+		WithObservability(obs))
+	set := mgr.(*dbManager).replicas
+	ctx := database.WithReadOnly(context.Background())
+
+	set.checkAll(context.Background())
+	require.Same(t, primaryDBTX, baseDBTX(mgr.DBTX(ctx)), "sem réplica saudável, leitura cai no primary")
+
+	rep.pingErr = nil
+	set.checkAll(context.Background())
+	require.Same(t, replicaDBTX, baseDBTX(mgr.DBTX(ctx)))
+
+	entries := obs.Logger().(*fake.FakeLogger).GetEntries()
+	require.Len(t, entries, 2)
+	require.Equal(t, "database replica removed from rotation", entries[0].Message)
+}
+
+func TestReplicas_DownAtStartupGetsNoReads(t *testing.T) {
+	primaryDBTX := &namedDBTX{name: "primary"}
+	rep := &mockAdapter{driver: database.DriverPostgres, dbtx: &namedDBTX{name: "replica"}, pingErr: errors.New("down")}
+	mgr := newReplicaTestManager(t, &mockAdapter{driver: database.DriverPostgres, dbtx: primaryDBTX}, []*mockAdapter{rep})
+
+	ctx := database.WithReadOnly(context.Background())
+	require.Same(t, primaryDBTX, baseDBTX(mgr.DBTX(ctx)), "a réplica só entra na rotação depois de responder ao ping")
+}
+
+func TestReplicas_HealthCheckLoopRunsOnInterval(t *testing.T) {
+	rep := &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}, pingErr: errors.New("down")}
+	mgr := newReplicaTestManager(t, &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}, []*mockAdapter{rep},
+		WithReplicaHealthCheckInterval(10*time.Millisecond))
+	set := mgr.(*dbManager).replicas
+
+	require.Eventually(t, func() bool { return set.pick() == nil }, time.Second, 5*time.Millisecond)
+}
+
+func TestReplicas_SpansCarryRoleAttribute(t *testing.T) {
+	obs := fake.NewProvider()
+	rep := &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}
+	mgr := newReplicaTestManager(t, &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}, []*mockAdapter{rep},
+		WithObservability(obs))
+
+	_, _ = mgr.DBTX(context.Background()).ExecContext(context.Background(), "UPDATE t SET x = 1")
+	_, _ = mgr.DBTX(database.WithReadOnly(context.Background())).ExecContext(context.Background(), "SELECT 1")
+
+	spans := obs.Tracer().(*fake.FakeTracer).GetSpans()
+	require.Len(t, spans, 2)
+	require.Equal(t, rolePrimary, spanAttr(spans[0], roleAttr))
+	require.Equal(t, roleReplica, spanAttr(spans[1], roleAttr))
+}
+
+func TestReplicas_DriverMismatch_ReturnsInvalidConfigAndClosesPrimary(t *testing.T) {
+	originalBuildAdapterFunc := buildAdapterFunc
+	originalRunStartupMigrationsFunc := runStartupMigrationsFunc
+	t.Cleanup(func() {
+		buildAdapterFunc = originalBuildAdapterFunc
+		runStartupMigrationsFunc = originalRunStartupMigrationsFunc
+	})
+
+	primary := &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}
+	rep := &mockAdapter{driver: database.DriverMySQL, dbtx: &stubDBTX{}}
+	buildAdapterFunc = func(cfg DriverConfig, _ options) (driverAdapter, error) {
+		if _, ok := cfg.(mysql.MySQLConfig); ok {
+			return rep, nil
+		}
+		return primary, nil
+	}
+	runStartupMigrationsFunc = func(_ DriverConfig, _ database.Driver, _ database.DBTX, _ options) error { return nil }
+
+	_, err := New(postgres.PostgresConfig{DSN: "postgres://primary"}, WithReplicas(mysql.MySQLConfig{DSN: "user:pass@tcp(h:3306)/db"}))
+
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+	require.Equal(t, 1, primary.closeCalls)
+	require.Equal(t, 1, rep.closeCalls)
+}
+
+func TestReplicas_ShutdownClosesReplicas(t *testing.T) {
+	rep := &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}
+	primary := &mockAdapter{driver: database.DriverPostgres, dbtx: &stubDBTX{}}
+	mgr := newReplicaTestManager(t, primary, []*mockAdapter{rep})
+
+	require.NoError(t, mgr.Shutdown(context.Background()))
+	require.Equal(t, 1, primary.closeCalls)
+	require.Equal(t, 1, rep.closeCalls)
+}
+
+func spanAttr(span *fake.FakeSpan, key string) string {
+	for _, f := range span.Attributes {
+		if f.Key == key {
+			return f.StringValue()
+		}
+	}
+	return ""
+}
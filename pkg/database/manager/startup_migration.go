@@ -1,6 +1,7 @@
 package manager
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/JailtonJunior94/devkit-go/pkg/database"
 	"github.com/JailtonJunior94/devkit-go/pkg/database/cockroach"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/checksum"
 	"github.com/JailtonJunior94/devkit-go/pkg/database/mssql"
 	"github.com/JailtonJunior94/devkit-go/pkg/database/mysql"
 	"github.com/JailtonJunior94/devkit-go/pkg/database/postgres"
@@ -25,25 +27,26 @@ import (
 
 var startupMigrationDirFunc = defaultStartupMigrationDir
 
-func runStartupMigrations(cfg DriverConfig, driver database.Driver, o options) error {
+func runStartupMigrations(cfg DriverConfig, driver database.Driver, db database.DBTX, o options) error {
 	dsn, err := resolveMigrationDSN(cfg)
 	if err != nil {
 		return err
 	}
 	dbURL := normalizeMigrationDSN(dsn)
+	check := startupChecksums{driver: driver, db: db, o: o}
 
 	if o.startupMigrationFS != nil {
-		return runStartupMigrationsFromFS(o.startupMigrationFS, o.startupMigrationRoot, dbURL)
+		return runStartupMigrationsFromFS(o.startupMigrationFS, o.startupMigrationRoot, dbURL, check)
 	}
 
 	dir := o.startupMigrationDir
 	if dir == "" {
 		dir = startupMigrationDirFunc(driver)
 	}
-	return runStartupMigrationsFromDir(dir, dbURL)
+	return runStartupMigrationsFromDir(dir, dbURL, check)
 }
 
-func runStartupMigrationsFromDir(dir, dbURL string) error {
+func runStartupMigrationsFromDir(dir, dbURL string, check startupChecksums) error {
 	info, err := os.Stat(dir)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
@@ -63,16 +66,16 @@ func runStartupMigrationsFromDir(dir, dbURL string) error {
 		_, _ = m.Close()
 	}()
 
-	if err := m.Up(); err != nil && !errors.Is(err, migratelib.ErrNoChange) {
-		return fmt.Errorf("%w: %w", database.ErrMigrationFailed, err)
-	}
-	return nil
+	return check.up(m, os.DirFS(dir))
 }
 
-func runStartupMigrationsFromFS(fsys fs.FS, root, dbURL string) error {
+func runStartupMigrationsFromFS(fsys fs.FS, root, dbURL string, check startupChecksums) error {
 	if root == "" {
 		root = "."
 	}
+	if _, err := fs.Stat(fsys, root); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
 	src, err := iofs.New(fsys, root)
 	if err != nil {
 		return fmt.Errorf("%w: iofs source: %w", database.ErrMigrationFailed, err)
@@ -84,17 +87,75 @@ func runStartupMigrationsFromFS(fsys fs.FS, root, dbURL string) error {
 	defer func() {
 		_, _ = m.Close()
 	}()
+
+	sub, err := fs.Sub(fsys, root)
+	if err != nil {
+		return fmt.Errorf("%w: %w", database.ErrMigrationFailed, err)
+	}
+	return check.up(m, sub)
+}
+
+// startupChecksums verifies the migration files against the checksums
+// recorded by previous runs before applying them, and records the new ones.
+type startupChecksums struct {
+	driver database.Driver
+	db     database.DBTX
+	o      options
+}
+
+func (c startupChecksums) up(m *migratelib.Migrate, fsys fs.FS) error {
+	if c.db == nil || c.o.startupDriftPolicy == database.DriftIgnore {
+		if err := m.Up(); err != nil && !errors.Is(err, migratelib.ErrNoChange) {
+			return fmt.Errorf("%w: %w", database.ErrMigrationFailed, err)
+		}
+		return nil
+	}
+
+	ctx := context.Background()
+	files, err := checksum.Scan(fsys)
+	if err != nil {
+		return fmt.Errorf("%w: %w", database.ErrMigrationFailed, err)
+	}
+	store := checksum.NewStore(c.db, c.driver)
+	prev, _, err := migrateVersion(m)
+	if err != nil {
+		return fmt.Errorf("%w: %w", database.ErrMigrationFailed, err)
+	}
+	err = checksum.Report(ctx, c.o.startupDriftPolicy, c.o.observability, store.Verify(ctx, files, prev))
+	if err != nil {
+		return fmt.Errorf("%w: %w", database.ErrMigrationFailed, err)
+	}
+
 	if err := m.Up(); err != nil && !errors.Is(err, migratelib.ErrNoChange) {
 		return fmt.Errorf("%w: %w", database.ErrMigrationFailed, err)
 	}
+	current, dirty, err := migrateVersion(m)
+	if err == nil && !dirty {
+		err = store.Sync(ctx, files, prev, current)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", database.ErrMigrationFailed, err)
+	}
 	return nil
 }
 
+func migrateVersion(m *migratelib.Migrate) (int, bool, error) {
+	version, dirty, err := m.Version()
+	if errors.Is(err, migratelib.ErrNilVersion) {
+		return checksum.NilVersion, false, nil
+	}
+	return int(version), dirty, err
+}
+
 func defaultStartupMigrationDir(driver database.Driver) string {
 	return filepath.Join("migrations", string(driver))
 }
 
 func resolveMigrationDSN(cfg DriverConfig) (string, error) {
+	cfg, err := withProviderCredentials(cfg)
+	if err != nil {
+		return "", err
+	}
 	switch c := cfg.(type) {
 	case postgres.PostgresConfig:
 		return postgresMigrationDSN(c), nil
@@ -104,11 +165,48 @@ func resolveMigrationDSN(cfg DriverConfig) (string, error) {
 		return c.ResolveDSN(), nil
 	case mssql.MSSQLConfig:
 		return c.ResolveDSN(), nil
+	case MigrationDSNProvider:
+		return c.MigrationDSN(), nil
 	default:
 		return "", fmt.Errorf("%w: unsupported driver config type %T", database.ErrInvalidConfig, cfg)
 	}
 }
 
+// withProviderCredentials copies the current credentials of a structured
+// config's provider into its User and Password, so migrations authenticate
+// like the pool does.
+func withProviderCredentials(cfg DriverConfig) (DriverConfig, error) {
+	apply := func(provider database.CredentialsProvider, dsn string, user, password *string) error {
+		if provider == nil || dsn != "" {
+			return nil
+		}
+		creds, err := provider.Credentials(context.Background())
+		if err != nil {
+			return fmt.Errorf("%w: credentials: %w", database.ErrMigrationFailed, err)
+		}
+		*user = firstNonEmpty(creds.User, *user)
+		*password = firstNonEmpty(creds.Password, *password)
+		return nil
+	}
+
+	var err error
+	switch c := cfg.(type) {
+	case postgres.PostgresConfig:
+		err = apply(c.Credentials, c.DSN, &c.User, &c.Password)
+		cfg = c
+	case cockroach.CockroachConfig:
+		err = apply(c.Credentials, c.DSN, &c.User, &c.Password)
+		cfg = c
+	case mysql.MySQLConfig:
+		err = apply(c.Credentials, c.DSN, &c.User, &c.Password)
+		cfg = c
+	case mssql.MSSQLConfig:
+		err = apply(c.Credentials, c.DSN, &c.User, &c.Password)
+		cfg = c
+	}
+	return cfg, err
+}
+
 type pgFlavorParams struct {
 	DSN        string
 	Host       string
@@ -0,0 +1,214 @@
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	valuerType  = reflect.TypeFor[driver.Valuer]()
+	timeType    = reflect.TypeFor[time.Time]()
+)
+
+// fieldPath locates a column inside a (possibly embedded) struct. When unwrap
+// is set the column maps to the single field of a value-object wrapper such as
+// vos.UUID{Value uuid.UUID}.
+type fieldPath struct {
+	index  []int
+	unwrap bool
+}
+
+type structMapping struct {
+	columns []string
+	fields  map[string]fieldPath
+}
+
+var mappings sync.Map // map[reflect.Type]*structMapping
+
+// mappingFor returns the column → field mapping of t. Columns come from the
+// `db` tag, falling back to the snake_case field name; `db:"-"` skips a field.
+// Anonymous struct fields without a tag (entity.Base) are flattened.
+func mappingFor(t reflect.Type) *structMapping {
+	if cached, ok := mappings.Load(t); ok {
+		return cached.(*structMapping)
+	}
+	m := &structMapping{fields: make(map[string]fieldPath)}
+	collectFields(t, nil, m)
+	actual, _ := mappings.LoadOrStore(t, m)
+	return actual.(*structMapping)
+}
+
+func collectFields(t reflect.Type, parent []int, m *structMapping) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup("db")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+
+		index := append(append([]int(nil), parent...), i)
+
+		if f.Anonymous && !hasTag {
+			switch {
+			case f.Type.Kind() == reflect.Pointer:
+				// Embedded pointers would need allocation while scanning; not supported.
+				continue
+			case f.Type.Kind() == reflect.Struct && !isLeaf(f.Type):
+				collectFields(f.Type, index, m)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = snakeCase(f.Name)
+		}
+		path := fieldPath{index: index, unwrap: unwrapField(f.Type) >= 0}
+		if existing, exists := m.fields[name]; exists {
+			// The shallowest field wins, mirroring Go's own promotion rules.
+			if len(existing.index) > len(index) {
+				m.fields[name] = path
+			}
+			continue
+		}
+		m.columns = append(m.columns, name)
+		m.fields[name] = path
+	}
+}
+
+// isLeaf reports whether values of t are scanned as a single column.
+func isLeaf(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return true
+	}
+	return t == timeType ||
+		reflect.PointerTo(t).Implements(scannerType) ||
+		t.Implements(valuerType) ||
+		unwrapField(t) >= 0
+}
+
+// unwrapField returns the index of the only field of a struct wrapper whose
+// field is itself a scanner (vos.UUID, vos.ULID), or -1.
+func unwrapField(t reflect.Type) int {
+	if t.Kind() != reflect.Struct || t == timeType || t.NumField() != 1 {
+		return -1
+	}
+	if reflect.PointerTo(t).Implements(scannerType) || t.Implements(valuerType) {
+		return -1
+	}
+	f := t.Field(0)
+	if !f.IsExported() || !reflect.PointerTo(f.Type).Implements(scannerType) {
+		return -1
+	}
+	return 0
+}
+
+func (p fieldPath) addr(v reflect.Value) any {
+	f := v.FieldByIndex(p.index)
+	if p.unwrap {
+		f = f.Field(0)
+	}
+	return f.Addr().Interface()
+}
+
+func (p fieldPath) value(v reflect.Value) any {
+	f := v.FieldByIndex(p.index)
+	if p.unwrap {
+		f = f.Field(0)
+	}
+	return f.Interface()
+}
+
+// scanTargets returns one destination per column for a struct value.
+func scanTargets(v reflect.Value, columns []string) ([]any, error) {
+	m := mappingFor(v.Type())
+	dest := make([]any, len(columns))
+	for i, column := range columns {
+		path, ok := m.fields[column]
+		if !ok {
+			return nil, fmt.Errorf("database: column %q has no destination field in %s", column, v.Type())
+		}
+		dest[i] = path.addr(v)
+	}
+	return dest, nil
+}
+
+// scalarTarget returns the destination used when T itself maps to one column.
+func scalarTarget(v reflect.Value) any {
+	if idx := unwrapField(v.Type()); idx >= 0 {
+		return v.Field(idx).Addr().Interface()
+	}
+	return v.Addr().Interface()
+}
+
+// ColumnValues returns the fields of a struct (or pointer to struct) keyed by
+// the columns the scanning helpers map them to. A map[string]any is returned
+// as is.
+func ColumnValues(arg any) (map[string]any, error) {
+	return namedValues(arg)
+}
+
+// ColumnNames returns the columns of a struct type in field order, the same
+// ones QueryAll maps and ColumnValues returns.
+func ColumnNames(arg any) ([]string, error) {
+	t := reflect.TypeOf(arg)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("database: columns need a struct, got %T", arg)
+	}
+	return append([]string(nil), mappingFor(t).columns...), nil
+}
+
+// ColumnPointer returns a pointer to the field of dest, a pointer to struct,
+// that column maps to, as the scanning helpers would fill it.
+func ColumnPointer(dest any, column string) (any, error) {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("database: destination must be a non-nil pointer to struct, got %T", dest)
+	}
+	path, ok := mappingFor(v.Elem().Type()).fields[column]
+	if !ok {
+		return nil, fmt.Errorf("database: column %q has no field in %s", column, v.Elem().Type())
+	}
+	return path.addr(v.Elem()), nil
+}
+
+// fieldValues returns the named values of a struct, used to bind `:name`
+// parameters.
+func fieldValues(v reflect.Value) map[string]any {
+	m := mappingFor(v.Type())
+	values := make(map[string]any, len(m.columns))
+	for _, column := range m.columns {
+		values[column] = m.fields[column].value(v)
+	}
+	return values
+}
+
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	b.Grow(len(name) + 4)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
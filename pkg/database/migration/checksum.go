@@ -0,0 +1,138 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	migratelib "github.com/golang-migrate/migrate/v4"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/checksum"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+)
+
+// checksumFiles returns the checksum of every up file of the index. Go
+// migrations have no file and are not checksummed.
+func (idx *sourceIndex) checksumFiles() ([]checksum.File, error) {
+	var files []checksum.File
+	for _, mig := range idx.migrations {
+		if mig.Go {
+			continue
+		}
+		body, err := fs.ReadFile(idx.fsys, mig.UpFile)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, checksum.File{Version: mig.Version, Name: mig.UpFile, Sum: checksum.Sum(body)})
+	}
+	return files, nil
+}
+
+func appliedVersion(mm *migratelib.Migrate) (version int, dirty bool, err error) {
+	v, applied, dirty, err := currentVersion(mm)
+	if err != nil || !applied {
+		return checksum.NilVersion, false, err
+	}
+	return int(v), dirty, nil
+}
+
+func (m *migrator) checksumStore(ctx context.Context) (*checksum.Store, []checksum.File, error) {
+	idx, err := loadIndex(m.src, m.goms)
+	if err != nil {
+		return nil, nil, err
+	}
+	files, err := idx.checksumFiles()
+	if err != nil {
+		return nil, nil, err
+	}
+	return checksum.NewStore(m.mgr.DBTX(ctx), m.drv), files, nil
+}
+
+// checked wraps op with checksum verification before it runs and, outside
+// dry-run, records the checksums of what it applied.
+func (m *migrator) checked(ctx context.Context, op func(*migratelib.Migrate) error) func(*migratelib.Migrate) error {
+	if m.opts.driftPolicy == database.DriftIgnore {
+		return op
+	}
+	return func(mm *migratelib.Migrate) error {
+		store, files, err := m.checksumStore(ctx)
+		if err != nil {
+			return err
+		}
+		prev, _, err := appliedVersion(mm)
+		if err != nil {
+			return err
+		}
+		if err := checksum.Report(ctx, m.opts.driftPolicy, m.obs, store.Verify(ctx, files, prev)); err != nil {
+			return err
+		}
+
+		opErr := op(mm)
+		if m.opts.dryRun != nil || (opErr != nil && !errors.Is(opErr, migratelib.ErrNoChange)) {
+			return opErr
+		}
+		current, dirty, err := appliedVersion(mm)
+		if err == nil && !dirty {
+			err = store.Sync(ctx, files, prev, current)
+		}
+		if err != nil {
+			return err
+		}
+		return opErr
+	}
+}
+
+// resetChecksums records the current files as the truth after Force.
+func (m *migrator) resetChecksums(ctx context.Context, version int) func(*migratelib.Migrate) error {
+	return func(mm *migratelib.Migrate) error {
+		if err := mm.Force(version); err != nil {
+			return err
+		}
+		if m.opts.driftPolicy == database.DriftIgnore {
+			return nil
+		}
+		store, files, err := m.checksumStore(ctx)
+		if err != nil {
+			return err
+		}
+		return store.Reset(ctx, files, version)
+	}
+}
+
+func (m *migrator) Verify(ctx context.Context) error {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	spanName := fmt.Sprintf("db.%s.migration.verify", m.drv)
+	_, span := m.obs.Tracer().Start(ctx, spanName)
+	defer span.End()
+
+	err := m.run(ctx, func(mm *migratelib.Migrate) error {
+		store, files, err := m.checksumStore(ctx)
+		if err != nil {
+			return err
+		}
+		current, _, err := appliedVersion(mm)
+		if err != nil {
+			return err
+		}
+		return store.Verify(ctx, files, current)
+	})
+	if err != nil {
+		var drift *database.MigrationDriftError
+		if errors.As(err, &drift) {
+			span.SetAttributes(
+				observability.Int("migration.drift.modified", len(drift.Modified)),
+				observability.Int("migration.drift.missing", len(drift.Missing)),
+				observability.Int("migration.drift.out_of_order", len(drift.OutOfOrder)),
+			)
+		}
+		mapped := mapError(err)
+		span.RecordError(mapped)
+		span.SetStatus(observability.StatusCodeError, mapped.Error())
+		return mapped
+	}
+	return nil
+}
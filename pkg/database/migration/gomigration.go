@@ -0,0 +1,234 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	migratelib "github.com/golang-migrate/migrate/v4"
+	migratedatabase "github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/source"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/manager"
+)
+
+// GoMigrationFunc applies one direction of a Go migration inside tx.
+type GoMigrationFunc func(ctx context.Context, tx database.Tx) error
+
+// GoMigration is a migration written in Go. It shares the version sequence
+// and the schema_migrations table with the SQL files of the Source; its
+// version must not collide with a file. Each direction runs in its own
+// transaction opened on the Manager: it commits when the func returns nil
+// and rolls back otherwise. Down may be nil.
+type GoMigration struct {
+	Version    uint
+	Identifier string
+	Up         GoMigrationFunc
+	Down       GoMigrationFunc
+}
+
+// goMarkerPrefix starts the body golang-migrate reads for a Go migration; the
+// database wrapper recognises it and calls the function instead of the SQL
+// driver.
+const goMarkerPrefix = "-- devkit:go-migration "
+
+func goMarker(version uint, dir Direction) string {
+	return goMarkerPrefix + string(dir) + " " + strconv.FormatUint(uint64(version), 10)
+}
+
+func parseGoMarker(body []byte) (uint, Direction, bool) {
+	rest, ok := bytes.CutPrefix(body, []byte(goMarkerPrefix))
+	if !ok {
+		return 0, "", false
+	}
+	dir, version, ok := strings.Cut(string(rest), " ")
+	if !ok {
+		return 0, "", false
+	}
+	v, err := strconv.ParseUint(version, 10, 0)
+	if err != nil {
+		return 0, "", false
+	}
+	return uint(v), Direction(dir), true
+}
+
+func validateGoMigrations(migrations []GoMigration) (map[uint]GoMigration, error) {
+	if len(migrations) == 0 {
+		return nil, nil
+	}
+	byVersion := make(map[uint]GoMigration, len(migrations))
+	for _, gm := range migrations {
+		if gm.Up == nil {
+			return nil, fmt.Errorf("%w: go migration %d has no Up func", database.ErrInvalidConfig, gm.Version)
+		}
+		if _, dup := byVersion[gm.Version]; dup {
+			return nil, fmt.Errorf("%w: duplicate go migration version %d", database.ErrInvalidConfig, gm.Version)
+		}
+		byVersion[gm.Version] = gm
+	}
+	return byVersion, nil
+}
+
+// goSource merges Go migrations into the versions of a file source.
+type goSource struct {
+	inner    source.Driver
+	goms     map[uint]GoMigration
+	versions []uint
+}
+
+func newGoSource(inner source.Driver, goms map[uint]GoMigration) (*goSource, error) {
+	s := &goSource{inner: inner, goms: goms}
+	for v := range goms {
+		s.versions = append(s.versions, v)
+	}
+	v, err := inner.First()
+	for err == nil {
+		if _, clash := goms[v]; clash {
+			return nil, fmt.Errorf("%w: go migration version %d collides with a migration file", database.ErrInvalidConfig, v)
+		}
+		s.versions = append(s.versions, v)
+		v, err = inner.Next(v)
+	}
+	if !isNotExist(err) {
+		return nil, err
+	}
+	slices.Sort(s.versions)
+	return s, nil
+}
+
+func (s *goSource) Open(string) (source.Driver, error) {
+	return nil, fmt.Errorf("migration: go source cannot be opened by URL")
+}
+
+func (s *goSource) Close() error { return s.inner.Close() }
+
+func (s *goSource) First() (uint, error) {
+	if len(s.versions) == 0 {
+		return 0, &os.PathError{Op: "first", Path: "go source", Err: os.ErrNotExist}
+	}
+	return s.versions[0], nil
+}
+
+func (s *goSource) Prev(version uint) (uint, error) {
+	i, found := slices.BinarySearch(s.versions, version)
+	if !found || i == 0 {
+		return 0, &os.PathError{Op: "prev for version " + strconv.FormatUint(uint64(version), 10), Path: "go source", Err: os.ErrNotExist}
+	}
+	return s.versions[i-1], nil
+}
+
+func (s *goSource) Next(version uint) (uint, error) {
+	i, found := slices.BinarySearch(s.versions, version)
+	if !found || i == len(s.versions)-1 {
+		return 0, &os.PathError{Op: "next for version " + strconv.FormatUint(uint64(version), 10), Path: "go source", Err: os.ErrNotExist}
+	}
+	return s.versions[i+1], nil
+}
+
+func (s *goSource) ReadUp(version uint) (io.ReadCloser, string, error) {
+	if gm, ok := s.goms[version]; ok {
+		return io.NopCloser(strings.NewReader(goMarker(version, DirectionUp))), gm.Identifier, nil
+	}
+	return s.inner.ReadUp(version)
+}
+
+func (s *goSource) ReadDown(version uint) (io.ReadCloser, string, error) {
+	if gm, ok := s.goms[version]; ok {
+		if gm.Down == nil {
+			return nil, "", &os.PathError{Op: "read down for version " + strconv.FormatUint(uint64(version), 10), Path: "go source", Err: os.ErrNotExist}
+		}
+		return io.NopCloser(strings.NewReader(goMarker(version, DirectionDown))), gm.Identifier, nil
+	}
+	return s.inner.ReadDown(version)
+}
+
+// goDatabase runs Go migration markers through the Manager and forwards every
+// other body to the golang-migrate driver, which keeps locking and version
+// tracking.
+type goDatabase struct {
+	migratedatabase.Driver
+	ctx  context.Context
+	mgr  manager.Manager
+	goms map[uint]GoMigration
+}
+
+func (d *goDatabase) Run(r io.Reader) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	version, dir, ok := parseGoMarker(body)
+	if !ok {
+		return d.Driver.Run(bytes.NewReader(body))
+	}
+	gm, ok := d.goms[version]
+	if !ok {
+		return fmt.Errorf("migration: go migration %d not registered", version)
+	}
+	fn := gm.Up
+	if dir == DirectionDown {
+		fn = gm.Down
+	}
+	return runGoMigration(d.ctx, d.mgr, version, dir, fn)
+}
+
+func runGoMigration(ctx context.Context, mgr manager.Manager, version uint, dir Direction, fn GoMigrationFunc) error {
+	tx, err := mgr.BeginTx(ctx, database.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("migration: go migration %d %s: %w", version, dir, err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+	}()
+	if err := fn(ctx, tx); err != nil {
+		_ = tx.Rollback(ctx)
+		return fmt.Errorf("migration: go migration %d %s: %w", version, dir, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("migration: go migration %d %s: commit: %w", version, dir, err)
+	}
+	return nil
+}
+
+func isNotExist(err error) bool {
+	return errors.Is(err, os.ErrNotExist)
+}
+
+func openWithGoMigrations(
+	ctx context.Context,
+	mgr manager.Manager,
+	goms map[uint]GoMigration,
+	srcDrv source.Driver,
+	srcURL string,
+	dbURL string,
+) (*migratelib.Migrate, error) {
+	if srcDrv == nil {
+		var err error
+		if srcDrv, err = source.Open(srcURL); err != nil {
+			return nil, err
+		}
+	}
+	merged, err := newGoSource(srcDrv, goms)
+	if err != nil {
+		_ = srcDrv.Close()
+		return nil, err
+	}
+	dbDrv, err := migratedatabase.Open(dbURL)
+	if err != nil {
+		_ = merged.Close()
+		return nil, err
+	}
+	db := &goDatabase{Driver: dbDrv, ctx: ctx, mgr: mgr, goms: goms}
+	scheme, _, _ := strings.Cut(dbURL, ":")
+	return migratelib.NewWithInstance("go", merged, scheme, db)
+}
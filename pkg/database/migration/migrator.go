@@ -22,12 +22,25 @@ import (
 type Migrator interface {
 	Up(ctx context.Context) error
 	Down(ctx context.Context, steps int) error
+	// Goto migrates up or down until version is the current one.
+	Goto(ctx context.Context, version uint) error
 	Force(ctx context.Context, version int) error
 	Version(ctx context.Context) (version uint, dirty bool, err error)
+	// Status lists every migration in the Source with its applied state.
+	Status(ctx context.Context) ([]MigrationStatus, error)
+	// Plan lists the files the operation selected by target would execute,
+	// without applying them.
+	Plan(ctx context.Context, target Target) ([]Step, error)
+	// Verify compares the applied files with the checksums recorded when
+	// they ran and returns a *database.MigrationDriftError on divergence.
+	Verify(ctx context.Context) error
 }
 
 type migrator struct {
-	open func() (*migratelib.Migrate, error)
+	open func(ctx context.Context) (*migratelib.Migrate, error)
+	src  Source
+	goms map[uint]GoMigration
+	mgr  manager.Manager
 	opts options
 	obs  observability.Observability
 	drv  database.Driver
@@ -43,18 +56,26 @@ func New(mgr manager.Manager, src Source, opts ...Option) (Migrator, error) {
 		return nil, fmt.Errorf("%w: WithDSN is required", database.ErrInvalidConfig)
 	}
 
+	goms, err := validateGoMigrations(o.goMigrations)
+	if err != nil {
+		return nil, err
+	}
+
 	dbURL := normalizeDSN(o.dsn)
 
-	open := func() (*migratelib.Migrate, error) {
+	open := func(ctx context.Context) (*migratelib.Migrate, error) {
 		srcDrv, srcURL, err := resolveSource(src)
 		if err != nil {
 			return nil, err
 		}
 
 		var m *migratelib.Migrate
-		if srcDrv != nil {
+		switch {
+		case len(goms) > 0:
+			m, err = openWithGoMigrations(ctx, mgr, goms, srcDrv, srcURL, dbURL)
+		case srcDrv != nil:
 			m, err = migratelib.NewWithSourceInstance("iofs", srcDrv, dbURL)
-		} else {
+		default:
 			m, err = migratelib.New(srcURL, dbURL)
 		}
 		if err != nil {
@@ -66,7 +87,7 @@ func New(mgr manager.Manager, src Source, opts ...Option) (Migrator, error) {
 		return m, nil
 	}
 
-	probe, err := open()
+	probe, err := open(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -74,6 +95,9 @@ func New(mgr manager.Manager, src Source, opts ...Option) (Migrator, error) {
 
 	return &migrator{
 		open: open,
+		src:  src,
+		goms: goms,
+		mgr:  mgr,
 		opts: o,
 		obs:  o.observability,
 		drv:  mgr.Driver(),
@@ -103,10 +127,16 @@ func (m *migrator) Up(ctx context.Context) error {
 	defer cancel()
 
 	spanName := fmt.Sprintf("db.%s.migration.up", m.drv)
-	_, span := m.obs.Tracer().Start(ctx, spanName)
+	_, span := m.obs.Tracer().Start(ctx, spanName, observability.WithAttributes(
+		observability.Bool("migration.dry_run", m.opts.dryRun != nil),
+	))
 	defer span.End()
 
-	if err := m.run(ctx, func(mm *migratelib.Migrate) error { return mm.Up() }); err != nil {
+	op := func(mm *migratelib.Migrate) error { return mm.Up() }
+	if m.opts.dryRun != nil {
+		op = m.dryRun(Latest())
+	}
+	if err := m.run(ctx, m.checked(ctx, op)); err != nil {
 		mapped := mapError(err)
 		if !errors.Is(mapped, ErrNoChange) {
 			span.RecordError(mapped)
@@ -122,10 +152,16 @@ func (m *migrator) Down(ctx context.Context, steps int) error {
 	defer cancel()
 
 	spanName := fmt.Sprintf("db.%s.migration.down", m.drv)
-	_, span := m.obs.Tracer().Start(ctx, spanName)
+	_, span := m.obs.Tracer().Start(ctx, spanName, observability.WithAttributes(
+		observability.Bool("migration.dry_run", m.opts.dryRun != nil),
+	))
 	defer span.End()
 
-	if err := m.run(ctx, func(mm *migratelib.Migrate) error { return mm.Steps(-steps) }); err != nil {
+	op := func(mm *migratelib.Migrate) error { return mm.Steps(-steps) }
+	if m.opts.dryRun != nil {
+		op = m.dryRun(DownSteps(steps))
+	}
+	if err := m.run(ctx, m.checked(ctx, op)); err != nil {
 		mapped := mapError(err)
 		span.RecordError(mapped)
 		span.SetStatus(observability.StatusCodeError, mapped.Error())
@@ -139,10 +175,19 @@ func (m *migrator) Force(ctx context.Context, version int) error {
 	defer cancel()
 
 	spanName := fmt.Sprintf("db.%s.migration.force", m.drv)
-	_, span := m.obs.Tracer().Start(ctx, spanName)
+	_, span := m.obs.Tracer().Start(ctx, spanName, observability.WithAttributes(
+		observability.Bool("migration.dry_run", m.opts.dryRun != nil),
+	))
 	defer span.End()
 
-	if err := m.run(ctx, func(mm *migratelib.Migrate) error { return mm.Force(version) }); err != nil {
+	op := m.resetChecksums(ctx, version)
+	if m.opts.dryRun != nil {
+		op = func(*migratelib.Migrate) error {
+			_, err := fmt.Fprintf(m.opts.dryRun, "-- force version %d\n", version)
+			return err
+		}
+	}
+	if err := m.run(ctx, op); err != nil {
 		mapped := mapError(err)
 		span.RecordError(mapped)
 		span.SetStatus(observability.StatusCodeError, mapped.Error())
@@ -151,8 +196,101 @@ func (m *migrator) Force(ctx context.Context, version int) error {
 	return nil
 }
 
-func (m *migrator) Version(_ context.Context) (uint, bool, error) {
-	mm, err := m.open()
+func (m *migrator) Goto(ctx context.Context, version uint) error {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	spanName := fmt.Sprintf("db.%s.migration.goto", m.drv)
+	_, span := m.obs.Tracer().Start(ctx, spanName, observability.WithAttributes(
+		observability.Int64("migration.target_version", int64(version)),
+		observability.Bool("migration.dry_run", m.opts.dryRun != nil),
+	))
+	defer span.End()
+
+	op := func(mm *migratelib.Migrate) error { return mm.Migrate(version) }
+	if m.opts.dryRun != nil {
+		op = m.dryRun(ToVersion(version))
+	}
+	if err := m.run(ctx, m.checked(ctx, op)); err != nil {
+		mapped := mapError(err)
+		if !errors.Is(mapped, ErrNoChange) {
+			span.RecordError(mapped)
+			span.SetStatus(observability.StatusCodeError, mapped.Error())
+		}
+		return mapped
+	}
+	return nil
+}
+
+func (m *migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	spanName := fmt.Sprintf("db.%s.migration.status", m.drv)
+	_, span := m.obs.Tracer().Start(ctx, spanName)
+	defer span.End()
+
+	var statuses []MigrationStatus
+	err := m.run(ctx, func(mm *migratelib.Migrate) error {
+		idx, err := loadIndex(m.src, m.goms)
+		if err != nil {
+			return err
+		}
+		current, applied, dirty, err := currentVersion(mm)
+		if err != nil {
+			return err
+		}
+		statuses = idx.status(current, applied, dirty)
+		return nil
+	})
+	if err != nil {
+		mapped := mapError(err)
+		span.RecordError(mapped)
+		span.SetStatus(observability.StatusCodeError, mapped.Error())
+		return nil, mapped
+	}
+	span.SetAttributes(observability.Int("migration.count", len(statuses)))
+	return statuses, nil
+}
+
+func (m *migrator) Plan(ctx context.Context, target Target) ([]Step, error) {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	spanName := fmt.Sprintf("db.%s.migration.plan", m.drv)
+	_, span := m.obs.Tracer().Start(ctx, spanName)
+	defer span.End()
+
+	var steps []Step
+	err := m.run(ctx, func(mm *migratelib.Migrate) error {
+		var err error
+		steps, _, err = planFor(mm, m.src, m.goms, target)
+		return err
+	})
+	if err != nil {
+		mapped := mapError(err)
+		span.RecordError(mapped)
+		span.SetStatus(observability.StatusCodeError, mapped.Error())
+		return nil, mapped
+	}
+	span.SetAttributes(observability.Int("migration.steps", len(steps)))
+	return steps, nil
+}
+
+// dryRun returns an operation that prints the SQL target would execute to
+// the WithDryRun writer instead of applying it.
+func (m *migrator) dryRun(target Target) func(*migratelib.Migrate) error {
+	return func(mm *migratelib.Migrate) error {
+		steps, idx, err := planFor(mm, m.src, m.goms, target)
+		if err != nil {
+			return err
+		}
+		return writePlan(m.opts.dryRun, idx, steps)
+	}
+}
+
+func (m *migrator) Version(ctx context.Context) (uint, bool, error) {
+	mm, err := m.open(ctx)
 	if err != nil {
 		return 0, false, err
 	}
@@ -172,7 +310,7 @@ func (m *migrator) run(ctx context.Context, fn func(*migratelib.Migrate) error)
 		return fmt.Errorf("%w: %w", database.ErrMigrationFailed, err)
 	}
 
-	mm, err := m.open()
+	mm, err := m.open(ctx)
 	if err != nil {
 		return err
 	}
@@ -7,6 +7,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/JailtonJunior94/devkit-go/pkg/database/migration"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -151,6 +152,193 @@ func (_c *MockMigrator_Force_Call) RunAndReturn(run func(ctx context.Context, ve
 	return _c
 }
 
+// Goto provides a mock function for the type MockMigrator
+func (_mock *MockMigrator) Goto(ctx context.Context, version uint) error {
+	ret := _mock.Called(ctx, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Goto")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = returnFunc(ctx, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMigrator_Goto_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Goto'
+type MockMigrator_Goto_Call struct {
+	*mock.Call
+}
+
+// Goto is a helper method to define mock.On call
+//   - ctx context.Context
+//   - version uint
+func (_e *MockMigrator_Expecter) Goto(ctx interface{}, version interface{}) *MockMigrator_Goto_Call {
+	return &MockMigrator_Goto_Call{Call: _e.mock.On("Goto", ctx, version)}
+}
+
+func (_c *MockMigrator_Goto_Call) Run(run func(ctx context.Context, version uint)) *MockMigrator_Goto_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint
+		if args[1] != nil {
+			arg1 = args[1].(uint)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMigrator_Goto_Call) Return(err error) *MockMigrator_Goto_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMigrator_Goto_Call) RunAndReturn(run func(ctx context.Context, version uint) error) *MockMigrator_Goto_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Plan provides a mock function for the type MockMigrator
+func (_mock *MockMigrator) Plan(ctx context.Context, target migration.Target) ([]migration.Step, error) {
+	ret := _mock.Called(ctx, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Plan")
+	}
+
+	var r0 []migration.Step
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, migration.Target) ([]migration.Step, error)); ok {
+		return returnFunc(ctx, target)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, migration.Target) []migration.Step); ok {
+		r0 = returnFunc(ctx, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]migration.Step)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, migration.Target) error); ok {
+		r1 = returnFunc(ctx, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMigrator_Plan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Plan'
+type MockMigrator_Plan_Call struct {
+	*mock.Call
+}
+
+// Plan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - target migration.Target
+func (_e *MockMigrator_Expecter) Plan(ctx interface{}, target interface{}) *MockMigrator_Plan_Call {
+	return &MockMigrator_Plan_Call{Call: _e.mock.On("Plan", ctx, target)}
+}
+
+func (_c *MockMigrator_Plan_Call) Run(run func(ctx context.Context, target migration.Target)) *MockMigrator_Plan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 migration.Target
+		if args[1] != nil {
+			arg1 = args[1].(migration.Target)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMigrator_Plan_Call) Return(steps []migration.Step, err error) *MockMigrator_Plan_Call {
+	_c.Call.Return(steps, err)
+	return _c
+}
+
+func (_c *MockMigrator_Plan_Call) RunAndReturn(run func(ctx context.Context, target migration.Target) ([]migration.Step, error)) *MockMigrator_Plan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Status provides a mock function for the type MockMigrator
+func (_mock *MockMigrator) Status(ctx context.Context) ([]migration.MigrationStatus, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Status")
+	}
+
+	var r0 []migration.MigrationStatus
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]migration.MigrationStatus, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []migration.MigrationStatus); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]migration.MigrationStatus)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMigrator_Status_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Status'
+type MockMigrator_Status_Call struct {
+	*mock.Call
+}
+
+// Status is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMigrator_Expecter) Status(ctx interface{}) *MockMigrator_Status_Call {
+	return &MockMigrator_Status_Call{Call: _e.mock.On("Status", ctx)}
+}
+
+func (_c *MockMigrator_Status_Call) Run(run func(ctx context.Context)) *MockMigrator_Status_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMigrator_Status_Call) Return(statuses []migration.MigrationStatus, err error) *MockMigrator_Status_Call {
+	_c.Call.Return(statuses, err)
+	return _c
+}
+
+func (_c *MockMigrator_Status_Call) RunAndReturn(run func(ctx context.Context) ([]migration.MigrationStatus, error)) *MockMigrator_Status_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Up provides a mock function for the type MockMigrator
 func (_mock *MockMigrator) Up(ctx context.Context) error {
 	ret := _mock.Called(ctx)
@@ -202,6 +390,57 @@ func (_c *MockMigrator_Up_Call) RunAndReturn(run func(ctx context.Context) error
 	return _c
 }
 
+// Verify provides a mock function for the type MockMigrator
+func (_mock *MockMigrator) Verify(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMigrator_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockMigrator_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMigrator_Expecter) Verify(ctx interface{}) *MockMigrator_Verify_Call {
+	return &MockMigrator_Verify_Call{Call: _e.mock.On("Verify", ctx)}
+}
+
+func (_c *MockMigrator_Verify_Call) Run(run func(ctx context.Context)) *MockMigrator_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMigrator_Verify_Call) Return(err error) *MockMigrator_Verify_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMigrator_Verify_Call) RunAndReturn(run func(ctx context.Context) error) *MockMigrator_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Version provides a mock function for the type MockMigrator
 func (_mock *MockMigrator) Version(ctx context.Context) (uint, bool, error) {
 	ret := _mock.Called(ctx)
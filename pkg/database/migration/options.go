@@ -1,8 +1,11 @@
 package migration
 
 import (
+	"io"
+	"os"
 	"time"
 
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
 	"github.com/JailtonJunior94/devkit-go/pkg/observability"
 	"github.com/JailtonJunior94/devkit-go/pkg/observability/noop"
 )
@@ -13,11 +16,15 @@ type options struct {
 	dsn           string
 	timeout       time.Duration
 	observability observability.Observability
+	dryRun        io.Writer
+	goMigrations  []GoMigration
+	driftPolicy   database.DriftPolicy
 }
 
 func defaultOptions() options {
 	return options{
 		observability: noop.NewProvider(),
+		driftPolicy:   database.DriftIgnore,
 	}
 }
 
@@ -42,3 +49,29 @@ func WithObservability(obs observability.Observability) Option {
 		}
 	}
 }
+
+// WithDryRun makes Up, Down, Goto and Force print the SQL they would execute
+// to w instead of applying it. A nil w prints to stdout.
+func WithDryRun(w io.Writer) Option {
+	return func(o *options) {
+		if w == nil {
+			w = os.Stdout
+		}
+		o.dryRun = w
+	}
+}
+
+// WithGoMigrations registers migrations written in Go. They run interleaved
+// by version with the files of the Source.
+func WithGoMigrations(migrations ...GoMigration) Option {
+	return func(o *options) {
+		o.goMigrations = append(o.goMigrations, migrations...)
+	}
+}
+
+// WithDriftPolicy turns on checksum verification; the default DriftIgnore records nothing.
+func WithDriftPolicy(policy database.DriftPolicy) Option {
+	return func(o *options) {
+		o.driftPolicy = policy
+	}
+}
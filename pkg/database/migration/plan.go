@@ -0,0 +1,292 @@
+package migration
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	migratelib "github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+type Direction string
+
+const (
+	DirectionUp   Direction = "up"
+	DirectionDown Direction = "down"
+)
+
+// Migration is one version found in a Source or registered with
+// WithGoMigrations. DownFile is empty when the version has no down migration;
+// both files are empty for Go migrations.
+type Migration struct {
+	Version    uint
+	Identifier string
+	UpFile     string
+	DownFile   string
+	Go         bool
+}
+
+// MigrationStatus reports whether a Migration is applied to the database.
+// Dirty is set on the current version when its last run failed midway.
+type MigrationStatus struct {
+	Migration
+	Applied bool
+	Dirty   bool
+}
+
+// Step is one migration Up, Down or Goto would execute, in execution order.
+// File is empty for Go migrations.
+type Step struct {
+	Version    uint
+	Identifier string
+	Direction  Direction
+	File       string
+	Go         bool
+}
+
+type targetKind int
+
+const (
+	targetLatest targetKind = iota
+	targetSteps
+	targetVersion
+)
+
+// Target selects the operation Plan describes.
+type Target struct {
+	kind    targetKind
+	steps   int
+	version uint
+}
+
+// Latest plans Up.
+func Latest() Target { return Target{kind: targetLatest} }
+
+// DownSteps plans Down(ctx, n).
+func DownSteps(n int) Target { return Target{kind: targetSteps, steps: n} }
+
+// ToVersion plans Goto(ctx, version).
+func ToVersion(version uint) Target { return Target{kind: targetVersion, version: version} }
+
+// sourceIndex holds the migrations of a Source ordered by version.
+type sourceIndex struct {
+	fsys       fs.FS
+	migrations []Migration
+	goms       map[uint]GoMigration
+}
+
+func loadIndex(src Source, goms map[uint]GoMigration) (*sourceIndex, error) {
+	var (
+		fsys fs.FS
+		root = "."
+	)
+	switch s := src.(type) {
+	case FSPath:
+		fsys = os.DirFS(string(s))
+	case EmbedFS:
+		fsys, root = s.FS, s.Root
+		if root == "" {
+			root = "."
+		}
+	default:
+		return nil, fmt.Errorf("migration: unsupported source type %T", src)
+	}
+	sub, err := fs.Sub(fsys, root)
+	if err != nil {
+		return nil, fmt.Errorf("migration: read source: %w", err)
+	}
+	entries, err := fs.ReadDir(sub, ".")
+	if err != nil {
+		return nil, fmt.Errorf("migration: read source: %w", err)
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		parsed, err := source.DefaultParse(e.Name())
+		if err != nil {
+			continue
+		}
+		mig, ok := byVersion[parsed.Version]
+		if !ok {
+			mig = &Migration{Version: parsed.Version, Identifier: parsed.Identifier}
+			byVersion[parsed.Version] = mig
+		}
+		target := &mig.UpFile
+		if parsed.Direction == source.Down {
+			target = &mig.DownFile
+		}
+		if *target != "" {
+			return nil, fmt.Errorf("migration: duplicate %s migration for version %d", parsed.Direction, parsed.Version)
+		}
+		*target = e.Name()
+	}
+
+	for v, gm := range goms {
+		if _, clash := byVersion[v]; clash {
+			return nil, fmt.Errorf("%w: go migration version %d collides with a migration file", database.ErrInvalidConfig, v)
+		}
+		byVersion[v] = &Migration{Version: v, Identifier: gm.Identifier, Go: true}
+	}
+
+	idx := &sourceIndex{fsys: sub, goms: goms}
+	for _, mig := range byVersion {
+		if mig.UpFile == "" && !mig.Go {
+			return nil, fmt.Errorf("migration: version %d has no up migration", mig.Version)
+		}
+		idx.migrations = append(idx.migrations, *mig)
+	}
+	slices.SortFunc(idx.migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	return idx, nil
+}
+
+// position returns the number of migrations at or below version.
+func (idx *sourceIndex) position(version uint) int {
+	n, _ := slices.BinarySearchFunc(idx.migrations, version, func(m Migration, v uint) int {
+		if m.Version <= v {
+			return -1
+		}
+		return 1
+	})
+	return n
+}
+
+func (idx *sourceIndex) status(current uint, applied, dirty bool) []MigrationStatus {
+	out := make([]MigrationStatus, len(idx.migrations))
+	for i, mig := range idx.migrations {
+		out[i] = MigrationStatus{
+			Migration: mig,
+			Applied:   applied && mig.Version <= current,
+			Dirty:     dirty && mig.Version == current,
+		}
+	}
+	return out
+}
+
+// plan mirrors the walk golang-migrate performs for target starting at the
+// current version (applied is false when the database has none).
+func (idx *sourceIndex) plan(current uint, applied bool, target Target) ([]Step, error) {
+	done := 0
+	if applied {
+		done = idx.position(current)
+	}
+
+	switch target.kind {
+	case targetLatest:
+		return idx.upSteps(done, len(idx.migrations)), nil
+	case targetSteps:
+		if target.steps <= 0 {
+			return nil, fmt.Errorf("%w: down steps must be positive", database.ErrInvalidConfig)
+		}
+		return idx.downSteps(done, max(done-target.steps, 0))
+	case targetVersion:
+		pos, found := slices.BinarySearchFunc(idx.migrations, target.version, func(m Migration, v uint) int {
+			return cmp.Compare(m.Version, v)
+		})
+		if !found {
+			return nil, fmt.Errorf("migration: version %d not found in source", target.version)
+		}
+		want := pos + 1
+		if want >= done {
+			return idx.upSteps(done, want), nil
+		}
+		return idx.downSteps(done, want)
+	default:
+		return nil, fmt.Errorf("%w: unknown plan target", database.ErrInvalidConfig)
+	}
+}
+
+func (idx *sourceIndex) upSteps(from, to int) []Step {
+	var steps []Step
+	for _, mig := range idx.migrations[from:to] {
+		steps = append(steps, Step{Version: mig.Version, Identifier: mig.Identifier, Direction: DirectionUp, File: mig.UpFile, Go: mig.Go})
+	}
+	return steps
+}
+
+func (idx *sourceIndex) downSteps(from, to int) ([]Step, error) {
+	var steps []Step
+	for i := from - 1; i >= to; i-- {
+		mig := idx.migrations[i]
+		if !idx.hasDown(mig) {
+			return nil, fmt.Errorf("migration: version %d has no down migration", mig.Version)
+		}
+		steps = append(steps, Step{Version: mig.Version, Identifier: mig.Identifier, Direction: DirectionDown, File: mig.DownFile, Go: mig.Go})
+	}
+	return steps, nil
+}
+
+func (idx *sourceIndex) hasDown(mig Migration) bool {
+	if mig.Go {
+		return idx.goms[mig.Version].Down != nil
+	}
+	return mig.DownFile != ""
+}
+
+// currentVersion reads the applied version; applied is false on an empty
+// database.
+func currentVersion(mm *migratelib.Migrate) (version uint, applied, dirty bool, err error) {
+	version, dirty, err = mm.Version()
+	if errors.Is(err, migratelib.ErrNilVersion) {
+		return 0, false, false, nil
+	}
+	if err != nil {
+		return 0, false, false, err
+	}
+	return version, true, dirty, nil
+}
+
+// planFor loads src and plans target against the database behind mm. A
+// dirty database cannot be planned until it is repaired with Force.
+func planFor(mm *migratelib.Migrate, src Source, goms map[uint]GoMigration, target Target) ([]Step, *sourceIndex, error) {
+	idx, err := loadIndex(src, goms)
+	if err != nil {
+		return nil, nil, err
+	}
+	current, applied, dirty, err := currentVersion(mm)
+	if err != nil {
+		return nil, nil, err
+	}
+	if dirty {
+		return nil, nil, migratelib.ErrDirty{Version: int(current)}
+	}
+	steps, err := idx.plan(current, applied, target)
+	return steps, idx, err
+}
+
+// writePlan prints the SQL of every step to w, each preceded by a comment
+// naming its file; Go migrations are listed as a comment only. An empty plan
+// yields ErrNoChange like a real run.
+func writePlan(w io.Writer, idx *sourceIndex, steps []Step) error {
+	if len(steps) == 0 {
+		return migratelib.ErrNoChange
+	}
+	for _, step := range steps {
+		if step.Go {
+			if _, err := fmt.Fprintf(w, "-- go migration %d_%s (%s)\n", step.Version, step.Identifier, step.Direction); err != nil {
+				return err
+			}
+			continue
+		}
+		body, err := fs.ReadFile(idx.fsys, step.File)
+		if err != nil {
+			return fmt.Errorf("migration: read %s: %w", step.File, err)
+		}
+		if _, err := fmt.Fprintf(w, "-- %s\n%s\n", step.File, strings.TrimRight(string(body), "\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"testing/fstest"
+
+	migratelib "github.com/golang-migrate/migrate/v4"
+	migratedatabase "github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability/fake"
+)
+
+// memoryDatabase guarda versão e SQL executado em memória.
+type memoryDatabase struct {
+	version int
+	dirty   bool
+	ran     []string
+}
+
+func newMemoryDatabase() *memoryDatabase {
+	return &memoryDatabase{version: migratedatabase.NilVersion}
+}
+
+func (d *memoryDatabase) Open(string) (migratedatabase.Driver, error) { return d, nil }
+func (d *memoryDatabase) Close() error                                { return nil }
+func (d *memoryDatabase) Lock() error                                 { return nil }
+func (d *memoryDatabase) Unlock() error                               { return nil }
+func (d *memoryDatabase) Drop() error                                 { return nil }
+func (d *memoryDatabase) Version() (int, bool, error)                 { return d.version, d.dirty, nil }
+
+func (d *memoryDatabase) Run(r io.Reader) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	d.ran = append(d.ran, string(body))
+	return nil
+}
+
+func (d *memoryDatabase) SetVersion(version int, dirty bool) error {
+	d.version, d.dirty = version, dirty
+	return nil
+}
+
+func newPlanMigrator(t *testing.T, db *memoryDatabase, opts ...Option) (*migrator, string) {
+	t.Helper()
+	dir := t.TempDir()
+	addMigrationPair(t, dir, 1, "users")
+	addMigrationPair(t, dir, 2, "posts")
+	addMigrationPair(t, dir, 3, "tags")
+
+	o := defaultOptions()
+	o.observability = fake.NewProvider()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &migrator{
+		open: func(context.Context) (*migratelib.Migrate, error) {
+			src, err := source.Open("file://" + dir)
+			if err != nil {
+				return nil, err
+			}
+			return migratelib.NewWithInstance("file", src, "memory", db)
+		},
+		src:  FSPath(dir),
+		opts: o,
+		obs:  o.observability,
+		drv:  database.DriverPostgres,
+	}, dir
+}
+
+func stepFiles(steps []Step) []string {
+	var files []string
+	for _, s := range steps {
+		files = append(files, s.File)
+	}
+	return files
+}
+
+func TestStatus_ReportsAppliedAndPending(t *testing.T) {
+	db := newMemoryDatabase()
+	db.version = 2
+	m, _ := newPlanMigrator(t, db)
+
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+
+	require.Equal(t, uint(1), statuses[0].Version)
+	require.Equal(t, "users", statuses[0].Identifier)
+	require.Equal(t, "000001_users.up.sql", statuses[0].UpFile)
+	require.Equal(t, "000001_users.down.sql", statuses[0].DownFile)
+	require.Equal(t, []bool{true, true, false}, []bool{statuses[0].Applied, statuses[1].Applied, statuses[2].Applied})
+}
+
+func TestStatus_EmptyDatabaseIsAllPending(t *testing.T) {
+	m, _ := newPlanMigrator(t, newMemoryDatabase())
+
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	for _, s := range statuses {
+		require.False(t, s.Applied)
+	}
+}
+
+func TestPlan_Targets(t *testing.T) {
+	cases := []struct {
+		name    string
+		current int
+		target  Target
+		want    []string
+	}{
+		{"latest a partir do vazio", migratedatabase.NilVersion, Latest(), []string{"000001_users.up.sql", "000002_posts.up.sql", "000003_tags.up.sql"}},
+		{"latest a partir da 1", 1, Latest(), []string{"000002_posts.up.sql", "000003_tags.up.sql"}},
+		{"latest já atualizado", 3, Latest(), nil},
+		{"down 2", 3, DownSteps(2), []string{"000003_tags.down.sql", "000002_posts.down.sql"}},
+		{"down além do início", 1, DownSteps(5), []string{"000001_users.down.sql"}},
+		{"goto para cima", 1, ToVersion(3), []string{"000002_posts.up.sql", "000003_tags.up.sql"}},
+		{"goto para baixo", 3, ToVersion(1), []string{"000003_tags.down.sql", "000002_posts.down.sql"}},
+		{"goto versão atual", 2, ToVersion(2), nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newMemoryDatabase()
+			db.version = tc.current
+			m, _ := newPlanMigrator(t, db)
+
+			steps, err := m.Plan(context.Background(), tc.target)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, stepFiles(steps))
+			require.Empty(t, db.ran, "Plan não pode executar SQL")
+		})
+	}
+}
+
+func TestPlan_Errors(t *testing.T) {
+	db := newMemoryDatabase()
+	m, _ := newPlanMigrator(t, db)
+
+	_, err := m.Plan(context.Background(), ToVersion(99))
+	require.ErrorIs(t, err, database.ErrMigrationFailed)
+
+	_, err = m.Plan(context.Background(), DownSteps(0))
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+
+	db.version, db.dirty = 2, true
+	_, err = m.Plan(context.Background(), Latest())
+	require.ErrorIs(t, err, database.ErrMigrationFailed)
+	require.ErrorAs(t, err, &migratelib.ErrDirty{})
+}
+
+func TestGoto_MigratesUpAndDown(t *testing.T) {
+	db := newMemoryDatabase()
+	m, _ := newPlanMigrator(t, db)
+	ctx := context.Background()
+
+	require.NoError(t, m.Goto(ctx, 2))
+	require.Equal(t, 2, db.version)
+	require.Len(t, db.ran, 2)
+
+	require.NoError(t, m.Goto(ctx, 1))
+	require.Equal(t, 1, db.version)
+	require.Equal(t, "DROP TABLE posts;", db.ran[2])
+
+	require.ErrorIs(t, m.Goto(ctx, 1), ErrNoChange)
+}
+
+func TestDryRun_PrintsSQLWithoutApplying(t *testing.T) {
+	var out bytes.Buffer
+	db := newMemoryDatabase()
+	db.version = 1
+	m, _ := newPlanMigrator(t, db, WithDryRun(&out))
+	ctx := context.Background()
+
+	require.NoError(t, m.Up(ctx))
+	require.Equal(t, "-- 000002_posts.up.sql\nCREATE TABLE posts (id BIGINT);\n-- 000003_tags.up.sql\nCREATE TABLE tags (id BIGINT);\n", out.String())
+
+	out.Reset()
+	require.NoError(t, m.Down(ctx, 1))
+	require.Equal(t, "-- 000001_users.down.sql\nDROP TABLE users;\n", out.String())
+
+	out.Reset()
+	require.NoError(t, m.Force(ctx, 3))
+	require.Equal(t, "-- force version 3\n", out.String())
+
+	require.Empty(t, db.ran)
+	require.Equal(t, 1, db.version)
+	require.False(t, db.dirty)
+}
+
+func TestDryRun_NothingPendingReturnsNoChange(t *testing.T) {
+	var out bytes.Buffer
+	db := newMemoryDatabase()
+	db.version = 3
+	m, _ := newPlanMigrator(t, db, WithDryRun(&out))
+
+	require.ErrorIs(t, m.Up(context.Background()), ErrNoChange)
+	require.Empty(t, out.String())
+}
+
+func TestPlan_EmitsSpan(t *testing.T) {
+	m, _ := newPlanMigrator(t, newMemoryDatabase())
+
+	_, err := m.Plan(context.Background(), Latest())
+	require.NoError(t, err)
+	_, err = m.Status(context.Background())
+	require.NoError(t, err)
+
+	var names []string
+	for _, s := range m.obs.Tracer().(*fake.FakeTracer).GetSpans() {
+		names = append(names, s.Name)
+	}
+	require.Contains(t, names, "db.postgres.migration.plan")
+	require.Contains(t, names, "db.postgres.migration.status")
+}
+
+func TestLoadIndex_EmbedFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/2_b.up.sql":   {Data: []byte("B")},
+		"sql/1_a.up.sql":   {Data: []byte("A")},
+		"sql/1_a.down.sql": {Data: []byte("-A")},
+		"sql/README.md":    {Data: []byte("ignorado")},
+	}
+
+	idx, err := loadIndex(EmbedFS{FS: fsys, Root: "sql"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Migration{
+		{Version: 1, Identifier: "a", UpFile: "1_a.up.sql", DownFile: "1_a.down.sql"},
+		{Version: 2, Identifier: "b", UpFile: "2_b.up.sql"},
+	}, idx.migrations)
+
+	_, err = idx.plan(2, true, DownSteps(1))
+	require.ErrorContains(t, err, "no down migration")
+}
+
+func TestLoadIndex_InvalidSources(t *testing.T) {
+	_, err := loadIndex(EmbedFS{FS: fstest.MapFS{
+		"1_a.up.sql":      {Data: []byte("A")},
+		"000001_b.up.sql": {Data: []byte("B")},
+	}}, nil)
+	require.ErrorContains(t, err, "duplicate")
+
+	_, err = loadIndex(EmbedFS{FS: fstest.MapFS{"1_a.down.sql": {Data: []byte("-A")}}}, nil)
+	require.ErrorContains(t, err, "no up migration")
+}
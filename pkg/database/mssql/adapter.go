@@ -25,7 +25,7 @@ func New(cfg MSSQLConfig, obs observability.Observability) (*Adapter, error) {
 	dsn := cfg.ResolveDSN()
 	syncDSNMetadata(&cfg, dsn)
 
-	inner, err := sqlshared.Open(sqlshared.OpenParams{
+	params := sqlshared.OpenParams{
 		Driver:     database.DriverMSSQL,
 		DriverName: "sqlserver",
 		DSN:        dsn,
@@ -43,7 +43,17 @@ func New(cfg MSSQLConfig, obs observability.Observability) (*Adapter, error) {
 		},
 		PingTimeout:   cfg.PingTimeout,
 		Observability: obs,
-	})
+		Copy:          copyIn,
+	}
+	if cfg.Credentials != nil {
+		connector, err := newCredentialsConnector(dsn, cfg.Credentials)
+		if err != nil {
+			return nil, err
+		}
+		params.Connector = connector
+	}
+
+	inner, err := sqlshared.Open(params)
 	if err != nil {
 		return nil, err
 	}
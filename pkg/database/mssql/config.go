@@ -6,6 +6,8 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
 )
 
 const (
@@ -31,6 +33,10 @@ type MSSQLConfig struct {
 
 	DefaultSchema string
 
+	// Credentials, when set, is consulted for every new connection and its
+	// non-empty fields override User and Password.
+	Credentials database.CredentialsProvider
+
 	MaxOpenConns int
 	MaxIdleConns int
 	ConnMaxLife  time.Duration
@@ -63,7 +69,7 @@ func (c MSSQLConfig) Validate() error {
 	if c.Host == "" {
 		errs = append(errs, errors.New("mssql: host is required"))
 	}
-	if c.User == "" {
+	if c.User == "" && c.Credentials == nil {
 		errs = append(errs, errors.New("mssql: user is required"))
 	}
 	if c.Database == "" {
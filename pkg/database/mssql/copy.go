@@ -0,0 +1,49 @@
+package mssql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	mssqldb "github.com/microsoft/go-mssqldb"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/sqlshared"
+)
+
+// copyIn streams src through the TDS bulk copy protocol. Rows are buffered by
+// the driver and sent when the final Exec without arguments flushes the batch.
+func copyIn(ctx context.Context, conn sqlshared.Conn, table string, columns []string, src database.CopySource) (int64, error) {
+	stmt, err := conn.PrepareContext(ctx, mssqldb.CopyIn(bracketTable(table), mssqldb.BulkOptions{}, columns...))
+	if err != nil {
+		return 0, fmt.Errorf("%s: prepare bulk copy: %w", database.DriverMSSQL, err)
+	}
+	defer stmt.Close()
+
+	for src.Next() {
+		values, err := src.Values()
+		if err != nil {
+			return 0, err
+		}
+		if _, err := stmt.ExecContext(ctx, values...); err != nil {
+			return 0, err
+		}
+	}
+	if err := src.Err(); err != nil {
+		return 0, err
+	}
+
+	res, err := stmt.ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func bracketTable(table string) string {
+	parts := strings.Split(table, ".")
+	for i, p := range parts {
+		parts[i] = "[" + p + "]"
+	}
+	return strings.Join(parts, ".")
+}
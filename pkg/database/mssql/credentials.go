@@ -0,0 +1,47 @@
+package mssql
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	mssqldb "github.com/microsoft/go-mssqldb"
+	"github.com/microsoft/go-mssqldb/msdsn"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+// credentialsConnector opens every connection with the credentials returned
+// by provider at that moment.
+type credentialsConnector struct {
+	params   msdsn.Config
+	provider database.CredentialsProvider
+	base     *mssqldb.Connector
+}
+
+var _ driver.Connector = (*credentialsConnector)(nil)
+
+func newCredentialsConnector(dsn string, provider database.CredentialsProvider) (*credentialsConnector, error) {
+	params, err := msdsn.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: mssql: invalid DSN/config", database.ErrInvalidConfig)
+	}
+	return &credentialsConnector{params: params, provider: provider, base: mssqldb.NewConnectorConfig(params)}, nil
+}
+
+func (c *credentialsConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	creds, err := c.provider.Credentials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("mssql: credentials: %w", err)
+	}
+	params := c.params
+	if creds.User != "" {
+		params.User = creds.User
+	}
+	if creds.Password != "" {
+		params.Password = creds.Password
+	}
+	return mssqldb.NewConnectorConfig(params).Connect(ctx)
+}
+
+func (c *credentialsConnector) Driver() driver.Driver { return c.base.Driver() }
@@ -0,0 +1,32 @@
+package mssql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+func TestCredentialsConnector_ConsultsProviderPerConnection(t *testing.T) {
+	boom := errors.New("vault sealed")
+	calls := 0
+	connector, err := newCredentialsConnector("sqlserver://sa@127.0.0.1:1?database=erp", database.CredentialsFunc(func(context.Context) (database.Credentials, error) {
+		calls++
+		return database.Credentials{}, boom
+	}))
+	require.NoError(t, err)
+	require.NotNil(t, connector.Driver())
+
+	_, err = connector.Connect(context.Background())
+	require.ErrorIs(t, err, boom, "o provider é consultado antes de discar")
+	_, _ = connector.Connect(context.Background())
+	require.Equal(t, 2, calls)
+}
+
+func TestValidate_CredentialsProviderMakesUserOptional(t *testing.T) {
+	cfg := MSSQLConfig{Host: "localhost", Database: "erp", Credentials: database.CredentialsFunc(nil)}
+	require.NoError(t, cfg.Validate(), "com provider o usuário pode vir dele")
+}
@@ -0,0 +1,83 @@
+package mssql
+
+import (
+	"errors"
+	"strings"
+
+	mssqldb "github.com/microsoft/go-mssqldb"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+const (
+	errCannotInsertNull       = 515
+	errConstraintConflict     = 547
+	errDeadlockVictim         = 1205
+	errDuplicateKeyIndex      = 2601
+	errDuplicateKeyConstraint = 2627
+	errSnapshotUpdateConflict = 3960
+)
+
+func init() {
+	database.RegisterErrorClassifier(classifyError)
+}
+
+func classifyError(err error) (database.ErrorInfo, bool) {
+	var msErr mssqldb.Error
+	if !errors.As(err, &msErr) {
+		return database.ErrorInfo{}, false
+	}
+
+	message := msErr.Message
+	switch msErr.SQLErrorNumber() {
+	case errDuplicateKeyConstraint:
+		return database.ErrorInfo{
+			Kind:       database.ErrorKindUniqueViolation,
+			Constraint: between(message, "constraint '", "'"),
+		}, true
+	case errDuplicateKeyIndex:
+		return database.ErrorInfo{
+			Kind:       database.ErrorKindUniqueViolation,
+			Constraint: between(message, "unique index '", "'"),
+		}, true
+	case errConstraintConflict:
+		// 547 covers FOREIGN KEY, REFERENCE (delete side) and CHECK conflicts.
+		switch {
+		case strings.Contains(message, "CHECK constraint"):
+			return database.ErrorInfo{
+				Kind:       database.ErrorKindCheckViolation,
+				Constraint: between(message, "CHECK constraint \"", "\""),
+			}, true
+		case strings.Contains(message, "REFERENCE constraint"):
+			return database.ErrorInfo{
+				Kind:       database.ErrorKindForeignKeyViolation,
+				Constraint: between(message, "REFERENCE constraint \"", "\""),
+			}, true
+		default:
+			return database.ErrorInfo{
+				Kind:       database.ErrorKindForeignKeyViolation,
+				Constraint: between(message, "FOREIGN KEY constraint \"", "\""),
+			}, true
+		}
+	case errCannotInsertNull:
+		return database.ErrorInfo{Kind: database.ErrorKindNotNullViolation}, true
+	case errDeadlockVictim:
+		return database.ErrorInfo{Kind: database.ErrorKindDeadlock}, true
+	case errSnapshotUpdateConflict:
+		return database.ErrorInfo{Kind: database.ErrorKindSerializationFailure}, true
+	default:
+		return database.ErrorInfo{}, false
+	}
+}
+
+func between(s, prefix, suffix string) string {
+	_, rest, found := strings.Cut(s, prefix)
+	if !found {
+		return ""
+	}
+	value, _, found := strings.Cut(rest, suffix)
+	if !found {
+		return ""
+	}
+	return value
+}
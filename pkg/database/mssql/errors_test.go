@@ -0,0 +1,37 @@
+package mssql_test
+
+import (
+	"fmt"
+	"testing"
+
+	mssqldb "github.com/microsoft/go-mssqldb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	_ "github.com/JailtonJunior94/devkit-go/pkg/database/mssql"
+)
+
+func TestClassify_MSSQLErrorNumbers(t *testing.T) {
+	cases := []struct {
+		number     int32
+		message    string
+		kind       database.ErrorKind
+		constraint string
+	}{
+		{2627, "Violation of UNIQUE KEY constraint 'UQ_users_email'. Cannot insert duplicate key in object 'dbo.users'.", database.ErrorKindUniqueViolation, "UQ_users_email"},
+		{2601, "Cannot insert duplicate key row in object 'dbo.users' with unique index 'IX_users_email'.", database.ErrorKindUniqueViolation, "IX_users_email"},
+		{547, "The INSERT statement conflicted with the FOREIGN KEY constraint \"FK_orders_users\". The conflict occurred in database \"app\".", database.ErrorKindForeignKeyViolation, "FK_orders_users"},
+		{547, "The DELETE statement conflicted with the REFERENCE constraint \"FK_orders_users\".", database.ErrorKindForeignKeyViolation, "FK_orders_users"},
+		{547, "The INSERT statement conflicted with the CHECK constraint \"CK_orders_total\".", database.ErrorKindCheckViolation, "CK_orders_total"},
+		{515, "Cannot insert the value NULL into column 'name', table 'app.dbo.users'; column does not allow nulls.", database.ErrorKindNotNullViolation, ""},
+		{1205, "Transaction was deadlocked", database.ErrorKindDeadlock, ""},
+		{3960, "Snapshot isolation transaction aborted due to update conflict.", database.ErrorKindSerializationFailure, ""},
+		{208, "Invalid object name 'x'.", database.ErrorKindUnknown, ""},
+	}
+	for _, tc := range cases {
+		err := fmt.Errorf("exec: %w", mssqldb.Error{Number: tc.number, Message: tc.message})
+
+		require.Equal(t, tc.kind, database.Classify(err), tc.message)
+		require.Equal(t, tc.constraint, database.ConstraintName(err), tc.message)
+	}
+}
@@ -10,6 +10,9 @@ import (
 // Compile-time assertion: *mssql.Tx must satisfy database.Tx.
 var _ database.Tx = (*mssql.Tx)(nil)
 
+// Compile-time assertion: *mssql.Tx supports bulk copy.
+var _ database.Copier = (*mssql.Tx)(nil)
+
 // TestTx_SatisfiesDBTXInterface passes as long as the file compiles.
 // Behavioural tests for Tx (Exec, Query, Commit, Rollback) are in the
 // integration suite which requires a real MSSQL instance.
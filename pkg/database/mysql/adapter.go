@@ -17,6 +17,9 @@ type Adapter struct {
 
 type Tx = sqlshared.Tx
 
+// maxBindParams is the placeholder limit of a MySQL prepared statement.
+const maxBindParams = 65535
+
 func New(cfg MySQLConfig, obs observability.Observability) (*Adapter, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %w", database.ErrInvalidConfig, err)
@@ -25,7 +28,7 @@ func New(cfg MySQLConfig, obs observability.Observability) (*Adapter, error) {
 	dsn := cfg.ResolveDSN()
 	syncDSNMetadata(&cfg, dsn)
 
-	inner, err := sqlshared.Open(sqlshared.OpenParams{
+	params := sqlshared.OpenParams{
 		Driver:     database.DriverMySQL,
 		DriverName: "mysql",
 		DSN:        dsn,
@@ -43,7 +46,17 @@ func New(cfg MySQLConfig, obs observability.Observability) (*Adapter, error) {
 		},
 		PingTimeout:   cfg.PingTimeout,
 		Observability: obs,
-	})
+		Copy:          sqlshared.BatchInsert(database.DriverMySQL, quoteIdentifier, maxBindParams),
+	}
+	if cfg.Credentials != nil {
+		connector, err := credentialsConnector(dsn, cfg.Credentials)
+		if err != nil {
+			return nil, err
+		}
+		params.Connector = connector
+	}
+
+	inner, err := sqlshared.Open(params)
 	if err != nil {
 		return nil, err
 	}
@@ -57,3 +70,7 @@ func syncDSNMetadata(cfg *MySQLConfig, dsn string) {
 		}
 	}
 }
+
+func quoteIdentifier(name string) string {
+	return "`" + name + "`"
+}
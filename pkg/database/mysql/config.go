@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
 )
 
 const (
@@ -27,6 +29,10 @@ type MySQLConfig struct {
 	Password string
 	Database string
 
+	// Credentials, when set, is consulted for every new connection and its
+	// non-empty fields override User and Password.
+	Credentials database.CredentialsProvider
+
 	MaxOpenConns int
 	MaxIdleConns int
 	ConnMaxLife  time.Duration
@@ -53,7 +59,7 @@ func (c MySQLConfig) Validate() error {
 	if c.Host == "" {
 		errs = append(errs, errors.New("mysql: host is required"))
 	}
-	if c.User == "" {
+	if c.User == "" && c.Credentials == nil {
 		errs = append(errs, errors.New("mysql: user is required"))
 	}
 	if c.Database == "" {
@@ -0,0 +1,37 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+// credentialsConnector applies provider to every new connection through the
+// BeforeConnect hook of the driver.
+func credentialsConnector(dsn string, provider database.CredentialsProvider) (driver.Connector, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: mysql: invalid DSN/config", database.ErrInvalidConfig)
+	}
+	err = cfg.Apply(mysql.BeforeConnect(func(ctx context.Context, c *mysql.Config) error {
+		creds, err := provider.Credentials(ctx)
+		if err != nil {
+			return fmt.Errorf("mysql: credentials: %w", err)
+		}
+		if creds.User != "" {
+			c.User = creds.User
+		}
+		if creds.Password != "" {
+			c.Passwd = creds.Password
+		}
+		return nil
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("%w: mysql: invalid DSN/config", database.ErrInvalidConfig)
+	}
+	return mysql.NewConnector(cfg)
+}
@@ -0,0 +1,33 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+func TestCredentialsConnector_ConsultsProviderPerConnection(t *testing.T) {
+	boom := errors.New("vault sealed")
+	calls := 0
+	connector, err := credentialsConnector("app@tcp(127.0.0.1:1)/db", database.CredentialsFunc(func(context.Context) (database.Credentials, error) {
+		calls++
+		return database.Credentials{}, boom
+	}))
+	require.NoError(t, err)
+
+	_, err = connector.Connect(context.Background())
+	require.ErrorIs(t, err, boom, "o provider é consultado antes de discar")
+	_, _ = connector.Connect(context.Background())
+	require.Equal(t, 2, calls)
+}
+
+func TestCredentialsConnector_InvalidDSNDoesNotLeak(t *testing.T) {
+	const dsn = "user:sup3r-s3cr3t@tcp(host"
+	_, err := credentialsConnector(dsn, database.CredentialsFunc(nil))
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+	require.NotContains(t, err.Error(), "sup3r-s3cr3t")
+}
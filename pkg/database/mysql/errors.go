@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+const (
+	errServerShutdown        = 1053
+	errDuplicateEntry        = 1062
+	errBadNull               = 1048
+	errDeadlock              = 1213
+	errNoReferencedRowLegacy = 1216
+	errRowIsReferencedLegacy = 1217
+	errNoDefaultForField     = 1364
+	errRowIsReferenced       = 1451
+	errNoReferencedRow       = 1452
+	errDuplicateEntryWithKey = 1586
+	errConnectionKilled      = 1927
+	errCheckConstraint       = 3819
+)
+
+func init() {
+	database.RegisterErrorClassifier(classifyError)
+}
+
+func classifyError(err error) (database.ErrorInfo, bool) {
+	if errors.Is(err, mysql.ErrInvalidConn) {
+		return database.ErrorInfo{Kind: database.ErrorKindConnection}, true
+	}
+
+	var myErr *mysql.MySQLError
+	if !errors.As(err, &myErr) {
+		return database.ErrorInfo{}, false
+	}
+
+	switch myErr.Number {
+	case errDuplicateEntry, errDuplicateEntryWithKey:
+		return database.ErrorInfo{
+			Kind:       database.ErrorKindUniqueViolation,
+			Constraint: duplicateKeyName(myErr.Message),
+		}, true
+	case errRowIsReferenced, errNoReferencedRow, errRowIsReferencedLegacy, errNoReferencedRowLegacy:
+		return database.ErrorInfo{
+			Kind:       database.ErrorKindForeignKeyViolation,
+			Constraint: between(myErr.Message, "CONSTRAINT `", "`"),
+		}, true
+	case errBadNull, errNoDefaultForField:
+		return database.ErrorInfo{Kind: database.ErrorKindNotNullViolation}, true
+	case errCheckConstraint:
+		return database.ErrorInfo{
+			Kind:       database.ErrorKindCheckViolation,
+			Constraint: between(myErr.Message, "constraint '", "'"),
+		}, true
+	case errDeadlock:
+		return database.ErrorInfo{Kind: database.ErrorKindDeadlock}, true
+	case errServerShutdown, errConnectionKilled:
+		return database.ErrorInfo{Kind: database.ErrorKindConnection}, true
+	default:
+		return database.ErrorInfo{}, false
+	}
+}
+
+// duplicateKeyName extracts the key from "Duplicate entry 'x' for key 'tbl.key'".
+// MySQL 8 prefixes the key with its table name; the prefix is dropped so the
+// result matches the constraint name used in the DDL.
+func duplicateKeyName(message string) string {
+	idx := strings.LastIndex(message, "for key '")
+	if idx < 0 {
+		return ""
+	}
+	key := between(message[idx:], "for key '", "'")
+	if dot := strings.LastIndexByte(key, '.'); dot >= 0 {
+		key = key[dot+1:]
+	}
+	return key
+}
+
+func between(s, prefix, suffix string) string {
+	_, rest, found := strings.Cut(s, prefix)
+	if !found {
+		return ""
+	}
+	value, _, found := strings.Cut(rest, suffix)
+	if !found {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,40 @@
+package mysql_test
+
+import (
+	"fmt"
+	"testing"
+
+	driver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	_ "github.com/JailtonJunior94/devkit-go/pkg/database/mysql"
+)
+
+func TestClassify_MySQLErrorNumbers(t *testing.T) {
+	cases := []struct {
+		number     uint16
+		message    string
+		kind       database.ErrorKind
+		constraint string
+	}{
+		{1062, "Duplicate entry 'a@b.c' for key 'users.users_email_uq'", database.ErrorKindUniqueViolation, "users_email_uq"},
+		{1062, "Duplicate entry 'a@b.c' for key 'users_email_uq'", database.ErrorKindUniqueViolation, "users_email_uq"},
+		{1452, "Cannot add or update a child row: a foreign key constraint fails (`app`.`orders`, CONSTRAINT `fk_orders_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`))", database.ErrorKindForeignKeyViolation, "fk_orders_user"},
+		{1451, "Cannot delete or update a parent row: a foreign key constraint fails (`app`.`orders`, CONSTRAINT `fk_orders_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`))", database.ErrorKindForeignKeyViolation, "fk_orders_user"},
+		{1048, "Column 'name' cannot be null", database.ErrorKindNotNullViolation, ""},
+		{3819, "Check constraint 'orders_total_chk' is violated.", database.ErrorKindCheckViolation, "orders_total_chk"},
+		{1213, "Deadlock found when trying to get lock", database.ErrorKindDeadlock, ""},
+		{1205, "Lock wait timeout exceeded", database.ErrorKindUnknown, ""},
+	}
+	for _, tc := range cases {
+		err := fmt.Errorf("exec: %w", &driver.MySQLError{Number: tc.number, Message: tc.message})
+
+		require.Equal(t, tc.kind, database.Classify(err), tc.message)
+		require.Equal(t, tc.constraint, database.ConstraintName(err), tc.message)
+	}
+}
+
+func TestClassify_MySQLInvalidConnIsConnectionError(t *testing.T) {
+	require.True(t, database.IsConnectionError(fmt.Errorf("query: %w", driver.ErrInvalidConn)))
+}
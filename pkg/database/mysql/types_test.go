@@ -10,6 +10,9 @@ import (
 // Compile-time assertion: *mysql.Tx must satisfy database.Tx.
 var _ database.Tx = (*mysql.Tx)(nil)
 
+// Compile-time assertion: *mysql.Tx supports bulk copy.
+var _ database.Copier = (*mysql.Tx)(nil)
+
 // TestTx_SatisfiesDBTXInterface passes as long as the file compiles.
 // Behavioural tests for Tx (Exec, Query, Commit, Rollback) are in the
 // integration suite which requires a real MySQL instance.
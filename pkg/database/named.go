@@ -0,0 +1,175 @@
+package database
+
+import (
+	sqldriver "database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// maxCachedNamed bounds the parsed queries kept by namedQueries, so queries
+// built at runtime cannot grow it without limit.
+const maxCachedNamed = 1024
+
+var (
+	namedQueries sync.Map // map[string]*namedQuery
+	namedCount   atomic.Int64
+)
+
+// namedQuery is a query split around its `:name` parameters: text[i]
+// precedes names[i] and the last text ends the query.
+type namedQuery struct {
+	text  []string
+	names []string
+}
+
+// parseNamed splits query around its `:name` parameters, caching the result
+// per query string. Quoted strings and identifiers, comments and Postgres
+// `::type` casts are left untouched.
+func parseNamed(query string) *namedQuery {
+	if cached, ok := namedQueries.Load(query); ok {
+		return cached.(*namedQuery)
+	}
+
+	q := &namedQuery{}
+	var b strings.Builder
+	b.Grow(len(query))
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				b.WriteString(query[i:])
+				i = len(query)
+				continue
+			}
+			b.WriteString(query[i : i+end+2])
+			i += end + 1
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				b.WriteString(query[i:])
+				i = len(query)
+				continue
+			}
+			b.WriteString(query[i : i+end+1])
+			i += end
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				b.WriteString(query[i:])
+				i = len(query)
+				continue
+			}
+			b.WriteString(query[i : i+end+4])
+			i += end + 3
+		case c == ':' && i+1 < len(query) && query[i+1] == ':':
+			b.WriteString("::")
+			i++
+		case c == ':' && i+1 < len(query) && isNameStart(query[i+1]):
+			j := i + 1
+			for j < len(query) && isNameChar(query[j]) {
+				j++
+			}
+			q.text = append(q.text, b.String())
+			q.names = append(q.names, query[i+1:j])
+			b.Reset()
+			i = j - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+	q.text = append(q.text, b.String())
+
+	if namedCount.Load() < maxCachedNamed {
+		if _, loaded := namedQueries.LoadOrStore(query, q); !loaded {
+			namedCount.Add(1)
+		}
+	}
+	return q
+}
+
+// bindNamed rewrites query for driver and collects its arguments from arg.
+// A slice value, other than []byte or a driver.Valuer, expands to one
+// placeholder per element so it can fill an `IN (:ids)` list.
+func bindNamed(driver Driver, query string, arg any) (string, []any, error) {
+	values, err := namedValues(arg)
+	if err != nil {
+		return "", nil, err
+	}
+
+	q := parseNamed(query)
+	var b strings.Builder
+	b.Grow(len(query) + 4*len(q.names))
+	args := make([]any, 0, len(q.names))
+	for i, name := range q.names {
+		b.WriteString(q.text[i])
+		value, ok := values[name]
+		if !ok {
+			return "", nil, fmt.Errorf("database: missing value for named parameter %q", name)
+		}
+
+		items, expand := expandSlice(value)
+		if !expand {
+			args = append(args, value)
+			b.WriteString(driver.Placeholder(len(args)))
+			continue
+		}
+		if len(items) == 0 {
+			return "", nil, fmt.Errorf("database: named parameter %q is an empty slice", name)
+		}
+		for j, item := range items {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			args = append(args, item)
+			b.WriteString(driver.Placeholder(len(args)))
+		}
+	}
+	b.WriteString(q.text[len(q.names)])
+	return b.String(), args, nil
+}
+
+func expandSlice(value any) ([]any, bool) {
+	if value == nil {
+		return nil, false
+	}
+	if _, ok := value.(sqldriver.Valuer); ok {
+		return nil, false
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+	items := make([]any, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+	return items, true
+}
+
+func namedValues(arg any) (map[string]any, error) {
+	if m, ok := arg.(map[string]any); ok {
+		return m, nil
+	}
+
+	v := reflect.ValueOf(arg)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("database: named arguments must be a map[string]any or a struct, got %T", arg)
+	}
+	return fieldValues(v), nil
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,53 @@
+package database
+
+import "context"
+
+// NamedDB runs statements with `:name` parameters on a DBTX, rewriting them
+// to the placeholders of its driver. arg is a map[string]any or a struct (or
+// pointer to struct) named like the scanning helpers expect; slice values
+// expand for `IN (:ids)`. The parsed form of each query is cached, so
+// queries should be constant strings.
+type NamedDB struct {
+	db     DBTX
+	driver Driver
+}
+
+// Named wraps db for driver, typically Named(mgr.DBTX(ctx), mgr.Driver()).
+func Named(db DBTX, driver Driver) NamedDB {
+	return NamedDB{db: db, driver: driver}
+}
+
+// Bind returns query rewritten for the driver and its positional arguments,
+// for use with QueryAll, QueryOne or QueryIter.
+func (n NamedDB) Bind(query string, arg any) (string, []any, error) {
+	return bindNamed(n.driver, query, arg)
+}
+
+func (n NamedDB) ExecContext(ctx context.Context, query string, arg any) (Result, error) {
+	compiled, args, err := n.Bind(query, arg)
+	if err != nil {
+		return nil, err
+	}
+	return n.db.ExecContext(ctx, compiled, args...)
+}
+
+func (n NamedDB) QueryContext(ctx context.Context, query string, arg any) (Rows, error) {
+	compiled, args, err := n.Bind(query, arg)
+	if err != nil {
+		return nil, err
+	}
+	return n.db.QueryContext(ctx, compiled, args...)
+}
+
+// QueryRowContext defers a binding error to Scan.
+func (n NamedDB) QueryRowContext(ctx context.Context, query string, arg any) Row {
+	compiled, args, err := n.Bind(query, arg)
+	if err != nil {
+		return errRow{err: err}
+	}
+	return n.db.QueryRowContext(ctx, compiled, args...)
+}
+
+type errRow struct{ err error }
+
+func (r errRow) Scan(...any) error { return r.err }
@@ -0,0 +1,70 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/nullable"
+)
+
+func TestNamed_ExpandsSlicesPerDriver(t *testing.T) {
+	const query = "SELECT id FROM orders WHERE status = :status AND id IN (:ids)"
+	arg := map[string]any{"status": "open", "ids": []int64{1, 2, 3}}
+
+	cases := map[database.Driver]string{
+		database.DriverPostgres: "SELECT id FROM orders WHERE status = $1 AND id IN ($2, $3, $4)",
+		database.DriverMySQL:    "SELECT id FROM orders WHERE status = ? AND id IN (?, ?, ?)",
+		database.DriverMSSQL:    "SELECT id FROM orders WHERE status = @p1 AND id IN (@p2, @p3, @p4)",
+	}
+	for driver, want := range cases {
+		db := &queryDBTX{rows: &fakeRows{}}
+		_, err := database.Named(db, driver).QueryContext(context.Background(), query, arg)
+
+		require.NoError(t, err)
+		require.Equal(t, want, db.lastQuery)
+		require.Equal(t, []any{"open", int64(1), int64(2), int64(3)}, db.lastArgs)
+	}
+}
+
+func TestNamed_BytesAndValuersAreNotExpanded(t *testing.T) {
+	db := &queryDBTX{}
+	named := database.Named(db, database.DriverPostgres)
+	note := nullable.StringOf("x")
+
+	_, err := named.ExecContext(context.Background(), "UPDATE files SET data = :data, note = :note", map[string]any{
+		"data": []byte("raw"),
+		"note": note,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE files SET data = $1, note = $2", db.lastQuery)
+	require.Equal(t, []any{[]byte("raw"), note}, db.lastArgs)
+}
+
+func TestNamed_EmptySliceAndBindErrors(t *testing.T) {
+	db := &queryDBTX{}
+	named := database.Named(db, database.DriverMySQL)
+
+	_, err := named.ExecContext(context.Background(), "DELETE FROM t WHERE id IN (:ids)", map[string]any{"ids": []string{}})
+	require.ErrorContains(t, err, `"ids" is an empty slice`)
+
+	err = named.QueryRowContext(context.Background(), "SELECT :missing", map[string]any{}).Scan()
+	require.ErrorContains(t, err, `"missing"`, "o erro de bind aparece no Scan")
+	require.Empty(t, db.lastQuery, "nada é executado quando o bind falha")
+}
+
+func TestNamed_BindFeedsQueryHelpers(t *testing.T) {
+	db := &queryDBTX{rows: &fakeRows{columns: []string{"id"}, data: [][]any{{int64(1)}, {int64(2)}}}}
+	named := database.Named(db, database.DriverPostgres)
+
+	query, args, err := named.Bind("SELECT id FROM t WHERE id IN (:ids) OR parent_id IN (:ids)", map[string]any{"ids": []int64{1, 2}})
+	require.NoError(t, err)
+	require.Equal(t, "SELECT id FROM t WHERE id IN ($1, $2) OR parent_id IN ($3, $4)", query)
+	require.Equal(t, []any{int64(1), int64(2), int64(1), int64(2)}, args)
+
+	ids, err := database.QueryAll[int64](context.Background(), db, query, args...)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2}, ids)
+}
@@ -0,0 +1,15 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNamed_CachesPerQuery(t *testing.T) {
+	const query = "SELECT * FROM t WHERE a = :a AND b = ':b' AND c = :c"
+	first := parseNamed(query)
+	require.Same(t, first, parseNamed(query), "a mesma query reaproveita o resultado do parse")
+	require.Equal(t, []string{"a", "c"}, first.names)
+	require.Equal(t, []string{"SELECT * FROM t WHERE a = ", " AND b = ':b' AND c = ", ""}, first.text)
+}
@@ -0,0 +1,40 @@
+package database
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrListenUnsupported is returned by managers whose driver has no
+	// LISTEN/NOTIFY.
+	ErrListenUnsupported = errors.New("database: LISTEN not supported by driver")
+	// ErrSubscriptionClosed is returned by Next after Close.
+	ErrSubscriptionClosed = errors.New("database: subscription closed")
+)
+
+// Notification is one NOTIFY delivered to a Subscription.
+type Notification struct {
+	Channel string
+	Payload string
+	// PID is the backend process that sent the notification.
+	PID uint32
+}
+
+// Listener is implemented by managers that can LISTEN on postgres channels.
+type Listener interface {
+	// Listen pins a pooled connection and subscribes it to channels until
+	// the Subscription is closed.
+	Listen(ctx context.Context, channels ...string) (Subscription, error)
+}
+
+// Subscription receives the notifications of the channels it listens on.
+// Notifications sent while no subscription is open are not delivered.
+type Subscription interface {
+	// Next blocks until a notification arrives, ctx is done or the
+	// connection is lost. Any error, including ctx's, ends the subscription.
+	Next(ctx context.Context) (Notification, error)
+	// Close unsubscribes and returns the connection to the pool. It may be
+	// called while Next is blocked, which then returns ErrSubscriptionClosed.
+	Close(ctx context.Context) error
+}
@@ -0,0 +1,95 @@
+package outbox
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/claim"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/dialect"
+)
+
+const selectColumns = "id, destination, message_key, headers, message_headers, payload, created_at, attempts"
+
+type queries struct {
+	insert     string
+	claim      string
+	markSent   string
+	markFailed string
+}
+
+func buildQueries(driver database.Driver, table string) (queries, error) {
+	if err := claim.Validate("outbox", driver, table); err != nil {
+		return queries{}, err
+	}
+
+	p := func(n int) string { return dialect.Placeholder(driver, n) }
+
+	q := queries{
+		insert: fmt.Sprintf(
+			"INSERT INTO %s (destination, message_key, headers, message_headers, payload, created_at) VALUES (%s, %s, %s, %s, %s, %s)",
+			table, p(1), p(2), p(3), p(4), p(5), p(6),
+		),
+		markSent:   fmt.Sprintf("UPDATE %s SET sent_at = %s, attempts = attempts + 1 WHERE id = %s", table, p(1), p(2)),
+		markFailed: fmt.Sprintf("UPDATE %s SET attempts = attempts + 1, last_error = %s WHERE id = %s", table, p(1), p(2)),
+	}
+
+	q.claim = claim.Query(driver, selectColumns, table, "sent_at IS NULL AND attempts < "+p(1), "id", p(2))
+	return q, nil
+}
+
+func Schema(driver database.Driver, table string) (string, error) {
+	if table == "" {
+		table = DefaultTable
+	}
+	if err := claim.Validate("outbox", driver, table); err != nil {
+		return "", err
+	}
+
+	index := table[strings.LastIndex(table, ".")+1:] + "_pending_idx"
+
+	switch driver {
+	case database.DriverPostgres, database.DriverCockroach:
+		return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+	id BIGSERIAL PRIMARY KEY,
+	destination VARCHAR(255) NOT NULL,
+	message_key VARCHAR(255) NOT NULL DEFAULT '',
+	headers TEXT NOT NULL,
+	message_headers TEXT NOT NULL,
+	payload BYTEA NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	attempts INT NOT NULL DEFAULT 0,
+	last_error TEXT NULL,
+	sent_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS %[2]s ON %[1]s (id) WHERE sent_at IS NULL;`, table, index), nil
+	case database.DriverMySQL:
+		return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+	id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+	destination VARCHAR(255) NOT NULL,
+	message_key VARCHAR(255) NOT NULL DEFAULT '',
+	headers TEXT NOT NULL,
+	message_headers TEXT NOT NULL,
+	payload LONGBLOB NOT NULL,
+	created_at DATETIME(6) NOT NULL,
+	attempts INT NOT NULL DEFAULT 0,
+	last_error TEXT NULL,
+	sent_at DATETIME(6) NULL,
+	KEY %[2]s (sent_at, id)
+);`, table, index), nil
+	default:
+		return fmt.Sprintf(`IF OBJECT_ID(N'%[1]s', N'U') IS NULL
+CREATE TABLE %[1]s (
+	id BIGINT IDENTITY(1,1) PRIMARY KEY,
+	destination NVARCHAR(255) NOT NULL,
+	message_key NVARCHAR(255) NOT NULL DEFAULT '',
+	headers NVARCHAR(MAX) NOT NULL,
+	message_headers NVARCHAR(MAX) NOT NULL,
+	payload VARBINARY(MAX) NOT NULL,
+	created_at DATETIME2 NOT NULL,
+	attempts INT NOT NULL DEFAULT 0,
+	last_error NVARCHAR(MAX) NULL,
+	sent_at DATETIME2 NULL
+);`, table), nil
+	}
+}
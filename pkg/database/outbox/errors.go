@@ -0,0 +1,11 @@
+package outbox
+
+import "errors"
+
+var (
+	ErrNoTransaction    = errors.New("outbox: enqueue requires an active transaction in context")
+	ErrRelayRunning     = errors.New("outbox: relay already running")
+	ErrNilPublisher     = errors.New("outbox: publisher is nil")
+	ErrNilMessage       = errors.New("outbox: message is nil")
+	ErrEmptyDestination = errors.New("outbox: destination is required")
+)
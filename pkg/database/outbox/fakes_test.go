@@ -0,0 +1,157 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/messaging"
+)
+
+// --- fakes ---------------------------------------------------------------
+
+type execCall struct {
+	query string
+	args  []any
+}
+
+type fakeResult struct{}
+
+func (fakeResult) RowsAffected() (int64, error) { return 1, nil }
+
+// fakeRows devolve linhas pré-definidas para a query de claim.
+type fakeRows struct {
+	rows [][]any
+	idx  int
+}
+
+func (r *fakeRows) Next() bool {
+	if r.idx >= len(r.rows) {
+		return false
+	}
+	r.idx++
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	row := r.rows[r.idx-1]
+	for i, d := range dest {
+		switch p := d.(type) {
+		case *int64:
+			*p = row[i].(int64)
+		case *int:
+			*p = row[i].(int)
+		case *string:
+			*p = row[i].(string)
+		case *[]byte:
+			*p = row[i].([]byte)
+		case *time.Time:
+			*p = row[i].(time.Time)
+		default:
+			return errors.New("fakeRows: unsupported dest")
+		}
+	}
+	return nil
+}
+
+func (r *fakeRows) Close() error { return nil }
+func (r *fakeRows) Err() error   { return nil }
+
+type fakeTx struct {
+	mu         sync.Mutex
+	execs      []execCall
+	queries    []execCall
+	rows       [][]any
+	execErr    error
+	committed  bool
+	rolledBack bool
+}
+
+func (t *fakeTx) ExecContext(_ context.Context, query string, args ...any) (database.Result, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.execs = append(t.execs, execCall{query: query, args: args})
+	if t.execErr != nil {
+		return nil, t.execErr
+	}
+	return fakeResult{}, nil
+}
+
+func (t *fakeTx) QueryContext(_ context.Context, query string, args ...any) (database.Rows, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.queries = append(t.queries, execCall{query: query, args: args})
+	rows := t.rows
+	t.rows = nil
+	return &fakeRows{rows: rows}, nil
+}
+
+func (t *fakeTx) QueryRowContext(_ context.Context, _ string, _ ...any) database.Row { return nil }
+
+func (t *fakeTx) Commit(_ context.Context) error {
+	t.committed = true
+	return nil
+}
+
+func (t *fakeTx) Rollback(_ context.Context) error {
+	t.rolledBack = true
+	return nil
+}
+
+type fakeManager struct {
+	driver database.Driver
+	tx     *fakeTx
+}
+
+func (m *fakeManager) Driver() database.Driver              { return m.driver }
+func (m *fakeManager) DBTX(_ context.Context) database.DBTX { return m.tx }
+func (m *fakeManager) BeginTx(_ context.Context, _ database.TxOptions) (database.Tx, error) {
+	return m.tx, nil
+}
+func (m *fakeManager) Ping(_ context.Context) error     { return nil }
+func (m *fakeManager) Shutdown(_ context.Context) error { return nil }
+func (m *fakeManager) TryLock(_ context.Context, _ string) (database.Lock, bool, error) {
+	return nil, false, database.ErrLockUnsupported
+}
+func (m *fakeManager) Lock(_ context.Context, _ string) (database.Lock, error) {
+	return nil, database.ErrLockUnsupported
+}
+func (m *fakeManager) WithLock(_ context.Context, _ string, _ func(context.Context) error) error {
+	return database.ErrLockUnsupported
+}
+
+type published struct {
+	destination string
+	key         string
+	headers     map[string]string
+	message     *messaging.Message
+}
+
+type fakePublisher struct {
+	mu        sync.Mutex
+	published []published
+	failFor   map[string]error
+}
+
+func (p *fakePublisher) Publish(_ context.Context, topicOrQueue, key string, headers map[string]string, message *messaging.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err, ok := p.failFor[topicOrQueue]; ok {
+		return err
+	}
+	p.published = append(p.published, published{destination: topicOrQueue, key: key, headers: headers, message: message})
+	return nil
+}
+
+func (p *fakePublisher) PublishBatch(ctx context.Context, topicOrQueue, key string, headers map[string]string, messages []*messaging.Message) error {
+	for _, m := range messages {
+		if err := p.Publish(ctx, topicOrQueue, key, headers, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *fakePublisher) Close() error { return nil }
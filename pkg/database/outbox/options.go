@@ -0,0 +1,65 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/claim"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability/noop"
+)
+
+const (
+	DefaultTable        = "outbox_messages"
+	DefaultBatchSize    = 100
+	DefaultPollInterval = time.Second
+	DefaultMaxAttempts  = 10
+	defaultRelayName    = "outbox-relay"
+)
+
+type Option func(*options)
+
+type options struct {
+	claim.Options
+	name string
+}
+
+func defaultOptions() options {
+	return options{
+		Options: claim.Options{
+			Table:         DefaultTable,
+			BatchSize:     DefaultBatchSize,
+			PollInterval:  DefaultPollInterval,
+			MaxAttempts:   DefaultMaxAttempts,
+			Observability: noop.NewProvider(),
+		},
+		name: defaultRelayName,
+	}
+}
+
+func WithTable(table string) Option {
+	return func(o *options) { o.SetTable(table) }
+}
+
+func WithName(name string) Option {
+	return func(o *options) {
+		if name != "" {
+			o.name = name
+		}
+	}
+}
+
+func WithBatchSize(n int) Option {
+	return func(o *options) { o.SetBatchSize(n) }
+}
+
+func WithPollInterval(d time.Duration) Option {
+	return func(o *options) { o.SetPollInterval(d) }
+}
+
+func WithMaxAttempts(n int) Option {
+	return func(o *options) { o.SetMaxAttempts(n) }
+}
+
+func WithObservability(obs observability.Observability) Option {
+	return func(o *options) { o.SetObservability(obs) }
+}
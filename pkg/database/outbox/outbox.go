@@ -0,0 +1,127 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/manager"
+	"github.com/JailtonJunior94/devkit-go/pkg/messaging"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+)
+
+type Outbox interface {
+	Enqueue(ctx context.Context, destination, key string, headers map[string]string, messages ...*messaging.Message) error
+}
+
+type outbox struct {
+	driver   database.Driver
+	queries  queries
+	enqueued observability.Counter
+	now      func() time.Time
+}
+
+func New(mgr manager.Manager, opts ...Option) (Outbox, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	driver := mgr.Driver()
+	q, err := buildQueries(driver, o.Table)
+	if err != nil {
+		return nil, err
+	}
+
+	return &outbox{
+		driver:   driver,
+		queries:  q,
+		enqueued: o.Observability.Metrics().Counter("database.outbox.enqueued", "Messages written to the outbox", "{messages}"),
+		now:      time.Now,
+	}, nil
+}
+
+func (o *outbox) Enqueue(ctx context.Context, destination, key string, headers map[string]string, messages ...*messaging.Message) error {
+	tx, ok := database.FromContext(ctx)
+	if !ok {
+		return ErrNoTransaction
+	}
+	if destination == "" {
+		return ErrEmptyDestination
+	}
+
+	encodedHeaders, err := encodeHeaders(headers)
+	if err != nil {
+		return err
+	}
+
+	createdAt := o.now().UTC()
+	for _, msg := range messages {
+		if msg == nil {
+			return ErrNilMessage
+		}
+		encodedMsgHeaders, err := encodeMessageHeaders(msg.Headers)
+		if err != nil {
+			return err
+		}
+		body := msg.Body
+		if body == nil {
+			body = []byte{}
+		}
+		if _, err := tx.ExecContext(ctx, o.queries.insert, destination, key, encodedHeaders, encodedMsgHeaders, body, createdAt); err != nil {
+			return fmt.Errorf("outbox: enqueue: %w", err)
+		}
+	}
+
+	o.enqueued.Add(ctx, int64(len(messages)),
+		observability.String("db.system", string(o.driver)),
+		observability.String("destination", destination),
+	)
+	return nil
+}
+
+func encodeHeaders(headers map[string]string) (string, error) {
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	raw, err := json.Marshal(headers)
+	if err != nil {
+		return "", fmt.Errorf("outbox: encode headers: %w", err)
+	}
+	return string(raw), nil
+}
+
+func encodeMessageHeaders(headers []messaging.Header) (string, error) {
+	if headers == nil {
+		headers = []messaging.Header{}
+	}
+	raw, err := json.Marshal(headers)
+	if err != nil {
+		return "", fmt.Errorf("outbox: encode message headers: %w", err)
+	}
+	return string(raw), nil
+}
+
+func decodeHeaders(raw string) (map[string]string, error) {
+	headers := map[string]string{}
+	if raw == "" {
+		return headers, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &headers); err != nil {
+		return nil, fmt.Errorf("outbox: decode headers: %w", err)
+	}
+	return headers, nil
+}
+
+func decodeMessageHeaders(raw string) ([]messaging.Header, error) {
+	var headers []messaging.Header
+	if raw == "" {
+		return headers, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &headers); err != nil {
+		return nil, fmt.Errorf("outbox: decode message headers: %w", err)
+	}
+	return headers, nil
+}
@@ -0,0 +1,80 @@
+package outbox_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/outbox"
+	"github.com/JailtonJunior94/devkit-go/pkg/messaging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnqueue_WithoutTransaction_ReturnsErrNoTransaction(t *testing.T) {
+	ob, err := outbox.New(&fakeManager{driver: database.DriverPostgres})
+	require.NoError(t, err)
+
+	err = ob.Enqueue(context.Background(), "orders", "k", nil, &messaging.Message{Body: []byte("x")})
+
+	require.ErrorIs(t, err, outbox.ErrNoTransaction)
+}
+
+func TestEnqueue_WritesThroughTransactionInContext(t *testing.T) {
+	tx := &fakeTx{}
+	ob, err := outbox.New(&fakeManager{driver: database.DriverPostgres})
+	require.NoError(t, err)
+
+	ctx := database.WithTx(context.Background(), tx)
+	err = ob.Enqueue(ctx, "orders", "order-1", map[string]string{"type": "created"},
+		&messaging.Message{Body: []byte(`{"id":1}`), Headers: []messaging.Header{{Key: "h", Value: []byte("v")}}},
+		&messaging.Message{Body: []byte(`{"id":2}`)},
+	)
+
+	require.NoError(t, err)
+	require.Len(t, tx.execs, 2)
+	require.True(t, strings.HasPrefix(tx.execs[0].query, "INSERT INTO outbox_messages"))
+	require.Contains(t, tx.execs[0].query, "$6")
+	require.Equal(t, "orders", tx.execs[0].args[0])
+	require.Equal(t, "order-1", tx.execs[0].args[1])
+	require.JSONEq(t, `{"type":"created"}`, tx.execs[0].args[2].(string))
+	require.Equal(t, []byte(`{"id":1}`), tx.execs[0].args[4])
+}
+
+func TestEnqueue_RejectsNilMessageAndEmptyDestination(t *testing.T) {
+	ob, err := outbox.New(&fakeManager{driver: database.DriverMySQL})
+	require.NoError(t, err)
+	ctx := database.WithTx(context.Background(), &fakeTx{})
+
+	require.ErrorIs(t, ob.Enqueue(ctx, "", "k", nil, &messaging.Message{}), outbox.ErrEmptyDestination)
+	require.ErrorIs(t, ob.Enqueue(ctx, "orders", "k", nil, nil), outbox.ErrNilMessage)
+}
+
+func TestNew_InvalidTableOrDriver_ReturnsInvalidConfig(t *testing.T) {
+	_, err := outbox.New(&fakeManager{driver: database.DriverPostgres}, outbox.WithTable("x; DROP TABLE y"))
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+
+	_, err = outbox.New(&fakeManager{driver: database.Driver("oracle")})
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+}
+
+func TestSchema_PerDriver(t *testing.T) {
+	cases := []struct {
+		driver database.Driver
+		want   string
+	}{
+		{database.DriverPostgres, "BIGSERIAL"},
+		{database.DriverCockroach, "BIGSERIAL"},
+		{database.DriverMySQL, "AUTO_INCREMENT"},
+		{database.DriverMSSQL, "IDENTITY(1,1)"},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.driver), func(t *testing.T) {
+			ddl, err := outbox.Schema(tc.driver, "app.events_outbox")
+			require.NoError(t, err)
+			require.Contains(t, ddl, tc.want)
+			require.Contains(t, ddl, "app.events_outbox")
+		})
+	}
+}
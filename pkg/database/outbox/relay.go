@@ -0,0 +1,252 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/claim"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/manager"
+	"github.com/JailtonJunior94/devkit-go/pkg/messaging"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+)
+
+type Relay struct {
+	mgr       manager.Manager
+	pub       messaging.Publisher
+	opts      options
+	driver    database.Driver
+	queries   queries
+	obs       observability.Observability
+	published observability.Counter
+	failed    observability.Counter
+	lag       observability.Histogram
+	now       func() time.Time
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type record struct {
+	id          int64
+	destination string
+	key         string
+	headers     map[string]string
+	message     *messaging.Message
+	createdAt   time.Time
+	decodeErr   error
+}
+
+func NewRelay(mgr manager.Manager, pub messaging.Publisher, opts ...Option) (*Relay, error) {
+	if pub == nil {
+		return nil, ErrNilPublisher
+	}
+
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	driver := mgr.Driver()
+	q, err := buildQueries(driver, o.Table)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := o.Observability.Metrics()
+	return &Relay{
+		mgr:       mgr,
+		pub:       pub,
+		opts:      o,
+		driver:    driver,
+		queries:   q,
+		obs:       o.Observability,
+		published: metrics.Counter("database.outbox.published", "Outbox messages published", "{messages}"),
+		failed:    metrics.Counter("database.outbox.failed", "Outbox messages that failed to publish", "{messages}"),
+		lag: metrics.HistogramWithBuckets(
+			"database.outbox.lag_ms",
+			"Time between enqueue and publish",
+			"ms",
+			[]float64{10, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000},
+		),
+		now: time.Now,
+	}, nil
+}
+
+func (r *Relay) Name() string { return r.opts.name }
+
+func (r *Relay) Start(ctx context.Context) error {
+	r.mu.Lock()
+	if r.done != nil {
+		r.mu.Unlock()
+		return ErrRelayRunning
+	}
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	r.cancel = cancel
+	r.done = done
+	r.mu.Unlock()
+
+	defer func() {
+		cancel()
+		close(done)
+		r.mu.Lock()
+		r.cancel = nil
+		r.done = nil
+		r.mu.Unlock()
+	}()
+
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := r.RelayOnce(runCtx)
+		if err != nil && runCtx.Err() == nil {
+			r.obs.Logger().Error(runCtx, "outbox relay iteration failed",
+				observability.String("operation", "outbox.relay"),
+				observability.String("layer", "database"),
+				observability.String("name", r.opts.name),
+				observability.Error(err),
+			)
+		}
+		if err == nil && n >= r.opts.BatchSize {
+			if runCtx.Err() != nil {
+				return nil
+			}
+			continue
+		}
+
+		select {
+		case <-runCtx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	attrs := []observability.Field{observability.String("db.system", string(r.driver))}
+	ctx, span := r.obs.Tracer().Start(ctx, fmt.Sprintf("db.%s.outbox.relay", r.driver), observability.WithAttributes(attrs...))
+	defer span.End()
+
+	published, err := r.relayBatch(ctx, span)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(observability.StatusCodeError, err.Error())
+		return published, err
+	}
+	span.SetStatus(observability.StatusCodeOK, "ok")
+	return published, nil
+}
+
+func (r *Relay) relayBatch(ctx context.Context, span observability.Span) (int, error) {
+	tx, err := r.mgr.BeginTx(ctx, database.TxOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("outbox: begin tx: %w", err)
+	}
+
+	records, err := r.claim(ctx, tx)
+	if err != nil {
+		return 0, errors.Join(err, claim.Rollback(ctx, tx, "outbox"))
+	}
+	span.SetAttributes(observability.Int("outbox.claimed", len(records)))
+	if len(records) == 0 {
+		return 0, claim.Rollback(ctx, tx, "outbox")
+	}
+
+	published := 0
+	for _, rec := range records {
+		fields := []observability.Field{
+			observability.String("db.system", string(r.driver)),
+			observability.String("destination", rec.destination),
+		}
+
+		pubErr := rec.decodeErr
+		if pubErr == nil {
+			pubErr = r.pub.Publish(ctx, rec.destination, rec.key, rec.headers, rec.message)
+		}
+		if pubErr != nil {
+			r.failed.Increment(ctx, fields...)
+			r.obs.Logger().Warn(ctx, "outbox message publish failed",
+				observability.String("operation", "outbox.relay.publish"),
+				observability.String("layer", "database"),
+				observability.Int64("outbox.id", rec.id),
+				observability.String("destination", rec.destination),
+				observability.Error(pubErr),
+			)
+			if _, err := tx.ExecContext(ctx, r.queries.markFailed, claim.LastError(pubErr), rec.id); err != nil {
+				return published, errors.Join(fmt.Errorf("outbox: mark failed: %w", err), claim.Rollback(ctx, tx, "outbox"))
+			}
+			continue
+		}
+
+		sentAt := r.now().UTC()
+		if _, err := tx.ExecContext(ctx, r.queries.markSent, sentAt, rec.id); err != nil {
+			return published, errors.Join(fmt.Errorf("outbox: mark sent: %w", err), claim.Rollback(ctx, tx, "outbox"))
+		}
+		published++
+		r.published.Increment(ctx, fields...)
+		r.lag.Record(ctx, float64(sentAt.Sub(rec.createdAt).Milliseconds()), fields...)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return published, fmt.Errorf("outbox: commit: %w", err)
+	}
+	span.SetAttributes(observability.Int("outbox.published", published))
+	return published, nil
+}
+
+func (r *Relay) claim(ctx context.Context, tx database.Tx) ([]record, error) {
+	rows, err := tx.QueryContext(ctx, r.queries.claim, r.opts.MaxAttempts, r.opts.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("outbox: claim: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	records := make([]record, 0, r.opts.BatchSize)
+	for rows.Next() {
+		var (
+			rec               record
+			headers, msgHeads string
+			payload           []byte
+			attempts          int
+		)
+		if err := rows.Scan(&rec.id, &rec.destination, &rec.key, &headers, &msgHeads, &payload, &rec.createdAt, &attempts); err != nil {
+			return nil, fmt.Errorf("outbox: scan: %w", err)
+		}
+
+		rec.headers, rec.decodeErr = decodeHeaders(headers)
+		msg := &messaging.Message{Body: payload}
+		if rec.decodeErr == nil {
+			msg.Headers, rec.decodeErr = decodeMessageHeaders(msgHeads)
+		}
+		rec.message = msg
+		records = append(records, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("outbox: claim rows: %w", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,113 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/outbox"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability/fake"
+	"github.com/stretchr/testify/require"
+)
+
+func outboxRow(id int64, destination string, body string) []any {
+	return []any{
+		id, destination, "key", `{"tenant":"acme"}`, `[]`, []byte(body),
+		time.Now().Add(-time.Second).UTC(), 0,
+	}
+}
+
+func TestNewRelay_NilPublisher_ReturnsError(t *testing.T) {
+	_, err := outbox.NewRelay(&fakeManager{driver: database.DriverPostgres}, nil)
+	require.ErrorIs(t, err, outbox.ErrNilPublisher)
+}
+
+func TestRelayOnce_PublishesAndMarksSent(t *testing.T) {
+	tx := &fakeTx{rows: [][]any{outboxRow(1, "orders", "a"), outboxRow(2, "orders", "b")}}
+	pub := &fakePublisher{}
+	obs := fake.NewProvider()
+	relay, err := outbox.NewRelay(&fakeManager{driver: database.DriverPostgres, tx: tx}, pub, outbox.WithObservability(obs))
+	require.NoError(t, err)
+
+	n, err := relay.RelayOnce(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.True(t, tx.committed)
+	require.Len(t, pub.published, 2)
+	require.Equal(t, map[string]string{"tenant": "acme"}, pub.published[0].headers)
+	require.Equal(t, []byte("a"), pub.published[0].message.Body)
+	require.Contains(t, tx.queries[0].query, "FOR UPDATE SKIP LOCKED")
+	require.Len(t, tx.execs, 2)
+	require.True(t, strings.HasPrefix(tx.execs[0].query, "UPDATE outbox_messages SET sent_at"))
+	require.Len(t, obs.Metrics().(*fake.FakeMetrics).GetCounter("database.outbox.published").GetValues(), 2)
+	require.Len(t, obs.Metrics().(*fake.FakeMetrics).GetHistogram("database.outbox.lag_ms").GetValues(), 2)
+}
+
+func TestRelayOnce_PublishFailure_IncrementsAttempts(t *testing.T) {
+	tx := &fakeTx{rows: [][]any{outboxRow(1, "broken", "a"), outboxRow(2, "orders", "b")}}
+	pub := &fakePublisher{failFor: map[string]error{"broken": errors.New("broker down")}}
+	obs := fake.NewProvider()
+	relay, err := outbox.NewRelay(&fakeManager{driver: database.DriverMySQL, tx: tx}, pub, outbox.WithObservability(obs))
+	require.NoError(t, err)
+
+	n, err := relay.RelayOnce(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.True(t, tx.committed)
+	require.Contains(t, tx.execs[0].query, "last_error = ?")
+	require.Equal(t, "broker down", tx.execs[0].args[0])
+	require.Len(t, obs.Metrics().(*fake.FakeMetrics).GetCounter("database.outbox.failed").GetValues(), 1)
+}
+
+func TestRelayOnce_EmptyBatch_RollsBack(t *testing.T) {
+	tx := &fakeTx{}
+	relay, err := outbox.NewRelay(&fakeManager{driver: database.DriverMSSQL, tx: tx}, &fakePublisher{})
+	require.NoError(t, err)
+
+	n, err := relay.RelayOnce(context.Background())
+
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.True(t, tx.rolledBack)
+	require.Contains(t, tx.queries[0].query, "READPAST")
+}
+
+func TestRelayOnce_MarkFailure_RollsBackAndReturnsError(t *testing.T) {
+	execErr := errors.New("exec failed")
+	tx := &fakeTx{rows: [][]any{outboxRow(1, "orders", "a")}, execErr: execErr}
+	relay, err := outbox.NewRelay(&fakeManager{driver: database.DriverPostgres, tx: tx}, &fakePublisher{})
+	require.NoError(t, err)
+
+	_, err = relay.RelayOnce(context.Background())
+
+	require.ErrorIs(t, err, execErr)
+	require.True(t, tx.rolledBack)
+	require.False(t, tx.committed)
+}
+
+func TestRelay_StartStop(t *testing.T) {
+	tx := &fakeTx{}
+	relay, err := outbox.NewRelay(&fakeManager{driver: database.DriverPostgres, tx: tx}, &fakePublisher{},
+		outbox.WithPollInterval(10*time.Millisecond), outbox.WithName("orders-outbox"))
+	require.NoError(t, err)
+	require.Equal(t, "orders-outbox", relay.Name())
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- relay.Start(context.Background()) }()
+
+	require.Eventually(t, func() bool {
+		tx.mu.Lock()
+		defer tx.mu.Unlock()
+		return len(tx.queries) > 0
+	}, time.Second, 5*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, relay.Stop(ctx))
+	require.NoError(t, <-errCh)
+}
@@ -0,0 +1,143 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type cursor struct {
+	backward bool
+	values   []any
+}
+
+type cursorPayload struct {
+	Backward bool        `json:"b,omitempty"`
+	Values   [][2]string `json:"v"`
+}
+
+// encode signs the sort keys too, so a cursor only decodes for the same ordering.
+func (p *Paginator) encode(keys []Key, c cursor) (string, error) {
+	payload := cursorPayload{Backward: c.backward, Values: make([][2]string, len(c.values))}
+	for i, value := range c.values {
+		tagged, err := encodeValue(value)
+		if err != nil {
+			return "", fmt.Errorf("pagination: sort key %q: %w", keys[i].Column, err)
+		}
+		payload.Values[i] = tagged
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("pagination: encode cursor: %w", err)
+	}
+	body := base64.RawURLEncoding.EncodeToString(raw)
+	return body + "." + base64.RawURLEncoding.EncodeToString(p.sign(keys, body)), nil
+}
+
+func (p *Paginator) decode(keys []Key, token string) (cursor, error) {
+	body, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return cursor{}, ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, p.sign(keys, body)) {
+		return cursor{}, ErrInvalidCursor
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil || len(payload.Values) != len(keys) {
+		return cursor{}, ErrInvalidCursor
+	}
+
+	c := cursor{backward: payload.Backward, values: make([]any, len(keys))}
+	for i, tagged := range payload.Values {
+		if c.values[i], err = decodeValue(tagged); err != nil {
+			return cursor{}, ErrInvalidCursor
+		}
+	}
+	return c, nil
+}
+
+func (p *Paginator) sign(keys []Key, body string) []byte {
+	h := hmac.New(sha256.New, p.secret)
+	for _, k := range keys {
+		h.Write([]byte(k.Column))
+		if k.Desc {
+			h.Write([]byte(" desc"))
+		}
+		h.Write([]byte{0})
+	}
+	h.Write([]byte(body))
+	return h.Sum(nil)
+}
+
+func encodeValue(value any) ([2]string, error) {
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return [2]string{}, err
+		}
+		value = v
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return [2]string{}, errors.New("NULL cannot be a sort key")
+	case string:
+		return [2]string{"s", v}, nil
+	case []byte:
+		return [2]string{"x", base64.RawURLEncoding.EncodeToString(v)}, nil
+	case bool:
+		return [2]string{"b", strconv.FormatBool(v)}, nil
+	case time.Time:
+		return [2]string{"t", v.Format(time.RFC3339Nano)}, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return [2]string{"i", strconv.FormatInt(rv.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return [2]string{"u", strconv.FormatUint(rv.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return [2]string{"f", strconv.FormatFloat(rv.Float(), 'g', -1, 64)}, nil
+	case reflect.String:
+		return [2]string{"s", rv.String()}, nil
+	default:
+		return [2]string{}, fmt.Errorf("unsupported cursor value of type %T", value)
+	}
+}
+
+func decodeValue(tagged [2]string) (any, error) {
+	switch tag, raw := tagged[0], tagged[1]; tag {
+	case "s":
+		return raw, nil
+	case "x":
+		return base64.RawURLEncoding.DecodeString(raw)
+	case "b":
+		return strconv.ParseBool(raw)
+	case "t":
+		return time.Parse(time.RFC3339Nano, raw)
+	case "i":
+		return strconv.ParseInt(raw, 10, 64)
+	case "u":
+		return strconv.ParseUint(raw, 10, 64)
+	case "f":
+		return strconv.ParseFloat(raw, 64)
+	default:
+		return nil, fmt.Errorf("unknown cursor value tag %q", tag)
+	}
+}
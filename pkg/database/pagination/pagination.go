@@ -0,0 +1,192 @@
+// Package pagination pages query results by keyset with signed, opaque cursors.
+package pagination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/dialect"
+)
+
+const (
+	defaultLimit = 20
+	defaultMax   = 100
+	minSecretLen = 16
+)
+
+var columnPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ErrInvalidCursor is returned for malformed, tampered or foreign cursors.
+var ErrInvalidCursor = errors.New("pagination: invalid cursor")
+
+// Key is one non-NULL sort column; the last key must be unique.
+type Key struct {
+	Column string
+	Desc   bool
+}
+
+// Query is a SELECT without ORDER BY or LIMIT and the page to fetch from it.
+type Query struct {
+	SQL    string
+	Args   []any
+	Keys   []Key
+	Limit  int
+	Cursor string
+}
+
+// Page holds the items and the cursors of the pages around them, empty when there is none.
+type Page[T any] struct {
+	Items []T
+	Next  string
+	Prev  string
+}
+
+// Paginator builds keyset queries for one driver and signs their cursors.
+type Paginator struct {
+	driver       database.Driver
+	secret       []byte
+	defaultLimit int
+	maxLimit     int
+}
+
+type Option func(*Paginator)
+
+// WithDefaultLimit sets the page size used when Query.Limit is zero.
+func WithDefaultLimit(n int) Option {
+	return func(p *Paginator) {
+		if n > 0 {
+			p.defaultLimit = n
+		}
+	}
+}
+
+// WithMaxLimit caps Query.Limit, which usually comes from the client.
+func WithMaxLimit(n int) Option {
+	return func(p *Paginator) {
+		if n > 0 {
+			p.maxLimit = n
+		}
+	}
+}
+
+// New returns a Paginator for driver signing cursors with a secret of at least 16 bytes.
+func New(driver database.Driver, secret []byte, opts ...Option) (*Paginator, error) {
+	if !dialect.Supported(driver) {
+		return nil, fmt.Errorf("%w: pagination: unsupported driver %q", database.ErrInvalidConfig, driver)
+	}
+	if len(secret) < minSecretLen {
+		return nil, fmt.Errorf("%w: pagination: secret must have at least %d bytes", database.ErrInvalidConfig, minSecretLen)
+	}
+	p := &Paginator{
+		driver:       driver,
+		secret:       slices.Clone(secret),
+		defaultLimit: defaultLimit,
+		maxLimit:     defaultMax,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.defaultLimit = min(p.defaultLimit, p.maxLimit)
+	return p, nil
+}
+
+// Fetch runs the page of q on db and maps its rows like database.QueryAll.
+func Fetch[T any](ctx context.Context, db database.DBTX, p *Paginator, q Query) (Page[T], error) {
+	if err := validateKeys(q.Keys); err != nil {
+		return Page[T]{}, err
+	}
+	limit := p.limit(q.Limit)
+
+	var c cursor
+	if q.Cursor != "" {
+		var err error
+		if c, err = p.decode(q.Keys, q.Cursor); err != nil {
+			return Page[T]{}, err
+		}
+	}
+
+	query, args := p.build(q, c, limit+1)
+	items, err := database.QueryAll[T](ctx, db, query, args...)
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	more := len(items) > limit
+	if more {
+		items = items[:limit]
+	}
+	if c.backward {
+		slices.Reverse(items)
+	}
+	return newPage(p, q, c, items, more)
+}
+
+func newPage[T any](p *Paginator, q Query, c cursor, items []T, more bool) (Page[T], error) {
+	page := Page[T]{Items: items}
+	arrived := q.Cursor != ""
+
+	first, last := c.values, c.values
+	if len(items) > 0 {
+		var err error
+		if first, err = keyValues(q.Keys, items[0]); err != nil {
+			return Page[T]{}, err
+		}
+		if last, err = keyValues(q.Keys, items[len(items)-1]); err != nil {
+			return Page[T]{}, err
+		}
+	} else if !arrived {
+		return page, nil
+	}
+
+	var err error
+	if (more && !c.backward) || (arrived && c.backward) {
+		if page.Next, err = p.encode(q.Keys, cursor{values: last}); err != nil {
+			return Page[T]{}, err
+		}
+	}
+	if (more && c.backward) || (arrived && !c.backward) {
+		if page.Prev, err = p.encode(q.Keys, cursor{backward: true, values: first}); err != nil {
+			return Page[T]{}, err
+		}
+	}
+	return page, nil
+}
+
+func (p *Paginator) limit(n int) int {
+	if n <= 0 {
+		return p.defaultLimit
+	}
+	return min(n, p.maxLimit)
+}
+
+func validateKeys(keys []Key) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: pagination: at least one sort key is required", database.ErrInvalidConfig)
+	}
+	for _, k := range keys {
+		if !columnPattern.MatchString(k.Column) {
+			return fmt.Errorf("%w: pagination: invalid sort column %q", database.ErrInvalidConfig, k.Column)
+		}
+	}
+	return nil
+}
+
+func keyValues(keys []Key, item any) ([]any, error) {
+	columns, err := database.ColumnValues(item)
+	if err != nil {
+		return nil, fmt.Errorf("pagination: %w", err)
+	}
+	values := make([]any, len(keys))
+	for i, k := range keys {
+		value, ok := columns[k.Column]
+		if !ok {
+			return nil, fmt.Errorf("%w: pagination: %T has no column %q", database.ErrInvalidConfig, item, k.Column)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,212 @@
+package pagination_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/dbtest"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/pagination"
+)
+
+var secret = []byte("0123456789abcdef")
+
+type order struct {
+	ID        int64
+	CreatedAt time.Time
+}
+
+var (
+	t0   = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	keys = []pagination.Key{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}}
+)
+
+func rowsOf(ids ...int64) [][]any {
+	rows := make([][]any, len(ids))
+	for i, id := range ids {
+		rows[i] = []any{id, t0.Add(time.Duration(id) * time.Minute)}
+	}
+	return rows
+}
+
+func newPaginator(t *testing.T, driver database.Driver) *pagination.Paginator {
+	t.Helper()
+	p, err := pagination.New(driver, secret, pagination.WithDefaultLimit(2))
+	require.NoError(t, err)
+	return p
+}
+
+func lastStatement(t *testing.T, mgr *dbtest.Manager) dbtest.Event {
+	t.Helper()
+	statements := mgr.Statements()
+	require.NotEmpty(t, statements)
+	return statements[len(statements)-1]
+}
+
+func TestFetch_FirstPageFetchesOneExtraRow(t *testing.T) {
+	mgr := dbtest.New()
+	mgr.On(`FROM \(SELECT`).Rows([]string{"id", "created_at"}, rowsOf(5, 4, 3)...)
+	p := newPaginator(t, database.DriverPostgres)
+
+	page, err := pagination.Fetch[order](context.Background(), mgr.DBTX(context.Background()), p, pagination.Query{
+		SQL:  "SELECT id, created_at FROM orders WHERE customer_id = $1",
+		Args: []any{"c-1"},
+		Keys: keys,
+	})
+	require.NoError(t, err)
+
+	stmt := lastStatement(t, mgr)
+	require.Equal(t, "SELECT * FROM (SELECT id, created_at FROM orders WHERE customer_id = $1) AS page "+
+		"ORDER BY created_at DESC, id DESC LIMIT 3", stmt.Query)
+	require.Equal(t, []any{"c-1"}, stmt.Args)
+
+	require.Len(t, page.Items, 2, "a linha extra só indica que há próxima página")
+	require.Equal(t, int64(5), page.Items[0].ID)
+	require.NotEmpty(t, page.Next)
+	require.Empty(t, page.Prev, "a primeira página não tem anterior")
+}
+
+func TestFetch_NextAndPreviousPages(t *testing.T) {
+	mgr := dbtest.New()
+	p := newPaginator(t, database.DriverPostgres)
+	db := mgr.DBTX(context.Background())
+	base := pagination.Query{SQL: "SELECT id, created_at FROM orders WHERE customer_id = $1", Args: []any{"c-1"}, Keys: keys}
+
+	mgr.On(`FROM \(SELECT`).Rows([]string{"id", "created_at"}, rowsOf(5, 4, 3)...).Times(1)
+	first, err := pagination.Fetch[order](context.Background(), db, p, base)
+	require.NoError(t, err)
+
+	mgr.On(`FROM \(SELECT`).Rows([]string{"id", "created_at"}, rowsOf(3, 2)...).Times(1)
+	next := base
+	next.Cursor = first.Next
+	second, err := pagination.Fetch[order](context.Background(), db, p, next)
+	require.NoError(t, err)
+
+	stmt := lastStatement(t, mgr)
+	require.Equal(t, "SELECT * FROM (SELECT id, created_at FROM orders WHERE customer_id = $1) AS page "+
+		"WHERE (created_at, id) < ($2, $3) ORDER BY created_at DESC, id DESC LIMIT 3", stmt.Query)
+	require.Equal(t, []any{"c-1", t0.Add(4 * time.Minute), int64(4)}, stmt.Args, "o cursor preserva o tipo dos valores")
+	require.Equal(t, []int64{3, 2}, []int64{second.Items[0].ID, second.Items[1].ID})
+	require.Empty(t, second.Next, "sem linha extra não há próxima página")
+	require.NotEmpty(t, second.Prev)
+
+	mgr.On(`FROM \(SELECT`).Rows([]string{"id", "created_at"}, rowsOf(4, 5)...).Times(1)
+	prev := base
+	prev.Cursor = second.Prev
+	back, err := pagination.Fetch[order](context.Background(), db, p, prev)
+	require.NoError(t, err)
+
+	stmt = lastStatement(t, mgr)
+	require.Contains(t, stmt.Query, "WHERE (created_at, id) > ($2, $3) ORDER BY created_at ASC, id ASC LIMIT 3")
+	require.Equal(t, int64(3), stmt.Args[2])
+	require.Equal(t, []int64{5, 4}, []int64{back.Items[0].ID, back.Items[1].ID}, "a página anterior volta na ordem original")
+	require.NotEmpty(t, back.Next)
+	require.Empty(t, back.Prev, "sem linha extra não há página antes desta")
+}
+
+func TestFetch_MSSQLExpandsPredicate(t *testing.T) {
+	mgr := dbtest.New(dbtest.WithDriver(database.DriverMSSQL))
+	p := newPaginator(t, database.DriverMSSQL)
+	db := mgr.DBTX(context.Background())
+	q := pagination.Query{
+		SQL:  "SELECT id, created_at FROM orders",
+		Keys: []pagination.Key{{Column: "created_at", Desc: true}, {Column: "id"}},
+	}
+
+	mgr.On(`FROM \(SELECT`).Rows([]string{"id", "created_at"}, rowsOf(1, 2, 3)...).Times(1)
+	first, err := pagination.Fetch[order](context.Background(), db, p, q)
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(lastStatement(t, mgr).Query, "ORDER BY created_at DESC, id ASC OFFSET 0 ROWS FETCH NEXT 3 ROWS ONLY"))
+
+	q.Cursor = first.Next
+	_, err = pagination.Fetch[order](context.Background(), db, p, q)
+	require.NoError(t, err)
+	stmt := lastStatement(t, mgr)
+	require.Contains(t, stmt.Query, "WHERE ((created_at < @p1) OR (created_at = @p2 AND id > @p3))")
+	require.Equal(t, []any{t0.Add(2 * time.Minute), t0.Add(2 * time.Minute), int64(2)}, stmt.Args)
+}
+
+func TestFetch_RejectsForgedCursors(t *testing.T) {
+	mgr := dbtest.New()
+	mgr.On(`FROM \(SELECT`).Rows([]string{"id", "created_at"}, rowsOf(5, 4, 3)...)
+	p := newPaginator(t, database.DriverPostgres)
+	db := mgr.DBTX(context.Background())
+	q := pagination.Query{SQL: "SELECT id, created_at FROM orders", Keys: keys}
+
+	page, err := pagination.Fetch[order](context.Background(), db, p, q)
+	require.NoError(t, err)
+
+	for name, cursor := range map[string]string{
+		"lixo":       "not-a-cursor",
+		"adulterado": "x" + page.Next[1:],
+	} {
+		q.Cursor = cursor
+		_, err = pagination.Fetch[order](context.Background(), db, p, q)
+		require.ErrorIs(t, err, pagination.ErrInvalidCursor, name)
+	}
+
+	q.Cursor = page.Next
+	q.Keys = []pagination.Key{{Column: "created_at"}, {Column: "id"}}
+	_, err = pagination.Fetch[order](context.Background(), db, p, q)
+	require.ErrorIs(t, err, pagination.ErrInvalidCursor, "cursor de outra ordenação é rejeitado")
+
+	other, err := pagination.New(database.DriverPostgres, []byte("another-secret-key"))
+	require.NoError(t, err)
+	q.Keys = keys
+	_, err = pagination.Fetch[order](context.Background(), db, other, q)
+	require.ErrorIs(t, err, pagination.ErrInvalidCursor, "cursor assinado com outro segredo é rejeitado")
+}
+
+func TestFetch_EmptyPageAfterCursorKeepsWayBack(t *testing.T) {
+	mgr := dbtest.New()
+	p := newPaginator(t, database.DriverPostgres)
+	db := mgr.DBTX(context.Background())
+	q := pagination.Query{SQL: "SELECT id, created_at FROM orders", Keys: keys}
+
+	mgr.On(`FROM \(SELECT`).Rows([]string{"id", "created_at"}, rowsOf(5, 4, 3)...).Times(1)
+	first, err := pagination.Fetch[order](context.Background(), db, p, q)
+	require.NoError(t, err)
+
+	mgr.On(`FROM \(SELECT`).Rows([]string{"id", "created_at"}).Times(1)
+	q.Cursor = first.Next
+	empty, err := pagination.Fetch[order](context.Background(), db, p, q)
+	require.NoError(t, err)
+	require.Empty(t, empty.Items)
+	require.Empty(t, empty.Next)
+	require.NotEmpty(t, empty.Prev)
+}
+
+func TestNew_AndQueryValidation(t *testing.T) {
+	_, err := pagination.New(database.DriverPostgres, []byte("short"))
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+	_, err = pagination.New(database.DriverSQLite, secret)
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+
+	p := newPaginator(t, database.DriverPostgres)
+	db := dbtest.New().DBTX(context.Background())
+	_, err = pagination.Fetch[order](context.Background(), db, p, pagination.Query{SQL: "SELECT 1"})
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+	_, err = pagination.Fetch[order](context.Background(), db, p, pagination.Query{
+		SQL:  "SELECT 1",
+		Keys: []pagination.Key{{Column: "id; DROP TABLE orders"}},
+	})
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+}
+
+func TestFetch_LimitIsCapped(t *testing.T) {
+	mgr := dbtest.New()
+	p, err := pagination.New(database.DriverMySQL, secret, pagination.WithMaxLimit(50))
+	require.NoError(t, err)
+
+	_, err = pagination.Fetch[order](context.Background(), mgr.DBTX(context.Background()), p, pagination.Query{
+		SQL:   "SELECT id, created_at FROM orders",
+		Keys:  []pagination.Key{{Column: "id"}},
+		Limit: 1000,
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(lastStatement(t, mgr).Query, "ORDER BY id ASC LIMIT 51"))
+}
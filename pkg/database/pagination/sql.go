@@ -0,0 +1,90 @@
+package pagination
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+func (p *Paginator) build(q Query, c cursor, limit int) (string, []any) {
+	args := append([]any(nil), q.Args...)
+	var b strings.Builder
+	b.WriteString("SELECT * FROM (")
+	b.WriteString(q.SQL)
+	b.WriteString(") AS page")
+
+	if c.values != nil {
+		b.WriteString(" WHERE ")
+		args = p.predicate(&b, q.Keys, c, args)
+	}
+
+	b.WriteString(" ORDER BY ")
+	for i, k := range q.Keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(k.Column)
+		if k.Desc != c.backward {
+			b.WriteString(" DESC")
+		} else {
+			b.WriteString(" ASC")
+		}
+	}
+
+	if p.driver == database.DriverMSSQL {
+		b.WriteString(" OFFSET 0 ROWS FETCH NEXT " + strconv.Itoa(limit) + " ROWS ONLY")
+	} else {
+		b.WriteString(" LIMIT " + strconv.Itoa(limit))
+	}
+	return b.String(), args
+}
+
+// predicate uses a row comparison when every key moves the same way, so the
+// planner can use a composite index; MSSQL lacks them and gets the OR form.
+func (p *Paginator) predicate(b *strings.Builder, keys []Key, c cursor, args []any) []any {
+	placeholder := func(value any) string {
+		args = append(args, value)
+		return p.driver.Placeholder(len(args))
+	}
+	op := func(k Key) string {
+		if k.Desc != c.backward {
+			return " < "
+		}
+		return " > "
+	}
+
+	uniform := true
+	for _, k := range keys[1:] {
+		uniform = uniform && k.Desc == keys[0].Desc
+	}
+	if len(keys) == 1 || (uniform && p.driver != database.DriverMSSQL) {
+		columns := make([]string, len(keys))
+		marks := make([]string, len(keys))
+		for i, k := range keys {
+			columns[i] = k.Column
+			marks[i] = placeholder(c.values[i])
+		}
+		if len(keys) == 1 {
+			b.WriteString(columns[0] + op(keys[0]) + marks[0])
+			return args
+		}
+		b.WriteString("(" + strings.Join(columns, ", ") + ")" + op(keys[0]) + "(" + strings.Join(marks, ", ") + ")")
+		return args
+	}
+
+	b.WriteString("(")
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteString(" OR ")
+		}
+		b.WriteString("(")
+		for j := range i {
+			b.WriteString(keys[j].Column + " = " + placeholder(c.values[j]) + " AND ")
+		}
+		b.WriteString(k.Column + op(k) + placeholder(c.values[i]))
+		b.WriteString(")")
+	}
+	b.WriteString(")")
+	return args
+}
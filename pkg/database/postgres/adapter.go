@@ -48,6 +48,7 @@ func New(cfg PostgresConfig, obs observability.Observability) (*Adapter, error)
 		},
 		PingTimeout:   cfg.PingTimeout,
 		Observability: obs,
+		Credentials:   cfg.Credentials,
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,44 @@
+package postgres_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	_ "github.com/JailtonJunior94/devkit-go/pkg/database/postgres"
+)
+
+func TestClassify_PostgresErrorCodes(t *testing.T) {
+	cases := []struct {
+		code       string
+		constraint string
+		kind       database.ErrorKind
+	}{
+		{"23505", "users_email_key", database.ErrorKindUniqueViolation},
+		{"23503", "orders_user_id_fkey", database.ErrorKindForeignKeyViolation},
+		{"23502", "", database.ErrorKindNotNullViolation},
+		{"23514", "orders_total_check", database.ErrorKindCheckViolation},
+		{"40001", "", database.ErrorKindSerializationFailure},
+		{"40P01", "", database.ErrorKindDeadlock},
+		{"08006", "", database.ErrorKindConnection},
+		{"57P01", "", database.ErrorKindConnection},
+		{"42601", "", database.ErrorKindUnknown},
+	}
+	for _, tc := range cases {
+		err := fmt.Errorf("postgres: exec: %w", &pgconn.PgError{Code: tc.code, ConstraintName: tc.constraint})
+
+		require.Equal(t, tc.kind, database.Classify(err), tc.code)
+		require.Equal(t, tc.constraint, database.ConstraintName(err), tc.code)
+	}
+}
+
+func TestClassify_PostgresHelpers(t *testing.T) {
+	require.True(t, database.IsUniqueViolation(&pgconn.PgError{Code: "23505"}))
+	require.True(t, database.IsSerializationFailure(&pgconn.PgError{Code: "40001"}))
+	require.True(t, database.IsDeadlock(&pgconn.PgError{Code: "40P01"}))
+	require.True(t, database.IsRetryable(&pgconn.PgError{Code: "40P01"}))
+	require.False(t, database.IsRetryable(&pgconn.PgError{Code: "23505"}))
+}
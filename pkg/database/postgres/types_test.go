@@ -10,6 +10,9 @@ import (
 // Compile-time assertion: *postgres.Tx must satisfy database.DBTX.
 var _ database.DBTX = (*postgres.Tx)(nil)
 
+// Compile-time assertion: *postgres.Tx supports bulk copy.
+var _ database.Copier = (*postgres.Tx)(nil)
+
 // TestTx_SatisfiesDBTXInterface passes as long as the file compiles.
 // Behavioural tests for Tx (Exec, Query, Commit, Rollback) are in the
 // integration suite (task 3.0) which requires a real Postgres instance.
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+// ErrColumnsUnavailable is returned by the scanning helpers when the Rows
+// implementation cannot report its column names.
+var ErrColumnsUnavailable = errors.New("database: rows do not expose column names")
+
+type columnLister interface {
+	Columns() ([]string, error)
+}
+
+// QueryIter runs query on db and yields one T per row. Struct types are mapped
+// column by column (see mappingFor); any other type is scanned from a single
+// column. Iteration stops at the first error, which is yielded with a zero T.
+// Rows are closed when the loop ends, including on early break.
+func QueryIter[T any](ctx context.Context, db DBTX, query string, args ...any) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer rows.Close()
+
+		scan, err := rowScanner[T](rows)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		for rows.Next() {
+			item, err := scan()
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// QueryAll collects every row of query into a slice.
+func QueryAll[T any](ctx context.Context, db DBTX, query string, args ...any) ([]T, error) {
+	var items []T
+	for item, err := range QueryIter[T](ctx, db, query, args...) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// QueryOne returns the first row of query, or sql.ErrNoRows when there is none.
+func QueryOne[T any](ctx context.Context, db DBTX, query string, args ...any) (T, error) {
+	for item, err := range QueryIter[T](ctx, db, query, args...) {
+		return item, err
+	}
+	var zero T
+	return zero, sql.ErrNoRows
+}
+
+// ExecNamed executes query after rewriting its `:name` parameters to the
+// placeholder style of driver. arg is a map[string]any or a struct (or pointer
+// to struct) whose fields are named like the scanning helpers expect.
+func ExecNamed(ctx context.Context, db DBTX, driver Driver, query string, arg any) (Result, error) {
+	return Named(db, driver).ExecContext(ctx, query, arg)
+}
+
+func rowScanner[T any](rows Rows) (func() (T, error), error) {
+	t := reflect.TypeFor[T]()
+	if isLeaf(t) {
+		return func() (T, error) {
+			var item T
+			err := rows.Scan(scalarTarget(reflect.ValueOf(&item).Elem()))
+			return item, err
+		}, nil
+	}
+
+	lister, ok := rows.(columnLister)
+	if !ok {
+		return nil, ErrColumnsUnavailable
+	}
+	columns, err := lister.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("database: read columns: %w", err)
+	}
+
+	return func() (T, error) {
+		var item T
+		dest, err := scanTargets(reflect.ValueOf(&item).Elem(), columns)
+		if err != nil {
+			return item, err
+		}
+		return item, rows.Scan(dest...)
+	}, nil
+}
@@ -0,0 +1,259 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/entity"
+	"github.com/JailtonJunior94/devkit-go/pkg/nullable"
+	"github.com/JailtonJunior94/devkit-go/pkg/vos"
+)
+
+// fakeRows devolve linhas em memória e atribui valores como um driver faria.
+type fakeRows struct {
+	columns []string
+	data    [][]any
+	pos     int
+	err     error
+	closed  bool
+}
+
+func (r *fakeRows) Next() bool {
+	if r.pos >= len(r.data) {
+		return false
+	}
+	r.pos++
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	row := r.data[r.pos-1]
+	if len(dest) != len(row) {
+		return errors.New("fakeRows: wrong number of destinations")
+	}
+	for i, d := range dest {
+		if s, ok := d.(sql.Scanner); ok {
+			if err := s.Scan(row[i]); err != nil {
+				return err
+			}
+			continue
+		}
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(row[i]))
+	}
+	return nil
+}
+
+func (r *fakeRows) Close() error               { r.closed = true; return nil }
+func (r *fakeRows) Err() error                 { return r.err }
+func (r *fakeRows) Columns() ([]string, error) { return r.columns, nil }
+
+// queryDBTX registra a última instrução e devolve rows pré-configuradas.
+type queryDBTX struct {
+	rows      *fakeRows
+	queryErr  error
+	lastQuery string
+	lastArgs  []any
+}
+
+func (d *queryDBTX) ExecContext(_ context.Context, query string, args ...any) (database.Result, error) {
+	d.lastQuery, d.lastArgs = query, args
+	return nil, nil
+}
+
+func (d *queryDBTX) QueryContext(_ context.Context, query string, args ...any) (database.Rows, error) {
+	d.lastQuery, d.lastArgs = query, args
+	if d.queryErr != nil {
+		return nil, d.queryErr
+	}
+	return d.rows, nil
+}
+
+func (d *queryDBTX) QueryRowContext(_ context.Context, _ string, _ ...any) database.Row { return nil }
+
+type order struct {
+	entity.Base
+	Customer string `db:"customer_name"`
+	Note     nullable.String
+	Total    int64
+	Internal string `db:"-"`
+}
+
+func TestQueryAll_MapsTaggedEmbeddedAndScannerFields(t *testing.T) {
+	id := uuid.New()
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	db := &queryDBTX{rows: &fakeRows{
+		columns: []string{"id", "created_at", "updated_at", "customer_name", "note", "total"},
+		data: [][]any{
+			{id.String(), created, created, "ana", "fragile", int64(100)},
+			{id.String(), created, nil, "bob", nil, int64(250)},
+		},
+	}}
+
+	orders, err := database.QueryAll[order](context.Background(), db, "SELECT * FROM orders WHERE total > $1", 10)
+
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	require.Equal(t, id, orders[0].ID.Value)
+	require.Equal(t, created, orders[0].CreatedAt)
+	require.True(t, orders[0].UpdatedAt.IsValid())
+	require.False(t, orders[1].UpdatedAt.IsValid())
+	require.Equal(t, "ana", orders[0].Customer)
+	require.Equal(t, nullable.StringOf("fragile"), orders[0].Note)
+	require.True(t, orders[1].Note.IsNull())
+	require.Equal(t, int64(250), orders[1].Total)
+	require.Equal(t, []any{10}, db.lastArgs)
+	require.True(t, db.rows.closed)
+}
+
+func TestQueryAll_ScalarAndValueObjectTypes(t *testing.T) {
+	db := &queryDBTX{rows: &fakeRows{columns: []string{"count"}, data: [][]any{{int64(3)}, {int64(4)}}}}
+	counts, err := database.QueryAll[int64](context.Background(), db, "SELECT count")
+	require.NoError(t, err)
+	require.Equal(t, []int64{3, 4}, counts)
+
+	id := uuid.New()
+	db = &queryDBTX{rows: &fakeRows{columns: []string{"id"}, data: [][]any{{id.String()}}}}
+	ids, err := database.QueryAll[vos.UUID](context.Background(), db, "SELECT id")
+	require.NoError(t, err)
+	require.Equal(t, id, ids[0].Value)
+}
+
+func TestQueryAll_SnakeCaseFallbackHandlesInitialisms(t *testing.T) {
+	type audit struct {
+		UserID     int64
+		HTTPStatus int64
+	}
+	db := &queryDBTX{rows: &fakeRows{columns: []string{"user_id", "http_status"}, data: [][]any{{int64(1), int64(204)}}}}
+
+	rows, err := database.QueryAll[audit](context.Background(), db, "SELECT user_id, http_status")
+
+	require.NoError(t, err)
+	require.Equal(t, []audit{{UserID: 1, HTTPStatus: 204}}, rows)
+}
+
+func TestQueryAll_UnknownColumnFails(t *testing.T) {
+	db := &queryDBTX{rows: &fakeRows{columns: []string{"missing"}, data: [][]any{{"x"}}}}
+
+	_, err := database.QueryAll[order](context.Background(), db, "SELECT missing")
+
+	require.ErrorContains(t, err, `column "missing"`)
+}
+
+func TestColumnNamesAndPointer(t *testing.T) {
+	columns, err := database.ColumnNames(&order{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "created_at", "updated_at", "deleted_at", "customer_name", "note", "total"}, columns)
+
+	var o order
+	ptr, err := database.ColumnPointer(&o, "customer_name")
+	require.NoError(t, err)
+	*(ptr.(*string)) = "ana"
+	require.Equal(t, "ana", o.Customer)
+
+	ptr, err = database.ColumnPointer(&o, "id")
+	require.NoError(t, err)
+	require.IsType(t, &uuid.UUID{}, ptr, "value objects expõem o campo interno")
+
+	_, err = database.ColumnPointer(&o, "internal")
+	require.Error(t, err)
+	_, err = database.ColumnPointer(o, "id")
+	require.Error(t, err, "o destino precisa ser ponteiro")
+	_, err = database.ColumnNames(42)
+	require.Error(t, err)
+}
+
+func TestQueryAll_PropagatesQueryAndRowsErrors(t *testing.T) {
+	queryErr := errors.New("syntax error")
+	_, err := database.QueryAll[int64](context.Background(), &queryDBTX{queryErr: queryErr}, "SELEC")
+	require.ErrorIs(t, err, queryErr)
+
+	rowsErr := errors.New("conn reset")
+	db := &queryDBTX{rows: &fakeRows{columns: []string{"n"}, data: [][]any{{int64(1)}}, err: rowsErr}}
+	_, err = database.QueryAll[int64](context.Background(), db, "SELECT n")
+	require.ErrorIs(t, err, rowsErr)
+}
+
+func TestQueryOne_ReturnsFirstRowOrErrNoRows(t *testing.T) {
+	db := &queryDBTX{rows: &fakeRows{columns: []string{"name"}, data: [][]any{{"a"}, {"b"}}}}
+	name, err := database.QueryOne[string](context.Background(), db, "SELECT name")
+	require.NoError(t, err)
+	require.Equal(t, "a", name)
+	require.True(t, db.rows.closed)
+
+	db = &queryDBTX{rows: &fakeRows{columns: []string{"name"}}}
+	_, err = database.QueryOne[string](context.Background(), db, "SELECT name")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQueryIter_BreakClosesRows(t *testing.T) {
+	db := &queryDBTX{rows: &fakeRows{columns: []string{"n"}, data: [][]any{{int64(1)}, {int64(2)}, {int64(3)}}}}
+
+	var seen []int64
+	for n, err := range database.QueryIter[int64](context.Background(), db, "SELECT n") {
+		require.NoError(t, err)
+		seen = append(seen, n)
+		if n == 2 {
+			break
+		}
+	}
+
+	require.Equal(t, []int64{1, 2}, seen)
+	require.True(t, db.rows.closed)
+}
+
+func TestQueryAll_RowsWithoutColumns(t *testing.T) {
+	_, err := database.QueryAll[order](context.Background(), &columnlessDBTX{}, "SELECT *")
+	require.ErrorIs(t, err, database.ErrColumnsUnavailable)
+}
+
+// columnlessDBTX devolve Rows que não implementam Columns().
+type columnlessDBTX struct{ queryDBTX }
+
+type columnlessRows struct{ database.Rows }
+
+func (d *columnlessDBTX) QueryContext(_ context.Context, _ string, _ ...any) (database.Rows, error) {
+	return columnlessRows{Rows: &fakeRows{}}, nil
+}
+
+func TestExecNamed_RewritesPlaceholdersPerDriver(t *testing.T) {
+	const query = "UPDATE orders SET note = :note, total = :total WHERE id = :id AND created_at::date = ':id'"
+	id := uuid.New()
+	arg := order{Base: entity.Base{ID: vos.UUID{Value: id}}, Note: nullable.StringOf("x"), Total: 7}
+
+	cases := map[database.Driver]string{
+		database.DriverPostgres: "UPDATE orders SET note = $1, total = $2 WHERE id = $3 AND created_at::date = ':id'",
+		database.DriverMySQL:    "UPDATE orders SET note = ?, total = ? WHERE id = ? AND created_at::date = ':id'",
+		database.DriverMSSQL:    "UPDATE orders SET note = @p1, total = @p2 WHERE id = @p3 AND created_at::date = ':id'",
+	}
+	for driver, want := range cases {
+		db := &queryDBTX{}
+		_, err := database.ExecNamed(context.Background(), db, driver, query, &arg)
+
+		require.NoError(t, err)
+		require.Equal(t, want, db.lastQuery)
+		require.Equal(t, []any{nullable.StringOf("x"), int64(7), id}, db.lastArgs)
+	}
+}
+
+func TestExecNamed_MapArgsAndMissingName(t *testing.T) {
+	db := &queryDBTX{}
+	_, err := database.ExecNamed(context.Background(), db, database.DriverPostgres,
+		"DELETE FROM t WHERE a = :a -- :ignored\n AND b = :b", map[string]any{"a": 1, "b": 2})
+	require.NoError(t, err)
+	require.Equal(t, "DELETE FROM t WHERE a = $1 -- :ignored\n AND b = $2", db.lastQuery)
+	require.Equal(t, []any{1, 2}, db.lastArgs)
+
+	_, err = database.ExecNamed(context.Background(), db, database.DriverPostgres, "SELECT :nope", map[string]any{})
+	require.ErrorContains(t, err, `"nope"`)
+
+	_, err = database.ExecNamed(context.Background(), db, database.DriverPostgres, "SELECT :a", 42)
+	require.Error(t, err)
+}
@@ -0,0 +1,145 @@
+package queue
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/claim"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/dialect"
+)
+
+const selectColumns = "id, event_type, payload, attempts, created_at"
+
+type queries struct {
+	insert  string
+	claim   string
+	lease   string
+	release string
+	ack     string
+	retry   string
+	bury    string
+	depth   string
+}
+
+func buildQueries(driver database.Driver, table, deadTable string) (queries, error) {
+	if err := claim.Validate("queue", driver, table, deadTable); err != nil {
+		return queries{}, err
+	}
+
+	p := func(n int) string { return dialect.Placeholder(driver, n) }
+
+	q := queries{
+		insert: fmt.Sprintf(
+			"INSERT INTO %s (event_type, payload, run_at, created_at) VALUES (%s, %s, %s, %s)",
+			table, p(1), p(2), p(3), p(4),
+		),
+		lease: fmt.Sprintf("UPDATE %s SET attempts = attempts + 1, locked_until = %s WHERE id = %s", table, p(1), p(2)),
+		release: fmt.Sprintf(
+			"UPDATE %s SET attempts = attempts - 1, locked_until = NULL WHERE id = %s AND attempts = %s",
+			table, p(1), p(2),
+		),
+		ack: fmt.Sprintf("DELETE FROM %s WHERE id = %s AND attempts = %s", table, p(1), p(2)),
+		retry: fmt.Sprintf(
+			"UPDATE %s SET run_at = %s, locked_until = NULL, last_error = %s WHERE id = %s AND attempts = %s",
+			table, p(1), p(2), p(3), p(4),
+		),
+		bury: fmt.Sprintf(
+			"INSERT INTO %s (id, event_type, payload, attempts, last_error, created_at, failed_at) "+
+				"SELECT id, event_type, payload, attempts, %s, created_at, %s FROM %s WHERE id = %s AND attempts = %s",
+			deadTable, p(1), p(2), table, p(3), p(4),
+		),
+		depth: fmt.Sprintf(
+			"SELECT COUNT(*) FROM %s WHERE run_at <= %s AND (locked_until IS NULL OR locked_until <= %s)",
+			table, p(1), p(2),
+		),
+	}
+
+	q.claim = claim.Query(driver, selectColumns, table,
+		"run_at <= "+p(1)+" AND (locked_until IS NULL OR locked_until <= "+p(2)+")", "run_at, id", p(3))
+	return q, nil
+}
+
+// Schema returns the DDL of the queue table and its dead table. Empty names
+// take the defaults.
+func Schema(driver database.Driver, table, deadTable string) (string, error) {
+	if table == "" {
+		table = DefaultTable
+	}
+	if deadTable == "" {
+		deadTable = table + deadTableSuffix
+	}
+	if err := claim.Validate("queue", driver, table, deadTable); err != nil {
+		return "", err
+	}
+
+	index := table[strings.LastIndex(table, ".")+1:] + "_run_at_idx"
+
+	switch driver {
+	case database.DriverPostgres, database.DriverCockroach:
+		return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+	id BIGSERIAL PRIMARY KEY,
+	event_type VARCHAR(255) NOT NULL,
+	payload BYTEA NOT NULL,
+	run_at TIMESTAMPTZ NOT NULL,
+	locked_until TIMESTAMPTZ NULL,
+	attempts INT NOT NULL DEFAULT 0,
+	last_error TEXT NULL,
+	created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS %[3]s ON %[1]s (run_at, id);
+CREATE TABLE IF NOT EXISTS %[2]s (
+	id BIGINT PRIMARY KEY,
+	event_type VARCHAR(255) NOT NULL,
+	payload BYTEA NOT NULL,
+	attempts INT NOT NULL,
+	last_error TEXT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	failed_at TIMESTAMPTZ NOT NULL
+);`, table, deadTable, index), nil
+	case database.DriverMySQL:
+		return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+	id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+	event_type VARCHAR(255) NOT NULL,
+	payload LONGBLOB NOT NULL,
+	run_at DATETIME(6) NOT NULL,
+	locked_until DATETIME(6) NULL,
+	attempts INT NOT NULL DEFAULT 0,
+	last_error TEXT NULL,
+	created_at DATETIME(6) NOT NULL,
+	KEY %[3]s (run_at, id)
+);
+CREATE TABLE IF NOT EXISTS %[2]s (
+	id BIGINT NOT NULL PRIMARY KEY,
+	event_type VARCHAR(255) NOT NULL,
+	payload LONGBLOB NOT NULL,
+	attempts INT NOT NULL,
+	last_error TEXT NULL,
+	created_at DATETIME(6) NOT NULL,
+	failed_at DATETIME(6) NOT NULL
+);`, table, deadTable, index), nil
+	default:
+		return fmt.Sprintf(`IF OBJECT_ID(N'%[1]s', N'U') IS NULL
+CREATE TABLE %[1]s (
+	id BIGINT IDENTITY(1,1) PRIMARY KEY,
+	event_type NVARCHAR(255) NOT NULL,
+	payload VARBINARY(MAX) NOT NULL,
+	run_at DATETIME2 NOT NULL,
+	locked_until DATETIME2 NULL,
+	attempts INT NOT NULL DEFAULT 0,
+	last_error NVARCHAR(MAX) NULL,
+	created_at DATETIME2 NOT NULL,
+	INDEX %[3]s (run_at, id)
+);
+IF OBJECT_ID(N'%[2]s', N'U') IS NULL
+CREATE TABLE %[2]s (
+	id BIGINT PRIMARY KEY,
+	event_type NVARCHAR(255) NOT NULL,
+	payload VARBINARY(MAX) NOT NULL,
+	attempts INT NOT NULL,
+	last_error NVARCHAR(MAX) NULL,
+	created_at DATETIME2 NOT NULL,
+	failed_at DATETIME2 NOT NULL
+);`, table, deadTable, index), nil
+	}
+}
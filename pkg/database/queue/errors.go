@@ -0,0 +1,15 @@
+package queue
+
+import "errors"
+
+var (
+	ErrSourceRunning  = errors.New("queue: source already running")
+	ErrEmptyEventType = errors.New("queue: event type is required")
+	ErrNotQueueJob    = errors.New("queue: message was not claimed from the queue")
+	// ErrJobReclaimed is returned by Ack when the visibility timeout expired
+	// and another claim took the job over.
+	ErrJobReclaimed = errors.New("queue: job reclaimed after its visibility timeout")
+	// ErrAttemptsExhausted is recorded as the last error of a job whose last
+	// attempt was never acknowledged.
+	ErrAttemptsExhausted = errors.New("queue: last attempt expired without acknowledgement")
+)
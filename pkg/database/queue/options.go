@@ -0,0 +1,109 @@
+package queue
+
+import (
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/claim"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability/noop"
+)
+
+const (
+	DefaultTable             = "queue_jobs"
+	DefaultBatchSize         = 10
+	DefaultPollInterval      = time.Second
+	DefaultVisibilityTimeout = 5 * time.Minute
+	DefaultMaxAttempts       = 10
+	DefaultRetryBaseDelay    = time.Second
+	DefaultRetryMaxDelay     = 5 * time.Minute
+	deadTableSuffix          = "_dead"
+)
+
+type Option func(*options)
+
+type options struct {
+	claim.Options
+	deadTable         string
+	visibilityTimeout time.Duration
+	retryBaseDelay    time.Duration
+	retryMaxDelay     time.Duration
+}
+
+func defaultOptions() options {
+	return options{
+		Options: claim.Options{
+			Table:         DefaultTable,
+			BatchSize:     DefaultBatchSize,
+			PollInterval:  DefaultPollInterval,
+			MaxAttempts:   DefaultMaxAttempts,
+			Observability: noop.NewProvider(),
+		},
+		visibilityTimeout: DefaultVisibilityTimeout,
+		retryBaseDelay:    DefaultRetryBaseDelay,
+		retryMaxDelay:     DefaultRetryMaxDelay,
+	}
+}
+
+func resolveOptions(opts []Option) options {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.deadTable == "" {
+		o.deadTable = o.Table + deadTableSuffix
+	}
+	return o
+}
+
+func WithTable(table string) Option {
+	return func(o *options) { o.SetTable(table) }
+}
+
+// WithDeadTable sets the table that receives jobs out of attempts. It
+// defaults to the queue table name followed by "_dead".
+func WithDeadTable(table string) Option {
+	return func(o *options) {
+		if table != "" {
+			o.deadTable = table
+		}
+	}
+}
+
+func WithBatchSize(n int) Option {
+	return func(o *options) { o.SetBatchSize(n) }
+}
+
+func WithPollInterval(d time.Duration) Option {
+	return func(o *options) { o.SetPollInterval(d) }
+}
+
+// WithVisibilityTimeout sets how long a claimed job stays hidden from other
+// claims. A job not acknowledged in time is claimed again.
+func WithVisibilityTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.visibilityTimeout = d
+		}
+	}
+}
+
+func WithMaxAttempts(n int) Option {
+	return func(o *options) { o.SetMaxAttempts(n) }
+}
+
+// WithRetryBackoff sets the delay before a failed job runs again: base
+// doubled after every attempt, capped at maxDelay.
+func WithRetryBackoff(base, maxDelay time.Duration) Option {
+	return func(o *options) {
+		if base > 0 {
+			o.retryBaseDelay = base
+		}
+		if maxDelay > 0 {
+			o.retryMaxDelay = maxDelay
+		}
+	}
+}
+
+func WithObservability(obs observability.Observability) Option {
+	return func(o *options) { o.SetObservability(obs) }
+}
@@ -0,0 +1,66 @@
+// Package queue is a durable job queue stored in a database table. Jobs are
+// enqueued with Queue, usually inside uow.Do so they commit with the rest of
+// the transaction, and consumed through Source, a consumer.Source for
+// pkg/worker that claims them with FOR UPDATE SKIP LOCKED (READPAST on
+// SQL Server).
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/manager"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+)
+
+type Queue struct {
+	mgr      manager.Manager
+	driver   database.Driver
+	queries  queries
+	enqueued observability.Counter
+	now      func() time.Time
+}
+
+func New(mgr manager.Manager, opts ...Option) (*Queue, error) {
+	o := resolveOptions(opts)
+
+	driver := mgr.Driver()
+	q, err := buildQueries(driver, o.Table, o.deadTable)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Queue{
+		mgr:      mgr,
+		driver:   driver,
+		queries:  q,
+		enqueued: o.Observability.Metrics().Counter("database.queue.enqueued", "Jobs written to the queue", "{jobs}"),
+		now:      time.Now,
+	}, nil
+}
+
+// Enqueue stores a job that becomes visible at runAt, or immediately when
+// runAt is zero. It runs on the transaction carried by ctx when there is one.
+func (q *Queue) Enqueue(ctx context.Context, eventType string, payload []byte, runAt time.Time) error {
+	if eventType == "" {
+		return ErrEmptyEventType
+	}
+	if payload == nil {
+		payload = []byte{}
+	}
+	now := q.now().UTC()
+	if runAt.IsZero() {
+		runAt = now
+	}
+
+	if _, err := q.mgr.DBTX(ctx).ExecContext(ctx, q.queries.insert, eventType, payload, runAt.UTC(), now); err != nil {
+		return fmt.Errorf("queue: enqueue: %w", err)
+	}
+	q.enqueued.Increment(ctx,
+		observability.String("db.system", string(q.driver)),
+		observability.String("event_type", eventType),
+	)
+	return nil
+}
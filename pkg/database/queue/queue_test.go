@@ -0,0 +1,83 @@
+package queue_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/dbtest"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/queue"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/uow"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability/fake"
+)
+
+func TestNew_RejectsUnsupportedDriverAndInvalidTable(t *testing.T) {
+	_, err := queue.New(dbtest.New(dbtest.WithDriver(database.DriverSQLite)))
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+
+	_, err = queue.New(dbtest.New(), queue.WithTable("jobs; DROP TABLE users"))
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+
+	_, err = queue.NewSource(dbtest.New(), queue.WithDeadTable("dead-jobs"))
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+}
+
+func TestEnqueue_JoinsTransactionOfUnitOfWork(t *testing.T) {
+	mgr := dbtest.New()
+	obs := fake.NewProvider()
+	q, err := queue.New(mgr, queue.WithObservability(obs))
+	require.NoError(t, err)
+
+	runAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	_, err = uow.New[struct{}](mgr).Do(context.Background(), func(ctx context.Context, _ database.DBTX) (struct{}, error) {
+		return struct{}{}, q.Enqueue(ctx, "email.send", []byte(`{"to":"ana"}`), runAt)
+	})
+	require.NoError(t, err)
+
+	mgr.AssertCommittedOnce(t)
+	mgr.AssertExecutedInTx(t, `^INSERT INTO queue_jobs \(event_type, payload, run_at, created_at\) VALUES \(\$1, \$2, \$3, \$4\)$`)
+	args := mgr.Statements()[0].Args
+	require.Equal(t, "email.send", args[0])
+	require.Equal(t, runAt, args[2])
+	require.Len(t, obs.Metrics().(*fake.FakeMetrics).GetCounter("database.queue.enqueued").GetValues(), 1)
+}
+
+func TestEnqueue_ZeroRunAtRunsNowOutsideTransaction(t *testing.T) {
+	mgr := dbtest.New(dbtest.WithDriver(database.DriverMySQL))
+	q, err := queue.New(mgr)
+	require.NoError(t, err)
+
+	before := time.Now().UTC()
+	require.NoError(t, q.Enqueue(context.Background(), "report", nil, time.Time{}))
+
+	mgr.AssertExecutedOutsideTx(t, `VALUES \(\?, \?, \?, \?\)`)
+	args := mgr.Statements()[0].Args
+	require.Equal(t, []byte{}, args[1])
+	require.False(t, args[2].(time.Time).Before(before))
+	require.Equal(t, args[2], args[3], "sem runAt o job fica visível imediatamente")
+
+	require.ErrorIs(t, q.Enqueue(context.Background(), "", nil, time.Time{}), queue.ErrEmptyEventType)
+}
+
+func TestSchema_CreatesQueueAndDeadTables(t *testing.T) {
+	for _, driver := range []database.Driver{database.DriverPostgres, database.DriverCockroach, database.DriverMySQL, database.DriverMSSQL} {
+		t.Run(string(driver), func(t *testing.T) {
+			ddl, err := queue.Schema(driver, "", "")
+			require.NoError(t, err)
+			require.Contains(t, ddl, "queue_jobs (")
+			require.Contains(t, ddl, "queue_jobs_dead (")
+			require.Contains(t, ddl, "locked_until")
+		})
+	}
+
+	ddl, err := queue.Schema(database.DriverPostgres, "jobs.pending", "jobs.failed")
+	require.NoError(t, err)
+	require.Contains(t, ddl, "jobs.failed (")
+	require.Contains(t, ddl, "pending_run_at_idx")
+
+	_, err = queue.Schema(database.DriverSQLite, "", "")
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+}
@@ -0,0 +1,422 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/claim"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/manager"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+	"github.com/JailtonJunior94/devkit-go/pkg/worker/consumer"
+)
+
+// Message params set on every claimed job; Ack needs both.
+const (
+	ParamID      = "queue.id"
+	ParamAttempt = "queue.attempt"
+)
+
+const settleTimeout = 5 * time.Second
+
+// Source claims due jobs in batches and hands them to a consumer.Runner.
+// The runner acknowledges each job through Ack: success deletes it, failure
+// schedules a retry with backoff, and the last attempt moves it to the dead
+// table. A job not acknowledged within the visibility timeout is claimed
+// again, or moved to the dead table when that was its last attempt.
+type Source struct {
+	mgr       manager.Manager
+	opts      options
+	driver    database.Driver
+	queries   queries
+	obs       observability.Observability
+	claimed   observability.Counter
+	completed observability.Counter
+	retried   observability.Counter
+	dead      observability.Counter
+	depth     observability.UpDownCounter
+	lastDepth atomic.Int64
+	now       func() time.Time
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var (
+	_ consumer.Source       = (*Source)(nil)
+	_ consumer.Acknowledger = (*Source)(nil)
+)
+
+type job struct {
+	id        int64
+	eventType string
+	payload   []byte
+	attempt   int
+	createdAt time.Time
+}
+
+func NewSource(mgr manager.Manager, opts ...Option) (*Source, error) {
+	o := resolveOptions(opts)
+
+	driver := mgr.Driver()
+	q, err := buildQueries(driver, o.Table, o.deadTable)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := o.Observability.Metrics()
+	s := &Source{
+		mgr:       mgr,
+		opts:      o,
+		driver:    driver,
+		queries:   q,
+		obs:       o.Observability,
+		claimed:   metrics.Counter("database.queue.claimed", "Jobs claimed from the queue", "{jobs}"),
+		completed: metrics.Counter("database.queue.completed", "Jobs handled successfully", "{jobs}"),
+		retried:   metrics.Counter("database.queue.retried", "Failed jobs scheduled for another attempt", "{jobs}"),
+		dead:      metrics.Counter("database.queue.dead", "Jobs moved to the dead table", "{jobs}"),
+		depth:     metrics.UpDownCounter("database.queue.depth", "Due jobs waiting to be claimed", "{jobs}"),
+		now:       time.Now,
+	}
+	return s, nil
+}
+
+// Messages polls the queue until ctx is done or Stop is called. Jobs claimed
+// but not yet delivered when it stops are released for the next claim.
+func (s *Source) Messages(ctx context.Context) (<-chan consumer.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done != nil {
+		return nil, ErrSourceRunning
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	out := make(chan consumer.Message)
+	done := make(chan struct{})
+	s.cancel = cancel
+	s.done = done
+	go s.run(runCtx, out, done)
+	return out, nil
+}
+
+func (s *Source) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Source) run(ctx context.Context, out chan<- consumer.Message, done chan struct{}) {
+	defer func() {
+		close(out)
+		close(done)
+		s.mu.Lock()
+		s.cancel = nil
+		s.done = nil
+		s.mu.Unlock()
+	}()
+
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		jobs, err := s.claim(ctx)
+		if err != nil && ctx.Err() == nil {
+			s.obs.Logger().Error(ctx, "queue claim failed",
+				observability.String("operation", "queue.claim"),
+				observability.String("layer", "database"),
+				observability.Error(err),
+			)
+		}
+		for i, j := range jobs {
+			select {
+			case out <- j.message():
+			case <-ctx.Done():
+				s.release(ctx, jobs[i:])
+				return
+			}
+		}
+		if err == nil && len(jobs) >= s.opts.BatchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ClaimOnce claims up to the batch size of due jobs, hiding them for the
+// visibility timeout, and returns them as messages.
+func (s *Source) ClaimOnce(ctx context.Context) ([]consumer.Message, error) {
+	jobs, err := s.claim(ctx)
+	msgs := make([]consumer.Message, 0, len(jobs))
+	for _, j := range jobs {
+		msgs = append(msgs, j.message())
+	}
+	return msgs, err
+}
+
+func (s *Source) claim(ctx context.Context) ([]job, error) {
+	attrs := []observability.Field{observability.String("db.system", string(s.driver))}
+	ctx, span := s.obs.Tracer().Start(ctx, fmt.Sprintf("db.%s.queue.claim", s.driver), observability.WithAttributes(attrs...))
+	defer span.End()
+
+	jobs, err := s.claimBatch(ctx)
+	span.SetAttributes(observability.Int("queue.claimed", len(jobs)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(observability.StatusCodeError, err.Error())
+		return nil, err
+	}
+	span.SetStatus(observability.StatusCodeOK, "ok")
+	s.recordDepth(ctx, s.now().UTC())
+	return jobs, nil
+}
+
+func (s *Source) claimBatch(ctx context.Context) ([]job, error) {
+	tx, err := s.mgr.BeginTx(ctx, database.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("queue: begin tx: %w", err)
+	}
+
+	now := s.now().UTC()
+	jobs, err := s.selectDue(ctx, tx, now)
+	if err != nil {
+		return nil, errors.Join(err, claim.Rollback(ctx, tx, "queue"))
+	}
+	if len(jobs) == 0 {
+		return nil, claim.Rollback(ctx, tx, "queue")
+	}
+
+	lockedUntil := now.Add(s.opts.visibilityTimeout)
+	leased := make([]job, 0, len(jobs))
+	var expired []job
+	for _, j := range jobs {
+		if j.attempt > s.opts.MaxAttempts {
+			if err := s.buryExpired(ctx, tx, j, now); err != nil {
+				return nil, errors.Join(err, claim.Rollback(ctx, tx, "queue"))
+			}
+			expired = append(expired, j)
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, s.queries.lease, lockedUntil, j.id); err != nil {
+			return nil, errors.Join(fmt.Errorf("queue: lease: %w", err), claim.Rollback(ctx, tx, "queue"))
+		}
+		leased = append(leased, j)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("queue: commit: %w", err)
+	}
+
+	for _, j := range expired {
+		s.dead.Increment(ctx,
+			observability.String("db.system", string(s.driver)),
+			observability.String("event_type", j.eventType),
+		)
+		s.obs.Logger().Warn(ctx, "queue job moved to dead table",
+			observability.String("operation", "queue.claim"),
+			observability.String("layer", "database"),
+			observability.Int64("queue.id", j.id),
+			observability.Int("queue.attempt", j.attempt-1),
+			observability.Error(ErrAttemptsExhausted),
+		)
+	}
+	for _, j := range leased {
+		s.claimed.Increment(ctx,
+			observability.String("db.system", string(s.driver)),
+			observability.String("event_type", j.eventType),
+		)
+	}
+	return leased, nil
+}
+
+// buryExpired moves a job whose last attempt outlived its visibility timeout
+// to the dead table instead of running it once more.
+func (s *Source) buryExpired(ctx context.Context, tx database.Tx, j job, now time.Time) error {
+	attempts := j.attempt - 1
+	if err := s.settle(ctx, tx, s.queries.bury, ErrAttemptsExhausted.Error(), now, j.id, attempts); err != nil {
+		return fmt.Errorf("queue: bury: %w", err)
+	}
+	if err := s.settle(ctx, tx, s.queries.ack, j.id, attempts); err != nil {
+		return fmt.Errorf("queue: bury: %w", err)
+	}
+	return nil
+}
+
+func (s *Source) selectDue(ctx context.Context, tx database.Tx, now time.Time) ([]job, error) {
+	rows, err := tx.QueryContext(ctx, s.queries.claim, now, now, s.opts.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("queue: claim: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	jobs := make([]job, 0, s.opts.BatchSize)
+	for rows.Next() {
+		var j job
+		if err := rows.Scan(&j.id, &j.eventType, &j.payload, &j.attempt, &j.createdAt); err != nil {
+			return nil, fmt.Errorf("queue: scan: %w", err)
+		}
+		j.attempt++
+		jobs = append(jobs, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("queue: claim rows: %w", err)
+	}
+	return jobs, nil
+}
+
+// recordDepth counts the jobs due and not leased after each claim and moves
+// the depth counter of the table to that count.
+func (s *Source) recordDepth(ctx context.Context, now time.Time) {
+	depth, err := database.QueryOne[int64](ctx, s.mgr.DBTX(ctx), s.queries.depth, now, now)
+	if err != nil {
+		return
+	}
+	prev := s.lastDepth.Swap(depth)
+	s.depth.Add(ctx, depth-prev,
+		observability.String("db.system", string(s.driver)),
+		observability.String("table", s.opts.Table),
+	)
+}
+
+// release hands undelivered jobs back without counting the attempt.
+func (s *Source) release(ctx context.Context, jobs []job) {
+	releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), settleTimeout)
+	defer cancel()
+	for _, j := range jobs {
+		_, _ = s.mgr.DBTX(releaseCtx).ExecContext(releaseCtx, s.queries.release, j.id, j.attempt)
+	}
+}
+
+// Ack settles a job delivered by Messages: nil err deletes it, an error
+// schedules another attempt or, after the last one, moves it to the dead
+// table.
+func (s *Source) Ack(ctx context.Context, msg consumer.Message, handleErr error) error {
+	id, attempt, parseErr := jobRef(msg)
+	if parseErr != nil {
+		return parseErr
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), settleTimeout)
+	defer cancel()
+
+	fields := []observability.Field{
+		observability.String("db.system", string(s.driver)),
+		observability.String("event_type", msg.EventType),
+	}
+	switch {
+	case handleErr == nil:
+		if err := s.settle(ctx, s.mgr.DBTX(ctx), s.queries.ack, id, attempt); err != nil {
+			return fmt.Errorf("queue: ack: %w", err)
+		}
+		s.completed.Increment(ctx, fields...)
+	case attempt >= s.opts.MaxAttempts:
+		if err := s.bury(ctx, id, attempt, handleErr); err != nil {
+			return err
+		}
+		s.dead.Increment(ctx, fields...)
+		s.obs.Logger().Warn(ctx, "queue job moved to dead table",
+			observability.String("operation", "queue.ack"),
+			observability.String("layer", "database"),
+			observability.Int64("queue.id", id),
+			observability.Int("queue.attempt", attempt),
+			observability.Error(handleErr),
+		)
+	default:
+		runAt := s.now().UTC().Add(s.retryDelay(attempt))
+		lastErr := claim.LastError(handleErr)
+		if err := s.settle(ctx, s.mgr.DBTX(ctx), s.queries.retry, runAt, lastErr, id, attempt); err != nil {
+			return fmt.Errorf("queue: retry: %w", err)
+		}
+		s.retried.Increment(ctx, fields...)
+	}
+	return nil
+}
+
+func (s *Source) bury(ctx context.Context, id int64, attempt int, cause error) error {
+	tx, err := s.mgr.BeginTx(ctx, database.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("queue: begin tx: %w", err)
+	}
+	lastErr := claim.LastError(cause)
+	if err := s.settle(ctx, tx, s.queries.bury, lastErr, s.now().UTC(), id, attempt); err != nil {
+		return errors.Join(fmt.Errorf("queue: bury: %w", err), claim.Rollback(ctx, tx, "queue"))
+	}
+	if err := s.settle(ctx, tx, s.queries.ack, id, attempt); err != nil {
+		return errors.Join(fmt.Errorf("queue: bury: %w", err), claim.Rollback(ctx, tx, "queue"))
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("queue: commit: %w", err)
+	}
+	return nil
+}
+
+// settle runs a statement guarded by id and attempt; no row means another
+// claim took the job over.
+func (s *Source) settle(ctx context.Context, db database.DBTX, query string, args ...any) error {
+	res, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrJobReclaimed
+	}
+	return nil
+}
+
+func (s *Source) retryDelay(attempt int) time.Duration {
+	delay := s.opts.retryBaseDelay
+	for i := 1; i < attempt && delay < s.opts.retryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, s.opts.retryMaxDelay)
+}
+
+func (j job) message() consumer.Message {
+	return consumer.Message{
+		EventType: j.eventType,
+		Params: map[string]string{
+			ParamID:      strconv.FormatInt(j.id, 10),
+			ParamAttempt: strconv.Itoa(j.attempt),
+		},
+		Body: j.payload,
+	}
+}
+
+func jobRef(msg consumer.Message) (int64, int, error) {
+	id, err := strconv.ParseInt(msg.Params[ParamID], 10, 64)
+	if err != nil {
+		return 0, 0, ErrNotQueueJob
+	}
+	attempt, err := strconv.Atoi(msg.Params[ParamAttempt])
+	if err != nil {
+		return 0, 0, ErrNotQueueJob
+	}
+	return id, attempt, nil
+}
@@ -0,0 +1,231 @@
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/dbtest"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/queue"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability/fake"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability/noop"
+	"github.com/JailtonJunior94/devkit-go/pkg/worker/consumer"
+)
+
+var jobColumns = []string{"id", "event_type", "payload", "attempts", "created_at"}
+
+func jobRow(id int64, eventType string, attempts int) []any {
+	return []any{id, eventType, []byte(`{}`), attempts, time.Now().Add(-time.Minute).UTC()}
+}
+
+func queueMessage(id, attempt string) consumer.Message {
+	return consumer.Message{
+		EventType: "email.send",
+		Params:    map[string]string{queue.ParamID: id, queue.ParamAttempt: attempt},
+	}
+}
+
+func TestClaimOnce_LeasesDueJobsWithSkipLocked(t *testing.T) {
+	mgr := dbtest.New()
+	mgr.On(`^SELECT id, event_type`).Rows(jobColumns, jobRow(7, "email.send", 0), jobRow(8, "report", 2)).Times(1)
+	mgr.On(`^SELECT COUNT`).Rows([]string{"count"}, []any{int64(5)}).Times(1)
+	obs := fake.NewProvider()
+	src, err := queue.NewSource(mgr, queue.WithObservability(obs), queue.WithVisibilityTimeout(time.Minute))
+	require.NoError(t, err)
+
+	msgs, err := src.ClaimOnce(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, msgs, 2)
+	require.Equal(t, "email.send", msgs[0].EventType)
+	require.Equal(t, "7", msgs[0].Params[queue.ParamID])
+	require.Equal(t, "1", msgs[0].Params[queue.ParamAttempt])
+	require.Equal(t, "3", msgs[1].Params[queue.ParamAttempt])
+
+	statements := mgr.Statements()
+	require.Contains(t, statements[0].Query, "FOR UPDATE SKIP LOCKED")
+	require.Equal(t, 10, statements[0].Args[2])
+	mgr.AssertExecutedInTx(t, `^UPDATE queue_jobs SET attempts = attempts \+ 1, locked_until = \$1 WHERE id = \$2$`)
+	lockedUntil := statements[1].Args[0].(time.Time)
+	require.WithinDuration(t, time.Now().Add(time.Minute), lockedUntil, 5*time.Second)
+	mgr.AssertCommittedOnce(t)
+
+	metrics := obs.Metrics().(*fake.FakeMetrics)
+	require.Len(t, metrics.GetCounter("database.queue.claimed").GetValues(), 2)
+	mgr.AssertExecutedOutsideTx(t, `^SELECT COUNT\(\*\) FROM queue_jobs WHERE run_at <= \$1 AND \(locked_until IS NULL OR locked_until <= \$2\)$`)
+	depth := metrics.GetUpDownCounter("database.queue.depth").GetValues()
+	require.Len(t, depth, 1)
+	require.Equal(t, int64(5), depth[0].Value, "a profundidade parte de zero até os jobs devidos e livres")
+	require.Contains(t, depth[0].Fields, observability.String("table", "queue_jobs"))
+
+	// Uma nova contagem move o contador só pela diferença.
+	mgr.On(`^SELECT id, event_type`).Rows(jobColumns)
+	mgr.On(`^SELECT COUNT`).Rows([]string{"count"}, []any{int64(2)})
+	_, err = src.ClaimOnce(context.Background())
+	require.NoError(t, err)
+	depth = metrics.GetUpDownCounter("database.queue.depth").GetValues()
+	require.Equal(t, int64(-3), depth[len(depth)-1].Value)
+
+	spans := obs.Tracer().(*fake.FakeTracer).GetSpans()
+	require.Equal(t, "db.postgres.queue.claim", spans[0].Name)
+}
+
+func TestClaimOnce_BuriesJobsWhoseLastAttemptExpired(t *testing.T) {
+	mgr := dbtest.New()
+	mgr.On(`^SELECT id, event_type`).Rows(jobColumns, jobRow(7, "email.send", 3), jobRow(8, "report", 1))
+	mgr.On(`^INSERT INTO queue_jobs_dead`).RowsAffected(1)
+	mgr.On(`^DELETE FROM queue_jobs`).RowsAffected(1)
+	obs := fake.NewProvider()
+	src, err := queue.NewSource(mgr, queue.WithObservability(obs), queue.WithMaxAttempts(3))
+	require.NoError(t, err)
+
+	msgs, err := src.ClaimOnce(context.Background())
+	require.NoError(t, err)
+	require.Len(t, msgs, 1, "o job que esgotou as tentativas não é entregue de novo")
+	require.Equal(t, "8", msgs[0].Params[queue.ParamID])
+
+	var buried, leased []any
+	for _, e := range mgr.Statements() {
+		switch {
+		case strings.HasPrefix(e.Query, "INSERT INTO queue_jobs_dead"):
+			buried = e.Args
+		case strings.HasPrefix(e.Query, "UPDATE queue_jobs SET attempts = attempts + 1"):
+			leased = append(leased, e.Args[1])
+		}
+	}
+	require.Equal(t, queue.ErrAttemptsExhausted.Error(), buried[0])
+	require.Equal(t, []any{int64(7), 3}, buried[2:], "a tentativa gravada protege contra outro claim")
+	require.Equal(t, []any{int64(8)}, leased)
+	mgr.AssertExecutedInTx(t, `^DELETE FROM queue_jobs WHERE id = \$1 AND attempts = \$2$`)
+	mgr.AssertCommittedOnce(t)
+	require.Len(t, obs.Metrics().(*fake.FakeMetrics).GetCounter("database.queue.dead").GetValues(), 1)
+}
+
+func TestClaimOnce_EmptyQueueRollsBackAndUsesReadpastOnMSSQL(t *testing.T) {
+	mgr := dbtest.New(dbtest.WithDriver(database.DriverMSSQL))
+	src, err := queue.NewSource(mgr)
+	require.NoError(t, err)
+
+	msgs, err := src.ClaimOnce(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, msgs)
+	mgr.AssertRolledBack(t)
+	require.Contains(t, mgr.Statements()[0].Query, "WITH (UPDLOCK, READPAST, ROWLOCK)")
+}
+
+func TestAck_SuccessDeletesJob(t *testing.T) {
+	mgr := dbtest.New()
+	mgr.On(`^DELETE FROM queue_jobs`).RowsAffected(1)
+	obs := fake.NewProvider()
+	src, err := queue.NewSource(mgr, queue.WithObservability(obs))
+	require.NoError(t, err)
+
+	require.NoError(t, src.Ack(context.Background(), queueMessage("7", "2"), nil))
+
+	mgr.AssertExecuted(t, `^DELETE FROM queue_jobs WHERE id = \$1 AND attempts = \$2$`)
+	require.Equal(t, []any{int64(7), 2}, mgr.Statements()[0].Args)
+	require.Len(t, obs.Metrics().(*fake.FakeMetrics).GetCounter("database.queue.completed").GetValues(), 1)
+}
+
+func TestAck_FailureSchedulesRetryWithBackoff(t *testing.T) {
+	mgr := dbtest.New()
+	mgr.On(`^UPDATE queue_jobs SET run_at`).RowsAffected(1)
+	obs := fake.NewProvider()
+	src, err := queue.NewSource(mgr, queue.WithObservability(obs), queue.WithRetryBackoff(time.Second, time.Minute))
+	require.NoError(t, err)
+
+	require.NoError(t, src.Ack(context.Background(), queueMessage("7", "3"), errors.New("smtp down")))
+
+	args := mgr.Statements()[0].Args
+	require.WithinDuration(t, time.Now().Add(4*time.Second), args[0].(time.Time), time.Second, "1s dobrado a cada tentativa")
+	require.Equal(t, "smtp down", args[1])
+	require.Equal(t, []any{int64(7), 3}, args[2:])
+	require.Len(t, obs.Metrics().(*fake.FakeMetrics).GetCounter("database.queue.retried").GetValues(), 1)
+}
+
+func TestAck_RetryBackoffIsCapped(t *testing.T) {
+	mgr := dbtest.New()
+	mgr.On(`^UPDATE`).RowsAffected(1)
+	src, err := queue.NewSource(mgr, queue.WithMaxAttempts(100), queue.WithRetryBackoff(time.Second, 10*time.Second))
+	require.NoError(t, err)
+
+	require.NoError(t, src.Ack(context.Background(), queueMessage("7", "60"), errors.New("boom")))
+	require.WithinDuration(t, time.Now().Add(10*time.Second), mgr.Statements()[0].Args[0].(time.Time), time.Second)
+}
+
+func TestAck_LastAttemptMovesJobToDeadTable(t *testing.T) {
+	mgr := dbtest.New()
+	mgr.On(`^INSERT INTO queue_jobs_dead`).RowsAffected(1)
+	mgr.On(`^DELETE FROM queue_jobs`).RowsAffected(1)
+	obs := fake.NewProvider()
+	src, err := queue.NewSource(mgr, queue.WithObservability(obs), queue.WithMaxAttempts(3))
+	require.NoError(t, err)
+
+	require.NoError(t, src.Ack(context.Background(), queueMessage("7", "3"), errors.New("invalid payload")))
+
+	mgr.AssertExecutedInTx(t, `^INSERT INTO queue_jobs_dead .* SELECT .* FROM queue_jobs WHERE id = \$3 AND attempts = \$4$`)
+	mgr.AssertExecutedInTx(t, `^DELETE FROM queue_jobs WHERE`)
+	mgr.AssertCommittedOnce(t)
+	require.Equal(t, "invalid payload", mgr.Statements()[0].Args[0])
+	require.Len(t, obs.Metrics().(*fake.FakeMetrics).GetCounter("database.queue.dead").GetValues(), 1)
+}
+
+func TestAck_ReclaimedJobAndForeignMessage(t *testing.T) {
+	mgr := dbtest.New()
+	src, err := queue.NewSource(mgr)
+	require.NoError(t, err)
+
+	err = src.Ack(context.Background(), queueMessage("7", "1"), nil)
+	require.ErrorIs(t, err, queue.ErrJobReclaimed, "nenhuma linha afetada: outro claim assumiu o job")
+
+	err = src.Ack(context.Background(), consumer.Message{EventType: "x"}, nil)
+	require.ErrorIs(t, err, queue.ErrNotQueueJob)
+}
+
+func TestSource_RunsJobsThroughRunner(t *testing.T) {
+	mgr := dbtest.New()
+	mgr.On(`^SELECT id, event_type`).Rows(jobColumns, jobRow(1, "email.send", 0)).Times(1)
+	mgr.On(`^DELETE FROM queue_jobs`).RowsAffected(1)
+	src, err := queue.NewSource(mgr, queue.WithPollInterval(time.Millisecond))
+	require.NoError(t, err)
+
+	handled := make(chan consumer.Message, 1)
+	runner, err := consumer.NewRunner("jobs", src, []consumer.Registration{{
+		EventType: "email.send",
+		Handler: consumer.HandlerFunc(func(_ context.Context, msg consumer.Message) error {
+			handled <- msg
+			return nil
+		}),
+	}}, noop.NewProvider())
+	require.NoError(t, err)
+
+	started := make(chan error, 1)
+	go func() { started <- runner.Start(context.Background()) }()
+
+	select {
+	case msg := <-handled:
+		require.Equal(t, "1", msg.Params[queue.ParamID])
+	case <-time.After(time.Second):
+		t.Fatal("o job deveria ter sido entregue ao handler")
+	}
+	require.Eventually(t, func() bool {
+		for _, e := range mgr.Statements() {
+			if e.Kind == dbtest.EventExec && e.Query[:6] == "DELETE" {
+				return true
+			}
+		}
+		return false
+	}, time.Second, time.Millisecond, "o runner deve confirmar o job")
+
+	_, err = src.Messages(context.Background())
+	require.ErrorIs(t, err, queue.ErrSourceRunning)
+
+	require.NoError(t, runner.Stop(context.Background()))
+	require.NoError(t, <-started)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotFound is returned when no live (not soft-deleted) row has the id.
+	ErrNotFound = errors.New("repository: entity not found")
+	// ErrConflict matches every *ConflictError.
+	ErrConflict = errors.New("repository: version conflict")
+)
+
+// ConflictError is returned by Update when the row's version changed since it was read.
+type ConflictError struct {
+	Table   string
+	ID      any
+	Version int64
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("repository: %s %v: version %d is stale", e.Table, e.ID, e.Version)
+}
+
+func (e *ConflictError) Unwrap() error { return ErrConflict }
@@ -0,0 +1,64 @@
+package repository
+
+import "time"
+
+const (
+	DefaultIDColumn      = "id"
+	DefaultVersionColumn = "version"
+
+	createdAtColumn = "created_at"
+	updatedAtColumn = "updated_at"
+	deletedAtColumn = "deleted_at"
+)
+
+type Option func(*options)
+
+type options struct {
+	table   string
+	id      string
+	version string
+	now     func() time.Time
+}
+
+func defaultOptions() options {
+	return options{
+		id:      DefaultIDColumn,
+		version: DefaultVersionColumn,
+		now:     time.Now,
+	}
+}
+
+// WithTable sets the table, overriding a TableName method on T.
+func WithTable(table string) Option {
+	return func(o *options) {
+		if table != "" {
+			o.table = table
+		}
+	}
+}
+
+func WithIDColumn(column string) Option {
+	return func(o *options) {
+		if column != "" {
+			o.id = column
+		}
+	}
+}
+
+// WithVersionColumn names the integer column used for optimistic locking.
+func WithVersionColumn(column string) Option {
+	return func(o *options) {
+		if column != "" {
+			o.version = column
+		}
+	}
+}
+
+// WithClock sets the source of the timestamps written by the repository.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		if now != nil {
+			o.now = now
+		}
+	}
+}
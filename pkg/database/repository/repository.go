@@ -0,0 +1,389 @@
+// Package repository provides a generic CRUD repository with soft delete and optimistic locking.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/dialect"
+)
+
+var (
+	columnPattern  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	orderByPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\s+(?i:asc|desc))?$`)
+)
+
+// Tabler is implemented by entities that name their own table.
+type Tabler interface {
+	TableName() string
+}
+
+// Filter narrows List with a condition using :name parameters bound from Args.
+type Filter struct {
+	Where       string
+	Args        any
+	OrderBy     string
+	Limit       int
+	WithDeleted bool
+}
+
+// Repository reads and writes T in one table through the DBTX given to each method.
+type Repository[T any] struct {
+	driver  database.Driver
+	table   string
+	columns []string
+	id      string
+	version string
+	now     func() time.Time
+
+	softDelete bool
+	created    bool
+	updated    bool
+}
+
+// New builds the repository for T, a struct mapping the id column.
+func New[T any](driver database.Driver, opts ...Option) (*Repository[T], error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if !dialect.Supported(driver) && driver != database.DriverSQLite {
+		return nil, fmt.Errorf("%w: repository: unsupported driver %q", database.ErrInvalidConfig, driver)
+	}
+
+	var zero T
+	if o.table == "" {
+		if tabler, ok := any(&zero).(Tabler); ok {
+			o.table = tabler.TableName()
+		}
+	}
+	if !dialect.ValidIdentifier(o.table) {
+		return nil, fmt.Errorf("%w: repository: invalid or missing table name %q for %T", database.ErrInvalidConfig, o.table, zero)
+	}
+
+	columns, err := database.ColumnNames(zero)
+	if err != nil {
+		return nil, fmt.Errorf("%w: repository: %w", database.ErrInvalidConfig, err)
+	}
+	for _, c := range columns {
+		if !columnPattern.MatchString(c) {
+			return nil, fmt.Errorf("%w: repository: invalid column %q in %T", database.ErrInvalidConfig, c, zero)
+		}
+	}
+	if !slices.Contains(columns, o.id) {
+		return nil, fmt.Errorf("%w: repository: %T has no id column %q", database.ErrInvalidConfig, zero, o.id)
+	}
+
+	r := &Repository[T]{
+		driver:     driver,
+		table:      o.table,
+		columns:    columns,
+		id:         o.id,
+		now:        o.now,
+		softDelete: slices.Contains(columns, deletedAtColumn),
+		created:    slices.Contains(columns, createdAtColumn),
+		updated:    slices.Contains(columns, updatedAtColumn),
+	}
+	if slices.Contains(columns, o.version) {
+		ptr, _ := database.ColumnPointer(&zero, o.version)
+		if !isInteger(reflect.TypeOf(ptr).Elem().Kind()) {
+			return nil, fmt.Errorf("%w: repository: version column %q of %T must be an integer", database.ErrInvalidConfig, o.version, zero)
+		}
+		r.version = o.version
+	}
+	return r, nil
+}
+
+// FindByID returns the live row with id, or ErrNotFound.
+func (r *Repository[T]) FindByID(ctx context.Context, db database.DBTX, id any) (T, error) {
+	var zero T
+	conds := []string{r.id + " = :id"}
+	if r.softDelete {
+		conds = append(conds, deletedAtColumn+" IS NULL")
+	}
+	query, args, err := database.Named(db, r.driver).Bind(r.selectSQL()+" WHERE "+strings.Join(conds, " AND "), map[string]any{"id": idArg(id)})
+	if err != nil {
+		return zero, err
+	}
+
+	item, err := database.QueryOne[T](ctx, db, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return zero, fmt.Errorf("%w: %s %v", ErrNotFound, r.table, id)
+	}
+	return item, err
+}
+
+// List returns the rows matching f, skipping soft-deleted ones unless f.WithDeleted.
+func (r *Repository[T]) List(ctx context.Context, db database.DBTX, f Filter) ([]T, error) {
+	orderBy, err := r.orderBy(f.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	var conds []string
+	if r.softDelete && !f.WithDeleted {
+		conds = append(conds, deletedAtColumn+" IS NULL")
+	}
+	if strings.TrimSpace(f.Where) != "" {
+		conds = append(conds, "("+f.Where+")")
+	}
+
+	var b strings.Builder
+	b.WriteString(r.selectSQL())
+	if len(conds) > 0 {
+		b.WriteString(" WHERE " + strings.Join(conds, " AND "))
+	}
+	b.WriteString(" ORDER BY " + orderBy)
+	if f.Limit > 0 {
+		if r.driver == database.DriverMSSQL {
+			b.WriteString(" OFFSET 0 ROWS FETCH NEXT " + strconv.Itoa(f.Limit) + " ROWS ONLY")
+		} else {
+			b.WriteString(" LIMIT " + strconv.Itoa(f.Limit))
+		}
+	}
+
+	args := f.Args
+	if args == nil {
+		args = map[string]any{}
+	}
+	query, bound, err := database.Named(db, r.driver).Bind(b.String(), args)
+	if err != nil {
+		return nil, err
+	}
+	return database.QueryAll[T](ctx, db, query, bound...)
+}
+
+// Insert writes entity, filling a nil uuid id, created_at and the version.
+func (r *Repository[T]) Insert(ctx context.Context, db database.DBTX, entity *T) error {
+	values, err := database.ColumnValues(entity)
+	if err != nil {
+		return err
+	}
+
+	set := map[string]any{}
+	if ptr, _ := database.ColumnPointer(entity, r.id); ptr != nil {
+		if id, ok := ptr.(*uuid.UUID); ok && *id == uuid.Nil {
+			generated, err := uuid.NewV7()
+			if err != nil {
+				return fmt.Errorf("repository: generate id: %w", err)
+			}
+			set[r.id] = generated
+		}
+	}
+	if r.created && isZero(values[createdAtColumn]) {
+		set[createdAtColumn] = r.now().UTC()
+	}
+	if r.version != "" && isZero(values[r.version]) {
+		set[r.version] = int64(1)
+	}
+	for column, value := range set {
+		values[column] = value
+	}
+
+	placeholders := make([]string, len(r.columns))
+	for i, c := range r.columns {
+		placeholders[i] = ":" + c
+	}
+	query := "INSERT INTO " + r.table + " (" + strings.Join(r.columns, ", ") + ") VALUES (" + strings.Join(placeholders, ", ") + ")"
+	if _, err := database.Named(db, r.driver).ExecContext(ctx, query, values); err != nil {
+		return err
+	}
+	return assignAll(entity, set)
+}
+
+// Update writes entity and bumps its version, or returns a *ConflictError.
+func (r *Repository[T]) Update(ctx context.Context, db database.DBTX, entity *T) error {
+	values, err := database.ColumnValues(entity)
+	if err != nil {
+		return err
+	}
+
+	set := map[string]any{}
+	var assignments []string
+	for _, c := range r.columns {
+		switch c {
+		case r.id, createdAtColumn, deletedAtColumn, r.version:
+			continue
+		case updatedAtColumn:
+			set[c] = r.now().UTC()
+			values[c] = set[c]
+		}
+		assignments = append(assignments, c+" = :"+c)
+	}
+	conds := []string{r.id + " = :" + r.id}
+	var version int64
+	if r.version != "" {
+		version = toInt64(values[r.version])
+		assignments = append(assignments, r.version+" = "+r.version+" + 1")
+		conds = append(conds, r.version+" = :"+r.version)
+		set[r.version] = version + 1
+	}
+	if r.softDelete {
+		conds = append(conds, deletedAtColumn+" IS NULL")
+	}
+	if len(assignments) == 0 {
+		return fmt.Errorf("%w: repository: %s has no updatable columns", database.ErrInvalidConfig, r.table)
+	}
+
+	query := "UPDATE " + r.table + " SET " + strings.Join(assignments, ", ") + " WHERE " + strings.Join(conds, " AND ")
+	result, err := database.Named(db, r.driver).ExecContext(ctx, query, values)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository: rows affected: %w", err)
+	}
+	if affected == 0 {
+		if r.version != "" {
+			return &ConflictError{Table: r.table, ID: values[r.id], Version: version}
+		}
+		return fmt.Errorf("%w: %s %v", ErrNotFound, r.table, values[r.id])
+	}
+	return assignAll(entity, set)
+}
+
+// SoftDelete sets deleted_at on the live row with id, or returns ErrNotFound.
+func (r *Repository[T]) SoftDelete(ctx context.Context, db database.DBTX, id any) error {
+	return r.setDeleted(ctx, db, id, true)
+}
+
+// Restore clears deleted_at on the soft-deleted row with id, or returns ErrNotFound.
+func (r *Repository[T]) Restore(ctx context.Context, db database.DBTX, id any) error {
+	return r.setDeleted(ctx, db, id, false)
+}
+
+func (r *Repository[T]) setDeleted(ctx context.Context, db database.DBTX, id any, deleted bool) error {
+	if !r.softDelete {
+		return fmt.Errorf("%w: repository: %s has no %s column", database.ErrInvalidConfig, r.table, deletedAtColumn)
+	}
+
+	args := map[string]any{"id": idArg(id), "now": r.now().UTC()}
+	assignments := []string{deletedAtColumn + " = NULL"}
+	cond := deletedAtColumn + " IS NOT NULL"
+	if deleted {
+		assignments = []string{deletedAtColumn + " = :now"}
+		cond = deletedAtColumn + " IS NULL"
+	}
+	if r.updated {
+		assignments = append(assignments, updatedAtColumn+" = :now")
+	}
+	if r.version != "" {
+		assignments = append(assignments, r.version+" = "+r.version+" + 1")
+	}
+
+	query := "UPDATE " + r.table + " SET " + strings.Join(assignments, ", ") + " WHERE " + r.id + " = :id AND " + cond
+	result, err := database.Named(db, r.driver).ExecContext(ctx, query, args)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository: rows affected: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: %s %v", ErrNotFound, r.table, id)
+	}
+	return nil
+}
+
+func (r *Repository[T]) selectSQL() string {
+	return "SELECT " + strings.Join(r.columns, ", ") + " FROM " + r.table
+}
+
+func (r *Repository[T]) orderBy(clause string) (string, error) {
+	if strings.TrimSpace(clause) == "" {
+		return r.id, nil
+	}
+	parts := strings.Split(clause, ",")
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if !orderByPattern.MatchString(part) {
+			return "", fmt.Errorf("%w: repository: invalid order by %q", database.ErrInvalidConfig, clause)
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, ", "), nil
+}
+
+// idArg unwraps value objects such as vos.UUID, which the drivers cannot bind.
+func idArg(id any) any {
+	if _, ok := id.(driver.Valuer); ok {
+		return id
+	}
+	v := reflect.ValueOf(id)
+	if v.Kind() == reflect.Struct && v.NumField() == 1 && v.Type().Field(0).IsExported() && v.Type() != reflect.TypeFor[time.Time]() {
+		return v.Field(0).Interface()
+	}
+	return id
+}
+
+func assignAll(entity any, values map[string]any) error {
+	for column, value := range values {
+		ptr, err := database.ColumnPointer(entity, column)
+		if err != nil {
+			return err
+		}
+		if err := assign(ptr, value); err != nil {
+			return fmt.Errorf("repository: set %s: %w", column, err)
+		}
+	}
+	return nil
+}
+
+func assign(ptr, value any) error {
+	target := reflect.ValueOf(ptr).Elem()
+	src := reflect.ValueOf(value)
+	if src.Type().AssignableTo(target.Type()) {
+		target.Set(src)
+		return nil
+	}
+	if scanner, ok := ptr.(sql.Scanner); ok {
+		return scanner.Scan(value)
+	}
+	if target.Kind() == reflect.Pointer {
+		fresh := reflect.New(target.Type().Elem())
+		if err := assign(fresh.Interface(), value); err != nil {
+			return err
+		}
+		target.Set(fresh)
+		return nil
+	}
+	if !src.Type().ConvertibleTo(target.Type()) {
+		return fmt.Errorf("cannot assign %T to %s", value, target.Type())
+	}
+	target.Set(src.Convert(target.Type()))
+	return nil
+}
+
+func isZero(value any) bool {
+	return value == nil || reflect.ValueOf(value).IsZero()
+}
+
+func isInteger(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Uint64
+}
+
+func toInt64(value any) int64 {
+	v := reflect.ValueOf(value)
+	if v.CanInt() {
+		return v.Int()
+	}
+	if v.CanUint() {
+		return int64(v.Uint())
+	}
+	return 0
+}
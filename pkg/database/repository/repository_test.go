@@ -0,0 +1,233 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/dbtest"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/repository"
+	"github.com/JailtonJunior94/devkit-go/pkg/entity"
+	"github.com/JailtonJunior94/devkit-go/pkg/vos"
+)
+
+type product struct {
+	entity.Base
+	Name    string
+	Price   int64
+	Version int64
+}
+
+func (*product) TableName() string { return "products" }
+
+type tag struct {
+	ID   int64
+	Name string
+}
+
+var now = time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+func newRepo(t *testing.T, driver database.Driver) *repository.Repository[product] {
+	t.Helper()
+	repo, err := repository.New[product](driver, repository.WithClock(func() time.Time { return now }))
+	require.NoError(t, err)
+	return repo
+}
+
+func TestFindByID_SkipsSoftDeleted(t *testing.T) {
+	db := dbtest.New()
+	id := uuid.New()
+	db.On(`SELECT`).Rows(
+		[]string{"id", "created_at", "updated_at", "deleted_at", "name", "price", "version"},
+		[]any{id.String(), now, nil, nil, "caneta", int64(350), int64(2)},
+	).Times(1)
+	repo := newRepo(t, database.DriverPostgres)
+	ctx := context.Background()
+
+	p, err := repo.FindByID(ctx, db.DBTX(ctx), vos.UUID{Value: id})
+	require.NoError(t, err)
+	require.Equal(t, id, p.ID.Value)
+	require.Equal(t, "caneta", p.Name)
+	require.Equal(t, int64(2), p.Version)
+
+	_, err = repo.FindByID(ctx, db.DBTX(ctx), id)
+	require.ErrorIs(t, err, repository.ErrNotFound)
+
+	stmts := db.Statements()
+	require.Equal(t, "SELECT id, created_at, updated_at, deleted_at, name, price, version FROM products WHERE id = $1 AND deleted_at IS NULL", stmts[0].Query)
+	require.Equal(t, []any{id}, stmts[0].Args, "o value object do id é desembrulhado")
+}
+
+func TestInsert_FillsGeneratedValues(t *testing.T) {
+	db := dbtest.New(dbtest.WithDriver(database.DriverMySQL))
+	repo := newRepo(t, database.DriverMySQL)
+	ctx := context.Background()
+
+	p := &product{Name: "caderno", Price: 1200}
+	require.NoError(t, repo.Insert(ctx, db.DBTX(ctx), p))
+
+	require.NotEqual(t, uuid.Nil, p.ID.Value, "um id vazio recebe um UUIDv7")
+	require.Equal(t, uuid.Version(7), p.ID.Value.Version())
+	require.Equal(t, now, p.CreatedAt)
+	require.Equal(t, int64(1), p.Version)
+
+	stmts := db.Statements()
+	require.Len(t, stmts, 1)
+	require.Equal(t, "INSERT INTO products (id, created_at, updated_at, deleted_at, name, price, version) VALUES (?, ?, ?, ?, ?, ?, ?)", stmts[0].Query)
+	require.Equal(t, p.ID.Value, stmts[0].Args[0])
+	require.Equal(t, now, stmts[0].Args[1])
+	require.Equal(t, "caderno", stmts[0].Args[4])
+	require.Equal(t, int64(1), stmts[0].Args[6])
+}
+
+func TestInsert_KeepsProvidedValues(t *testing.T) {
+	db := dbtest.New()
+	repo := newRepo(t, database.DriverPostgres)
+	ctx := context.Background()
+
+	id := uuid.New()
+	created := now.Add(-time.Hour)
+	p := &product{Base: entity.Base{ID: vos.UUID{Value: id}, CreatedAt: created}, Version: 5}
+	require.NoError(t, repo.Insert(ctx, db.DBTX(ctx), p))
+
+	require.Equal(t, id, p.ID.Value)
+	require.Equal(t, created, p.CreatedAt)
+	require.Equal(t, int64(5), p.Version)
+}
+
+func TestUpdate_BumpsVersionAndTimestamp(t *testing.T) {
+	db := dbtest.New()
+	db.On(`UPDATE`).RowsAffected(1)
+	repo := newRepo(t, database.DriverPostgres)
+	ctx := context.Background()
+
+	id := uuid.New()
+	p := &product{Base: entity.Base{ID: vos.UUID{Value: id}}, Name: "lápis", Price: 90, Version: 3}
+	require.NoError(t, repo.Update(ctx, db.DBTX(ctx), p))
+
+	require.Equal(t, int64(4), p.Version)
+	updated, ok := p.UpdatedAt.Get()
+	require.True(t, ok)
+	require.Equal(t, now, updated)
+
+	stmts := db.Statements()
+	require.Equal(t, "UPDATE products SET updated_at = $1, name = $2, price = $3, version = version + 1 WHERE id = $4 AND version = $5 AND deleted_at IS NULL", stmts[0].Query)
+	require.Equal(t, []any{now, "lápis", int64(90), id, int64(3)}, stmts[0].Args)
+}
+
+func TestUpdate_StaleVersionReturnsConflict(t *testing.T) {
+	db := dbtest.New()
+	repo := newRepo(t, database.DriverPostgres)
+	ctx := context.Background()
+
+	id := uuid.New()
+	p := &product{Base: entity.Base{ID: vos.UUID{Value: id}}, Version: 3}
+	err := repo.Update(ctx, db.DBTX(ctx), p)
+
+	require.ErrorIs(t, err, repository.ErrConflict)
+	var conflict *repository.ConflictError
+	require.True(t, errors.As(err, &conflict))
+	require.Equal(t, "products", conflict.Table)
+	require.Equal(t, int64(3), conflict.Version)
+	require.Equal(t, int64(3), p.Version, "a entidade não muda quando o update é rejeitado")
+	require.False(t, p.UpdatedAt.IsValid())
+}
+
+func TestUpdate_WithoutVersionReturnsNotFound(t *testing.T) {
+	db := dbtest.New()
+	repo, err := repository.New[tag](database.DriverPostgres, repository.WithTable("tags"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	err = repo.Update(ctx, db.DBTX(ctx), &tag{ID: 7, Name: "go"})
+	require.ErrorIs(t, err, repository.ErrNotFound)
+	require.Equal(t, "UPDATE tags SET name = $1 WHERE id = $2", db.Statements()[0].Query,
+		"sem colunas de auditoria nada além dos campos é escrito")
+}
+
+func TestSoftDeleteAndRestore(t *testing.T) {
+	db := dbtest.New()
+	db.On(`UPDATE`).RowsAffected(1).Times(2)
+	repo := newRepo(t, database.DriverPostgres)
+	ctx := context.Background()
+	id := uuid.New()
+
+	require.NoError(t, repo.SoftDelete(ctx, db.DBTX(ctx), id))
+	require.NoError(t, repo.Restore(ctx, db.DBTX(ctx), id))
+	require.ErrorIs(t, repo.Restore(ctx, db.DBTX(ctx), id), repository.ErrNotFound)
+
+	stmts := db.Statements()
+	require.Equal(t, "UPDATE products SET deleted_at = $1, updated_at = $2, version = version + 1 WHERE id = $3 AND deleted_at IS NULL", stmts[0].Query)
+	require.Equal(t, []any{now, now, id}, stmts[0].Args)
+	require.Equal(t, "UPDATE products SET deleted_at = NULL, updated_at = $1, version = version + 1 WHERE id = $2 AND deleted_at IS NOT NULL", stmts[1].Query)
+
+	tags, err := repository.New[tag](database.DriverPostgres, repository.WithTable("tags"))
+	require.NoError(t, err)
+	require.ErrorIs(t, tags.SoftDelete(ctx, db.DBTX(ctx), 1), database.ErrInvalidConfig)
+}
+
+func TestList_FiltersOrdersAndLimits(t *testing.T) {
+	db := dbtest.New()
+	repo := newRepo(t, database.DriverPostgres)
+	ctx := context.Background()
+
+	_, err := repo.List(ctx, db.DBTX(ctx), repository.Filter{})
+	require.NoError(t, err)
+	_, err = repo.List(ctx, db.DBTX(ctx), repository.Filter{
+		Where:       "price > :min OR name = :name",
+		Args:        map[string]any{"min": 100, "name": "caneta"},
+		OrderBy:     "price desc, name",
+		Limit:       10,
+		WithDeleted: true,
+	})
+	require.NoError(t, err)
+	_, err = repo.List(ctx, db.DBTX(ctx), repository.Filter{Where: "price > :min", Args: map[string]any{"min": 1}})
+	require.NoError(t, err)
+
+	stmts := db.Statements()
+	const selectAll = "SELECT id, created_at, updated_at, deleted_at, name, price, version FROM products"
+	require.Equal(t, selectAll+" WHERE deleted_at IS NULL ORDER BY id", stmts[0].Query)
+	require.Equal(t, selectAll+" WHERE (price > $1 OR name = $2) ORDER BY price desc, name LIMIT 10", stmts[1].Query)
+	require.Equal(t, []any{100, "caneta"}, stmts[1].Args)
+	require.Equal(t, selectAll+" WHERE deleted_at IS NULL AND (price > $1) ORDER BY id", stmts[2].Query,
+		"o filtro do chamador não escapa da exclusão dos removidos")
+
+	_, err = repo.List(ctx, db.DBTX(ctx), repository.Filter{OrderBy: "price; DROP TABLE products"})
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+}
+
+func TestList_MSSQLUsesOffsetFetch(t *testing.T) {
+	db := dbtest.New(dbtest.WithDriver(database.DriverMSSQL))
+	repo := newRepo(t, database.DriverMSSQL)
+	ctx := context.Background()
+
+	_, err := repo.List(ctx, db.DBTX(ctx), repository.Filter{Limit: 5})
+	require.NoError(t, err)
+	require.Contains(t, db.Statements()[0].Query, "ORDER BY id OFFSET 0 ROWS FETCH NEXT 5 ROWS ONLY")
+}
+
+func TestNew_Validation(t *testing.T) {
+	_, err := repository.New[tag](database.DriverPostgres)
+	require.ErrorIs(t, err, database.ErrInvalidConfig, "sem TableName nem WithTable não há tabela")
+
+	_, err = repository.New[tag](database.DriverPostgres, repository.WithTable("tags; --"))
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+
+	_, err = repository.New[tag](database.DriverPostgres, repository.WithTable("tags"), repository.WithIDColumn("tag_id"))
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+
+	_, err = repository.New[product](database.Driver("oracle"))
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+
+	type badVersion struct {
+		ID      int64
+		Version string
+	}
+	_, err = repository.New[badVersion](database.DriverPostgres, repository.WithTable("things"))
+	require.ErrorIs(t, err, database.ErrInvalidConfig, "a coluna de versão precisa ser inteira")
+}
@@ -0,0 +1,59 @@
+package database
+
+import "context"
+
+// Session sets up the connection a transaction runs on with state that
+// outlives the transaction, such as MySQL's USE or SET SESSION, and puts the
+// previous state back once the transaction has ended.
+//
+// The manager pins the connection from BEGIN until the transaction is
+// committed or rolled back: Enter runs on it before BEGIN and Exit after the
+// transaction ends, even when database/sql already rolled it back because
+// the BeginTx context ended. When Enter or Exit fails the connection is
+// closed instead of returning to the pool, so no other caller inherits the
+// state.
+type Session interface {
+	Enter(ctx context.Context, conn DBTX) error
+	Exit(ctx context.Context, conn DBTX) error
+}
+
+// JoinSessions combines sessions into one that enters them in order and
+// exits them in reverse. Nil sessions are skipped.
+func JoinSessions(sessions ...Session) Session {
+	var joined joinedSession
+	for _, s := range sessions {
+		if s != nil {
+			joined = append(joined, s)
+		}
+	}
+	switch len(joined) {
+	case 0:
+		return nil
+	case 1:
+		return joined[0]
+	}
+	return joined
+}
+
+type joinedSession []Session
+
+// Enter exits the sessions already entered when a later one fails.
+func (j joinedSession) Enter(ctx context.Context, conn DBTX) error {
+	for i, s := range j {
+		if err := s.Enter(ctx, conn); err != nil {
+			_ = j[:i].Exit(ctx, conn)
+			return err
+		}
+	}
+	return nil
+}
+
+func (j joinedSession) Exit(ctx context.Context, conn DBTX) error {
+	var first error
+	for i := len(j) - 1; i >= 0; i-- {
+		if err := j[i].Exit(ctx, conn); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"fmt"
+
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "modernc.org/sqlite"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	internalpool "github.com/JailtonJunior94/devkit-go/pkg/database/internal/pool"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/sqlshared"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/manager"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+)
+
+type Adapter struct {
+	*sqlshared.Adapter
+}
+
+type Tx = sqlshared.Tx
+
+// maxBindParams is SQLITE_MAX_VARIABLE_NUMBER in the bundled SQLite build.
+const maxBindParams = 32766
+
+func init() {
+	manager.RegisterDriverFactory(SQLiteConfig{}, func(cfg manager.DriverConfig, obs observability.Observability) (manager.DriverAdapter, error) {
+		switch c := cfg.(type) {
+		case SQLiteConfig:
+			return New(c, obs)
+		case *SQLiteConfig:
+			return New(*c, obs)
+		default:
+			return nil, fmt.Errorf("%w: unsupported driver config type %T", database.ErrInvalidConfig, cfg)
+		}
+	})
+	manager.RegisterEnvConfig(database.DriverSQLite, configFromEnv)
+}
+
+func New(cfg SQLiteConfig, obs observability.Observability) (*Adapter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", database.ErrInvalidConfig, err)
+	}
+
+	maxOpen := cfg.MaxOpenConns
+	if cfg.IsMemory() {
+		// Connections to a memdb database contend on one lock; a single
+		// connection queues callers instead of failing them with SQLITE_BUSY.
+		maxOpen = 1
+	}
+
+	inner, err := sqlshared.Open(sqlshared.OpenParams{
+		Driver:     database.DriverSQLite,
+		DriverName: "sqlite",
+		DSN:        cfg.ResolveDSN(),
+		Settings: sqlshared.ConnSettings{
+			MaxOpenConns: maxOpen,
+			MaxIdleConns: cfg.MaxIdleConns,
+			ConnMaxLife:  cfg.ConnMaxLife,
+			ConnMaxIdle:  cfg.ConnMaxIdle,
+		},
+		ApplyDefaults: applyDefaults,
+		Info: internalpool.ConnInfo{
+			Database: cfg.Path,
+		},
+		PingTimeout:   cfg.PingTimeout,
+		Observability: obs,
+		Copy:          sqlshared.BatchInsert(database.DriverSQLite, quoteIdentifier, maxBindParams),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Adapter{Adapter: inner}, nil
+}
+
+func configFromEnv(getenv func(string) string) (manager.DriverConfig, error) {
+	path := getenv("DB_DATABASE")
+	if path == "" {
+		path = getenv("DB_NAME")
+	}
+	return SQLiteConfig{
+		DSN:  getenv("DB_DSN"),
+		Path: path,
+	}, nil
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + name + `"`
+}
@@ -0,0 +1,426 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/manager"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/migration"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/sqlite"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/uow"
+)
+
+// Compile-time assertion: *sqlite.Tx must satisfy database.Tx.
+var _ database.Tx = (*sqlite.Tx)(nil)
+
+var migrations = fstest.MapFS{
+	"1_create_users.up.sql":   {Data: []byte(`CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL);`)},
+	"1_create_users.down.sql": {Data: []byte(`DROP TABLE users;`)},
+}
+
+func newManager(t *testing.T, cfg manager.DriverConfig) manager.Manager {
+	t.Helper()
+	mgr, err := manager.New(cfg, manager.WithStartupMigrationFS(migrations, "."))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mgr.Shutdown(context.Background()) })
+	return mgr
+}
+
+func countUsers(t *testing.T, db database.DBTX) int64 {
+	t.Helper()
+	n, err := database.QueryOne[int64](context.Background(), db, "SELECT COUNT(*) FROM users")
+	require.NoError(t, err)
+	return n
+}
+
+func TestManagerNew_MemoryDatabaseRunsStartupMigrations(t *testing.T) {
+	mgr := newManager(t, sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: t.Name()})
+
+	require.Equal(t, database.DriverSQLite, mgr.Driver())
+	require.NoError(t, mgr.Ping(context.Background()))
+	require.Zero(t, countUsers(t, mgr.DBTX(context.Background())))
+}
+
+func TestManagerNew_FileDatabase(t *testing.T) {
+	cfg := sqlite.SQLiteConfig{Path: t.TempDir() + "/app.db"}
+	mgr := newManager(t, cfg)
+
+	_, err := mgr.DBTX(context.Background()).ExecContext(context.Background(), "INSERT INTO users (name) VALUES (?)", "ana")
+	require.NoError(t, err)
+	require.NoError(t, mgr.Shutdown(context.Background()))
+
+	reopened := newManager(t, cfg)
+	require.Equal(t, int64(1), countUsers(t, reopened.DBTX(context.Background())))
+}
+
+func TestManagerNew_EnvironmentConfig(t *testing.T) {
+	t.Setenv("DB_DRIVER", "sqlite")
+	t.Setenv("DB_DATABASE", t.TempDir()+"/env.db")
+
+	mgr := newManager(t, nil)
+
+	require.Equal(t, database.DriverSQLite, mgr.Driver())
+}
+
+func TestUnitOfWork_CommitRollbackAndSavepoints(t *testing.T) {
+	ctx := context.Background()
+	mgr := newManager(t, sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: t.Name()})
+	work := uow.New[struct{}](mgr)
+	insert := func(name string) func(context.Context, database.DBTX) (struct{}, error) {
+		return func(ctx context.Context, tx database.DBTX) (struct{}, error) {
+			_, err := tx.ExecContext(ctx, "INSERT INTO users (name) VALUES (?)", name)
+			return struct{}{}, err
+		}
+	}
+	boom := errors.New("boom")
+
+	_, err := work.Do(ctx, insert("committed"))
+	require.NoError(t, err)
+
+	_, err = work.Do(ctx, func(ctx context.Context, tx database.DBTX) (struct{}, error) {
+		if _, err := insert("rolled back")(ctx, tx); err != nil {
+			return struct{}{}, err
+		}
+		return struct{}{}, boom
+	})
+	require.ErrorIs(t, err, boom)
+
+	_, err = work.Do(ctx, func(ctx context.Context, tx database.DBTX) (struct{}, error) {
+		if _, err := insert("outer")(ctx, tx); err != nil {
+			return struct{}{}, err
+		}
+		_, innerErr := work.Do(ctx, func(ctx context.Context, tx database.DBTX) (struct{}, error) {
+			_, _ = insert("inner")(ctx, tx)
+			return struct{}{}, boom
+		})
+		require.ErrorIs(t, innerErr, boom)
+		return struct{}{}, nil
+	})
+	require.NoError(t, err)
+
+	names, err := database.QueryAll[string](ctx, mgr.DBTX(ctx), "SELECT name FROM users ORDER BY id")
+	require.NoError(t, err)
+	require.Equal(t, []string{"committed", "outer"}, names)
+}
+
+// busyTimeoutSession muda um PRAGMA da conexão e restaura o valor anterior.
+type busyTimeoutSession struct{ previous int }
+
+func (s *busyTimeoutSession) Enter(ctx context.Context, conn database.DBTX) error {
+	if err := conn.QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&s.previous); err != nil {
+		return err
+	}
+	_, err := conn.ExecContext(ctx, "PRAGMA busy_timeout = 1234")
+	return err
+}
+
+func (s *busyTimeoutSession) Exit(ctx context.Context, conn database.DBTX) error {
+	_, err := conn.ExecContext(ctx, "PRAGMA busy_timeout = "+strconv.Itoa(s.previous))
+	return err
+}
+
+func TestBeginTx_SessionRestoredAfterContextCancel(t *testing.T) {
+	mgr := newManager(t, sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: t.Name()})
+	busyTimeout := func(db database.DBTX) int {
+		var ms int
+		require.NoError(t, db.QueryRowContext(context.Background(), "PRAGMA busy_timeout").Scan(&ms))
+		return ms
+	}
+	before := busyTimeout(mgr.DBTX(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tx, err := mgr.BeginTx(ctx, database.TxOptions{Session: &busyTimeoutSession{}})
+	require.NoError(t, err)
+	require.Equal(t, 1234, busyTimeout(tx))
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, err := tx.ExecContext(context.Background(), "SELECT 1")
+		return errors.Is(err, sql.ErrTxDone)
+	}, time.Second, time.Millisecond, "o database/sql desfaz a transação quando o ctx é cancelado")
+
+	_ = tx.Rollback(context.Background())
+	require.Equal(t, before, busyTimeout(mgr.DBTX(context.Background())),
+		"a conexão só volta ao pool depois que a sessão é restaurada")
+}
+
+func TestMigrator_UpAndDown(t *testing.T) {
+	ctx := context.Background()
+	cfg := sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: t.Name()}
+	mgr, err := manager.New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mgr.Shutdown(ctx) })
+
+	m, err := migration.New(mgr, migration.EmbedFS{FS: migrations, Root: "."}, migration.WithDSN(cfg.MigrationDSN()))
+	require.NoError(t, err)
+
+	require.NoError(t, m.Up(ctx))
+	version, dirty, err := m.Version(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint(1), version)
+	require.False(t, dirty)
+	require.Zero(t, countUsers(t, mgr.DBTX(ctx)))
+
+	require.NoError(t, m.Down(ctx, 1))
+	_, err = mgr.DBTX(ctx).ExecContext(ctx, "SELECT 1 FROM users")
+	require.Error(t, err)
+}
+
+func TestMigrator_GoMigrationsInterleaveWithFiles(t *testing.T) {
+	ctx := context.Background()
+	cfg := sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: t.Name()}
+	mgr, err := manager.New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mgr.Shutdown(ctx) })
+
+	files := fstest.MapFS{
+		"1_create_users.up.sql":   {Data: []byte(`CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL);`)},
+		"1_create_users.down.sql": {Data: []byte(`DROP TABLE users;`)},
+		"3_create_tags.up.sql":    {Data: []byte(`CREATE TABLE tags (id INTEGER PRIMARY KEY);`)},
+		"3_create_tags.down.sql":  {Data: []byte(`DROP TABLE tags;`)},
+	}
+	seed := migration.GoMigration{
+		Version:    2,
+		Identifier: "seed_users",
+		Up: func(ctx context.Context, tx database.Tx) error {
+			_, err := tx.ExecContext(ctx, "INSERT INTO users (name) VALUES ('admin')")
+			return err
+		},
+		Down: func(ctx context.Context, tx database.Tx) error {
+			_, err := tx.ExecContext(ctx, "DELETE FROM users")
+			return err
+		},
+	}
+
+	m, err := migration.New(mgr, migration.EmbedFS{FS: files, Root: "."},
+		migration.WithDSN(cfg.MigrationDSN()),
+		migration.WithGoMigrations(seed),
+	)
+	require.NoError(t, err)
+
+	plan, err := m.Plan(ctx, migration.Latest())
+	require.NoError(t, err)
+	require.Len(t, plan, 3)
+	require.True(t, plan[1].Go)
+	require.Equal(t, "seed_users", plan[1].Identifier)
+
+	require.NoError(t, m.Up(ctx))
+	version, dirty, err := m.Version(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint(3), version)
+	require.False(t, dirty)
+	require.Equal(t, int64(1), countUsers(t, mgr.DBTX(ctx)))
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	require.True(t, statuses[1].Go)
+	require.True(t, statuses[1].Applied)
+
+	// Volta até a migração Go: a tabela tags some e o seed é desfeito.
+	require.NoError(t, m.Down(ctx, 2))
+	version, _, err = m.Version(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint(1), version)
+	require.Zero(t, countUsers(t, mgr.DBTX(ctx)))
+}
+
+func TestMigrator_GoMigrationErrorRollsBack(t *testing.T) {
+	ctx := context.Background()
+	cfg := sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: t.Name()}
+	mgr, err := manager.New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mgr.Shutdown(ctx) })
+
+	errSeed := errors.New("seed failed")
+	m, err := migration.New(mgr, migration.EmbedFS{FS: migrations, Root: "."},
+		migration.WithDSN(cfg.MigrationDSN()),
+		migration.WithGoMigrations(migration.GoMigration{
+			Version:    2,
+			Identifier: "seed_users",
+			Up: func(ctx context.Context, tx database.Tx) error {
+				if _, err := tx.ExecContext(ctx, "INSERT INTO users (name) VALUES ('admin')"); err != nil {
+					return err
+				}
+				return errSeed
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	err = m.Up(ctx)
+	require.ErrorIs(t, err, errSeed)
+
+	// A versão fica suja como em uma falha de SQL, mas a transação foi desfeita.
+	version, dirty, err := m.Version(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint(2), version)
+	require.True(t, dirty)
+	require.Zero(t, countUsers(t, mgr.DBTX(ctx)))
+}
+
+func TestMigrator_DetectsChecksumDrift(t *testing.T) {
+	ctx := context.Background()
+	cfg := sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: t.Name()}
+	mgr, err := manager.New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mgr.Shutdown(ctx) })
+
+	files := fstest.MapFS{
+		"1_create_users.up.sql": {Data: []byte(`CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL);`)},
+		"3_create_tags.up.sql":  {Data: []byte(`CREATE TABLE tags (id INTEGER PRIMARY KEY);`)},
+	}
+	newMigrator := func(opts ...migration.Option) migration.Migrator {
+		m, err := migration.New(mgr, migration.EmbedFS{FS: files, Root: "."}, append(opts, migration.WithDSN(cfg.MigrationDSN()))...)
+		require.NoError(t, err)
+		return m
+	}
+
+	m := newMigrator(migration.WithDriftPolicy(database.DriftFail))
+	require.NoError(t, m.Up(ctx))
+	require.NoError(t, m.Verify(ctx))
+
+	// Arquivo aplicado alterado, arquivo novo abaixo da versão atual e arquivo aplicado removido.
+	files["1_create_users.up.sql"] = &fstest.MapFile{Data: []byte(`CREATE TABLE users (id INTEGER PRIMARY KEY);`)}
+	files["2_create_posts.up.sql"] = &fstest.MapFile{Data: []byte(`CREATE TABLE posts (id INTEGER PRIMARY KEY);`)}
+	delete(files, "3_create_tags.up.sql")
+	files["4_create_likes.up.sql"] = &fstest.MapFile{Data: []byte(`CREATE TABLE likes (id INTEGER PRIMARY KEY);`)}
+
+	err = m.Verify(ctx)
+	require.ErrorIs(t, err, database.ErrMigrationDrift)
+	var drift *database.MigrationDriftError
+	require.ErrorAs(t, err, &drift)
+	require.Equal(t, []string{"1_create_users.up.sql"}, drift.Modified)
+	require.Equal(t, []string{"3_create_tags.up.sql"}, drift.Missing)
+	require.Equal(t, []string{"2_create_posts.up.sql"}, drift.OutOfOrder)
+	files["3_create_tags.up.sql"] = &fstest.MapFile{Data: []byte(`CREATE TABLE tags (id INTEGER PRIMARY KEY);`)}
+
+	// DriftFail impede o Up; warn e ignore seguem em frente.
+	require.ErrorIs(t, m.Up(ctx), database.ErrMigrationDrift)
+	version, _, err := m.Version(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint(3), version)
+
+	require.NoError(t, newMigrator(migration.WithDriftPolicy(database.DriftWarn)).Up(ctx))
+	version, _, err = m.Version(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint(4), version)
+	require.ErrorIs(t, m.Verify(ctx), database.ErrMigrationDrift)
+
+	// Force aceita o estado atual dos arquivos.
+	require.NoError(t, m.Force(ctx, 4))
+	require.NoError(t, m.Verify(ctx))
+}
+
+func TestMigrator_DefaultRecordsNoChecksums(t *testing.T) {
+	ctx := context.Background()
+	cfg := sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: t.Name()}
+	mgr, err := manager.New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mgr.Shutdown(ctx) })
+
+	files := fstest.MapFS{
+		"1_create_users.up.sql": {Data: []byte(`CREATE TABLE users (id INTEGER PRIMARY KEY);`)},
+	}
+	m, err := migration.New(mgr, migration.EmbedFS{FS: files, Root: "."}, migration.WithDSN(cfg.MigrationDSN()))
+	require.NoError(t, err)
+	require.NoError(t, m.Up(ctx))
+
+	// Sem WithDriftPolicy a tabela de checksums não é criada.
+	var tables int
+	err = mgr.DBTX(ctx).QueryRowContext(ctx, `SELECT COUNT(*) FROM sqlite_master WHERE name = 'schema_migration_checksums'`).Scan(&tables)
+	require.NoError(t, err)
+	require.Zero(t, tables)
+}
+
+func TestManagerNew_StartupMigrationDriftPolicy(t *testing.T) {
+	cfg := sqlite.SQLiteConfig{Path: t.TempDir() + "/app.db"}
+	files := fstest.MapFS{
+		"1_create_users.up.sql": {Data: []byte(`CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL);`)},
+	}
+
+	failOnDrift := manager.WithStartupMigrationDriftPolicy(database.DriftFail)
+
+	mgr, err := manager.New(cfg, manager.WithStartupMigrationFS(files, "."), failOnDrift)
+	require.NoError(t, err)
+	require.NoError(t, mgr.Shutdown(context.Background()))
+
+	files["1_create_users.up.sql"] = &fstest.MapFile{Data: []byte(`CREATE TABLE users (id INTEGER PRIMARY KEY);`)}
+
+	_, err = manager.New(cfg, manager.WithStartupMigrationFS(files, "."), failOnDrift)
+	require.ErrorIs(t, err, database.ErrMigrationFailed)
+	require.ErrorIs(t, err, database.ErrMigrationDrift)
+
+	mgr, err = manager.New(cfg, manager.WithStartupMigrationFS(files, "."))
+	require.NoError(t, err, "sem a opção o drift não é verificado")
+	require.NoError(t, mgr.Shutdown(context.Background()))
+
+	for _, policy := range []database.DriftPolicy{database.DriftWarn, database.DriftIgnore} {
+		mgr, err = manager.New(cfg,
+			manager.WithStartupMigrationFS(files, "."),
+			manager.WithStartupMigrationDriftPolicy(policy),
+		)
+		require.NoError(t, err, policy.String())
+		require.NoError(t, mgr.Shutdown(context.Background()))
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	_, err := sqlite.New(sqlite.SQLiteConfig{}, nil)
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+}
+
+func TestCopyFrom_BatchesRowsOnPool(t *testing.T) {
+	ctx := context.Background()
+	mgr := newManager(t, sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: t.Name()})
+
+	// Mais linhas que um lote para exercitar vários INSERTs.
+	seq := func(yield func([]any, error) bool) {
+		for i := range 1234 {
+			if !yield([]any{i + 1, "user"}, nil) {
+				return
+			}
+		}
+	}
+
+	n, err := database.CopyFrom(ctx, mgr.DBTX(ctx), "users", []string{"id", "name"}, database.CopyFromSeq(seq))
+	require.NoError(t, err)
+	require.Equal(t, int64(1234), n)
+	require.Equal(t, int64(1234), countUsers(t, mgr.DBTX(ctx)))
+}
+
+func TestCopyFrom_JoinsUnitOfWorkTransaction(t *testing.T) {
+	ctx := context.Background()
+	mgr := newManager(t, sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: t.Name()})
+	boom := errors.New("boom")
+
+	_, err := uow.NewVoid(mgr).Do(ctx, func(ctx context.Context, tx database.DBTX) (struct{}, error) {
+		rows := database.CopyFromRows([][]any{{1, "ana"}, {2, "bia"}})
+		n, err := database.CopyFrom(ctx, tx, "users", []string{"id", "name"}, rows)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), n)
+		return struct{}{}, boom
+	})
+	require.ErrorIs(t, err, boom)
+	require.Zero(t, countUsers(t, mgr.DBTX(ctx)))
+}
+
+func TestCopyFrom_RejectsInvalidInput(t *testing.T) {
+	ctx := context.Background()
+	mgr := newManager(t, sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: t.Name()})
+
+	_, err := database.CopyFrom(ctx, mgr.DBTX(ctx), "users; DROP TABLE users", []string{"id"}, database.CopyFromRows(nil))
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+
+	_, err = database.CopyFrom(ctx, mgr.DBTX(ctx), "users", []string{"id", "name"}, database.CopyFromRows([][]any{{1}}))
+	require.Error(t, err)
+	require.Zero(t, countUsers(t, mgr.DBTX(ctx)))
+}
@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxOpenConns keeps a single connection: SQLite serialises writers
+	// and a larger pool only trades throughput for SQLITE_BUSY errors.
+	DefaultMaxOpenConns = 1
+
+	DefaultMaxIdleConns = 1
+
+	DefaultBusyTimeout = 5 * time.Second
+
+	DefaultMemoryName = "devkit"
+
+	// MemoryPath selects an in-memory database instead of a file.
+	MemoryPath = ":memory:"
+)
+
+type SQLiteConfig struct {
+	DSN string
+
+	// Path is the database file, or MemoryPath for an in-memory database.
+	Path string
+	// Name identifies an in-memory database. Every connection of the process
+	// opened with the same Name shares its data, which is what lets startup
+	// migrations and the pool see the same schema. Defaults to
+	// DefaultMemoryName; tests running in parallel should pick distinct names.
+	Name string
+
+	BusyTimeout        time.Duration
+	DisableForeignKeys bool
+
+	MaxOpenConns int
+	MaxIdleConns int
+	ConnMaxLife  time.Duration
+	ConnMaxIdle  time.Duration
+
+	PingTimeout time.Duration
+}
+
+type driverConfig interface {
+	driverConfigMarker()
+	Validate() error
+}
+
+var _ driverConfig = SQLiteConfig{}
+
+func (SQLiteConfig) driverConfigMarker() {}
+
+func (c SQLiteConfig) Validate() error {
+	if c.DSN != "" {
+		return nil
+	}
+
+	var errs []error
+	if c.Path == "" {
+		errs = append(errs, errors.New("sqlite: path is required"))
+	}
+	if strings.ContainsAny(c.Name, "/?#&") {
+		errs = append(errs, errors.New("sqlite: name must not contain '/', '?', '#' or '&'"))
+	}
+	if c.IsMemory() && (c.ConnMaxLife > 0 || c.ConnMaxIdle > 0) {
+		errs = append(errs, errors.New("sqlite: in-memory databases cannot expire connections"))
+	}
+	return errors.Join(errs...)
+}
+
+// IsMemory reports whether the config points to an in-memory database.
+func (c SQLiteConfig) IsMemory() bool {
+	return c.DSN == "" && c.Path == MemoryPath
+}
+
+// ResolveDSN returns the DSN understood by the modernc.org/sqlite driver.
+// In-memory databases use the memdb VFS so that every connection of the
+// process sees the same data for as long as one of them stays open.
+func (c SQLiteConfig) ResolveDSN() string {
+	if c.DSN != "" {
+		return c.DSN
+	}
+	return c.location() + "?" + c.params().Encode()
+}
+
+// MigrationDSN returns the golang-migrate URL for the database, used by
+// startup migrations and by migration.WithDSN.
+func (c SQLiteConfig) MigrationDSN() string {
+	if c.DSN != "" {
+		return "sqlite://" + c.DSN
+	}
+	return "sqlite://" + c.location() + "?" + c.params().Encode()
+}
+
+func (c SQLiteConfig) location() string {
+	if !c.IsMemory() {
+		return c.Path
+	}
+	name := c.Name
+	if name == "" {
+		name = DefaultMemoryName
+	}
+	return "file:/" + name
+}
+
+func (c SQLiteConfig) params() url.Values {
+	busy := c.BusyTimeout
+	if busy <= 0 {
+		busy = DefaultBusyTimeout
+	}
+
+	foreignKeys := "1"
+	if c.DisableForeignKeys {
+		foreignKeys = "0"
+	}
+
+	params := url.Values{}
+	if c.IsMemory() {
+		params.Set("vfs", "memdb")
+	}
+	params.Add("_pragma", "busy_timeout("+strconv.FormatInt(busy.Milliseconds(), 10)+")")
+	params.Add("_pragma", "foreign_keys("+foreignKeys+")")
+	return params
+}
@@ -0,0 +1,61 @@
+package sqlite_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database/sqlite"
+)
+
+func TestSQLiteConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     sqlite.SQLiteConfig
+		wantErr string
+	}{
+		{name: "dsn bypasses field validation", cfg: sqlite.SQLiteConfig{DSN: "app.db"}},
+		{name: "file path", cfg: sqlite.SQLiteConfig{Path: "app.db"}},
+		{name: "memory", cfg: sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: "orders"}},
+		{name: "path missing", cfg: sqlite.SQLiteConfig{}, wantErr: "path is required"},
+		{name: "invalid name", cfg: sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: "a/b"}, wantErr: "name must not contain"},
+		{
+			name:    "memory with expiring connections",
+			cfg:     sqlite.SQLiteConfig{Path: sqlite.MemoryPath, ConnMaxIdle: time.Minute},
+			wantErr: "cannot expire connections",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSQLiteConfig_ResolveDSN(t *testing.T) {
+	require.Equal(t, "custom.db?_pragma=foreign_keys(0)", sqlite.SQLiteConfig{DSN: "custom.db?_pragma=foreign_keys(0)"}.ResolveDSN())
+
+	file := sqlite.SQLiteConfig{Path: "/var/lib/app.db", BusyTimeout: time.Second}
+	require.Equal(t, "/var/lib/app.db?_pragma=busy_timeout%281000%29&_pragma=foreign_keys%281%29", file.ResolveDSN())
+
+	memory := sqlite.SQLiteConfig{Path: sqlite.MemoryPath, DisableForeignKeys: true}
+	require.Equal(t, "file:/devkit?_pragma=busy_timeout%285000%29&_pragma=foreign_keys%280%29&vfs=memdb", memory.ResolveDSN())
+}
+
+func TestSQLiteConfig_MigrationDSN(t *testing.T) {
+	require.Equal(t,
+		"sqlite:///var/lib/app.db?_pragma=busy_timeout%285000%29&_pragma=foreign_keys%281%29",
+		sqlite.SQLiteConfig{Path: "/var/lib/app.db"}.MigrationDSN(),
+	)
+	require.Equal(t,
+		"sqlite://file:/orders?_pragma=busy_timeout%285000%29&_pragma=foreign_keys%281%29&vfs=memdb",
+		sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: "orders"}.MigrationDSN(),
+	)
+}
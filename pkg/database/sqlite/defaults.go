@@ -0,0 +1,12 @@
+package sqlite
+
+import "database/sql"
+
+// applyDefaults never expires connections: an in-memory database is dropped
+// as soon as its last connection closes.
+func applyDefaults(db *sql.DB) {
+	db.SetMaxOpenConns(DefaultMaxOpenConns)
+	db.SetMaxIdleConns(DefaultMaxIdleConns)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+}
@@ -0,0 +1,18 @@
+package sqlite
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDefaults_SetsSingleConnectionPool(t *testing.T) {
+	db, err := sql.Open("sqlite", "file:/defaults?vfs=memdb")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	applyDefaults(db)
+
+	require.Equal(t, DefaultMaxOpenConns, db.Stats().MaxOpenConnections)
+}
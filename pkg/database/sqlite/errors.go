@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"errors"
+	"strings"
+
+	"modernc.org/sqlite"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+// Extended result codes, see https://sqlite.org/rescode.html.
+const (
+	errConstraintCheck      = 275
+	errConstraintForeignKey = 787
+	errConstraintNotNull    = 1299
+	errConstraintPrimaryKey = 1555
+	errConstraintUnique     = 2067
+)
+
+func init() {
+	database.RegisterErrorClassifier(classifyError)
+}
+
+func classifyError(err error) (database.ErrorInfo, bool) {
+	var liteErr *sqlite.Error
+	if !errors.As(err, &liteErr) {
+		return database.ErrorInfo{}, false
+	}
+
+	switch liteErr.Code() {
+	case errConstraintUnique, errConstraintPrimaryKey:
+		return database.ErrorInfo{Kind: database.ErrorKindUniqueViolation}, true
+	case errConstraintForeignKey:
+		return database.ErrorInfo{Kind: database.ErrorKindForeignKeyViolation}, true
+	case errConstraintNotNull:
+		return database.ErrorInfo{Kind: database.ErrorKindNotNullViolation}, true
+	case errConstraintCheck:
+		return database.ErrorInfo{
+			Kind:       database.ErrorKindCheckViolation,
+			Constraint: checkConstraintName(liteErr.Error()),
+		}, true
+	default:
+		return database.ErrorInfo{}, false
+	}
+}
+
+// checkConstraintName extracts the name from "CHECK constraint failed: name".
+// SQLite reports the columns, not the index name, for unique violations, so
+// only CHECK constraints carry a usable name.
+func checkConstraintName(message string) string {
+	_, rest, found := strings.Cut(message, "CHECK constraint failed: ")
+	if !found {
+		return ""
+	}
+	name, _, _ := strings.Cut(rest, " (")
+	return name
+}
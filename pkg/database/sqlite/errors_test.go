@@ -0,0 +1,49 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/sqlite"
+)
+
+func TestClassify_SQLiteConstraintErrors(t *testing.T) {
+	ctx := context.Background()
+	adapter, err := sqlite.New(sqlite.SQLiteConfig{Path: sqlite.MemoryPath, Name: "classify"}, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = adapter.Close(ctx) })
+
+	db := adapter.DBTX()
+	_, err = db.ExecContext(ctx, `CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL UNIQUE)`)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `CREATE TABLE orders (
+		id INTEGER PRIMARY KEY,
+		user_id INTEGER NOT NULL REFERENCES users (id),
+		total INTEGER NOT NULL CONSTRAINT orders_total_chk CHECK (total > 0)
+	)`)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `INSERT INTO users (id, email) VALUES (1, 'a@b.c')`)
+	require.NoError(t, err)
+
+	cases := []struct {
+		query      string
+		kind       database.ErrorKind
+		constraint string
+	}{
+		{`INSERT INTO users (id, email) VALUES (2, 'a@b.c')`, database.ErrorKindUniqueViolation, ""},
+		{`INSERT INTO users (id, email) VALUES (1, 'x@y.z')`, database.ErrorKindUniqueViolation, ""},
+		{`INSERT INTO users (id, email) VALUES (3, NULL)`, database.ErrorKindNotNullViolation, ""},
+		{`INSERT INTO orders (user_id, total) VALUES (99, 10)`, database.ErrorKindForeignKeyViolation, ""},
+		{`INSERT INTO orders (user_id, total) VALUES (1, 0)`, database.ErrorKindCheckViolation, "orders_total_chk"},
+	}
+	for _, tc := range cases {
+		_, err := db.ExecContext(ctx, tc.query)
+
+		require.Error(t, err, tc.query)
+		require.Equal(t, tc.kind, database.Classify(err), tc.query)
+		require.Equal(t, tc.constraint, database.ConstraintName(err), tc.query)
+	}
+}
@@ -0,0 +1,72 @@
+package tenant
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+var schemaPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// route returns a SET LOCAL statement, or a session where the change outlives the transaction.
+func (m *Manager) route(schema string) (local string, session database.Session, err error) {
+	switch m.driver {
+	case database.DriverPostgres, database.DriverCockroach:
+		return `SET LOCAL search_path TO "` + schema + `"`, nil, nil
+	case database.DriverMySQL:
+		return "", &mysqlSession{schema: schema}, nil
+	case database.DriverMSSQL:
+		return "", mssqlSession{user: schema}, nil
+	default:
+		return "", nil, fmt.Errorf("%w: tenant: unsupported driver %q", database.ErrInvalidConfig, m.driver)
+	}
+}
+
+type mysqlSession struct {
+	schema string
+	home   string
+}
+
+func (s *mysqlSession) Enter(ctx context.Context, conn database.DBTX) error {
+	var home sql.NullString
+	if err := conn.QueryRowContext(ctx, "SELECT DATABASE()").Scan(&home); err != nil {
+		return fmt.Errorf("tenant: read default database: %w", err)
+	}
+	if !home.Valid || !schemaPattern.MatchString(home.String) {
+		return fmt.Errorf("%w: tenant: mysql connections need a default database to return to", database.ErrInvalidConfig)
+	}
+	s.home = home.String
+	if _, err := conn.ExecContext(ctx, "USE `"+s.schema+"`"); err != nil {
+		return fmt.Errorf("tenant: route to %q: %w", s.schema, err)
+	}
+	return nil
+}
+
+func (s *mysqlSession) Exit(ctx context.Context, conn database.DBTX) error {
+	if _, err := conn.ExecContext(ctx, "USE `"+s.home+"`"); err != nil {
+		return fmt.Errorf("tenant: restore connection: %w", err)
+	}
+	return nil
+}
+
+// mssqlSession impersonates the tenant's database user, whose DEFAULT_SCHEMA is the tenant's.
+type mssqlSession struct {
+	user string
+}
+
+func (s mssqlSession) Enter(ctx context.Context, conn database.DBTX) error {
+	if _, err := conn.ExecContext(ctx, "EXECUTE AS USER = N'"+s.user+"'"); err != nil {
+		return fmt.Errorf("tenant: route to %q: %w", s.user, err)
+	}
+	return nil
+}
+
+func (s mssqlSession) Exit(ctx context.Context, conn database.DBTX) error {
+	if _, err := conn.ExecContext(ctx, "REVERT"); err != nil {
+		return fmt.Errorf("tenant: restore connection: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package tenant
+
+import "errors"
+
+var (
+	ErrMissingTenant = errors.New("tenant: no tenant in context")
+	ErrUnknownTenant = errors.New("tenant: tenant not allowed")
+)
@@ -0,0 +1,68 @@
+package tenant
+
+import (
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability/noop"
+)
+
+type Option func(*options)
+
+type options struct {
+	allowList     map[string]struct{}
+	allowFunc     func(tenant string) bool
+	schema        func(tenant string) string
+	optional      bool
+	observability observability.Observability
+}
+
+func defaultOptions() options {
+	return options{
+		schema:        func(tenant string) string { return tenant },
+		observability: noop.NewProvider(),
+	}
+}
+
+// WithAllowList accepts only the given tenants, alongside WithAllowFunc.
+func WithAllowList(tenants ...string) Option {
+	return func(o *options) {
+		if o.allowList == nil {
+			o.allowList = make(map[string]struct{}, len(tenants))
+		}
+		for _, t := range tenants {
+			o.allowList[t] = struct{}{}
+		}
+	}
+}
+
+// WithAllowFunc accepts the tenants for which fn, called on every operation, returns true.
+func WithAllowFunc(fn func(tenant string) bool) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.allowFunc = fn
+		}
+	}
+}
+
+// WithSchema maps a tenant to its schema, MySQL database or SQL Server user.
+func WithSchema(fn func(tenant string) string) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.schema = fn
+		}
+	}
+}
+
+// WithOptionalTenant runs operations without a tenant on the underlying manager.
+func WithOptionalTenant(optional bool) Option {
+	return func(o *options) {
+		o.optional = optional
+	}
+}
+
+func WithObservability(obs observability.Observability) Option {
+	return func(o *options) {
+		if obs != nil {
+			o.observability = obs
+		}
+	}
+}
@@ -0,0 +1,282 @@
+// Package tenant routes a manager.Manager to the schema of the tenant in the context.
+package tenant
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/internal/dialect"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/manager"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+)
+
+// Manager scopes DBTX and BeginTx to the tenant in ctx.
+type Manager struct {
+	base     manager.Manager
+	driver   database.Driver
+	opts     options
+	rejected observability.Counter
+}
+
+var _ manager.Manager = (*Manager)(nil)
+
+func New(base manager.Manager, opts ...Option) (*Manager, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	driver := base.Driver()
+	if !dialect.Supported(driver) {
+		return nil, fmt.Errorf("%w: tenant: unsupported driver %q", database.ErrInvalidConfig, driver)
+	}
+	if o.allowList == nil && o.allowFunc == nil {
+		return nil, fmt.Errorf("%w: tenant: an allow-list is required", database.ErrInvalidConfig)
+	}
+
+	return &Manager{
+		base:     base,
+		driver:   driver,
+		opts:     o,
+		rejected: o.observability.Metrics().Counter("database.tenant.rejected", "Operations refused for a missing or unknown tenant", "{operations}"),
+	}, nil
+}
+
+func (m *Manager) Driver() database.Driver { return m.driver }
+
+// DBTX returns the transaction in ctx or runs each statement in its own routed transaction.
+func (m *Manager) DBTX(ctx context.Context) database.DBTX {
+	if tx, ok := database.FromContext(ctx); ok {
+		return tx
+	}
+	return &scopedDBTX{m: m}
+}
+
+// BeginTx starts a transaction routed to the tenant of ctx.
+func (m *Manager) BeginTx(ctx context.Context, opts database.TxOptions) (database.Tx, error) {
+	schema, ok, err := m.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return m.base.BeginTx(ctx, opts)
+	}
+
+	return m.begin(ctx, schema, opts)
+}
+
+func (m *Manager) Ping(ctx context.Context) error { return m.base.Ping(ctx) }
+
+func (m *Manager) TryLock(ctx context.Context, name string) (database.Lock, bool, error) {
+	return m.base.TryLock(ctx, name)
+}
+
+func (m *Manager) Lock(ctx context.Context, name string) (database.Lock, error) {
+	return m.base.Lock(ctx, name)
+}
+
+func (m *Manager) WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	return m.base.WithLock(ctx, name, fn)
+}
+
+func (m *Manager) Shutdown(ctx context.Context) error { return m.base.Shutdown(ctx) }
+
+func (m *Manager) resolve(ctx context.Context) (schema string, ok bool, err error) {
+	tenant, found := database.TenantFromContext(ctx)
+	if !found {
+		if m.opts.optional {
+			return "", false, nil
+		}
+		m.reject(ctx, "missing")
+		return "", false, ErrMissingTenant
+	}
+	if !m.allowed(tenant) {
+		m.reject(ctx, "unknown")
+		return "", false, fmt.Errorf("%w: %q", ErrUnknownTenant, tenant)
+	}
+	schema = m.opts.schema(tenant)
+	if !schemaPattern.MatchString(schema) {
+		return "", false, fmt.Errorf("%w: tenant: %q maps to invalid schema %q", database.ErrInvalidConfig, tenant, schema)
+	}
+	return schema, true, nil
+}
+
+func (m *Manager) allowed(tenant string) bool {
+	if _, ok := m.opts.allowList[tenant]; ok {
+		return true
+	}
+	return m.opts.allowFunc != nil && m.opts.allowFunc(tenant)
+}
+
+// reject leaves the tenant out of the metric: unknown tenants would make it unbounded.
+func (m *Manager) reject(ctx context.Context, reason string) {
+	m.rejected.Increment(ctx,
+		observability.String("db.system", string(m.driver)),
+		observability.String("reason", reason),
+	)
+}
+
+func (m *Manager) begin(ctx context.Context, schema string, opts database.TxOptions) (database.Tx, error) {
+	local, session, err := m.route(schema)
+	if err != nil {
+		return nil, err
+	}
+	opts.Session = database.JoinSessions(session, opts.Session)
+
+	tx, err := m.base.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if local == "" {
+		return tx, nil
+	}
+	if _, err := tx.ExecContext(ctx, local); err != nil {
+		_ = tx.Rollback(context.WithoutCancel(ctx))
+		return nil, fmt.Errorf("tenant: route to %q: %w", schema, err)
+	}
+	return tx, nil
+}
+
+type scopedDBTX struct {
+	m *Manager
+}
+
+func (d *scopedDBTX) begin(ctx context.Context) (database.Tx, database.DBTX, error) {
+	schema, ok, err := d.m.resolve(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, d.m.base.DBTX(ctx), nil
+	}
+	tx, err := d.m.begin(ctx, schema, database.TxOptions{ReadOnly: database.IsReadOnly(ctx)})
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, tx, nil
+}
+
+func (d *scopedDBTX) ExecContext(ctx context.Context, query string, args ...any) (database.Result, error) {
+	tx, db, err := d.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := db.ExecContext(ctx, query, args...)
+	if tx == nil {
+		return result, err
+	}
+	if err != nil {
+		_ = tx.Rollback(context.WithoutCancel(ctx))
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (d *scopedDBTX) QueryContext(ctx context.Context, query string, args ...any) (database.Rows, error) {
+	tx, db, err := d.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if tx == nil {
+		return rows, err
+	}
+	if err != nil {
+		_ = tx.Rollback(context.WithoutCancel(ctx))
+		return nil, err
+	}
+	return &scopedRows{Rows: rows, ctx: ctx, tx: tx}, nil
+}
+
+func (d *scopedDBTX) QueryRowContext(ctx context.Context, query string, args ...any) database.Row {
+	tx, db, err := d.begin(ctx)
+	if err != nil {
+		return errRow{err: err}
+	}
+	row := db.QueryRowContext(ctx, query, args...)
+	if tx == nil {
+		return row
+	}
+	return &scopedRow{row: row, ctx: ctx, tx: tx}
+}
+
+func (d *scopedDBTX) CopyFrom(ctx context.Context, table string, columns []string, src database.CopySource) (int64, error) {
+	tx, db, err := d.begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	n, err := database.CopyFrom(ctx, db, table, columns, src)
+	if tx == nil {
+		return n, err
+	}
+	if err != nil {
+		_ = tx.Rollback(context.WithoutCancel(ctx))
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// scopedRows commits on Close when read without error, so INSERT ... RETURNING persists.
+type scopedRows struct {
+	database.Rows
+	ctx  context.Context
+	tx   database.Tx
+	once sync.Once
+	err  error
+}
+
+func (r *scopedRows) Columns() ([]string, error) {
+	lister, ok := r.Rows.(interface{ Columns() ([]string, error) })
+	if !ok {
+		return nil, database.ErrColumnsUnavailable
+	}
+	return lister.Columns()
+}
+
+func (r *scopedRows) Close() error {
+	closeErr := r.Rows.Close()
+	r.once.Do(func() {
+		if closeErr != nil || r.Rows.Err() != nil {
+			_ = r.tx.Rollback(context.WithoutCancel(r.ctx))
+			return
+		}
+		r.err = r.tx.Commit(r.ctx)
+	})
+	if closeErr != nil {
+		return closeErr
+	}
+	return r.err
+}
+
+// scopedRow ends its transaction on Scan; an unscanned Row holds its connection.
+type scopedRow struct {
+	row database.Row
+	ctx context.Context
+	tx  database.Tx
+}
+
+func (r *scopedRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		_ = r.tx.Rollback(context.WithoutCancel(r.ctx))
+		return err
+	}
+	if commitErr := r.tx.Commit(r.ctx); commitErr != nil {
+		return commitErr
+	}
+	return err
+}
+
+type errRow struct{ err error }
+
+func (r errRow) Scan(...any) error { return r.err }
@@ -0,0 +1,210 @@
+package tenant_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/dbtest"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/tenant"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/uow"
+)
+
+func acme() context.Context {
+	return database.WithTenant(context.Background(), "acme")
+}
+
+func newManager(t *testing.T, base *dbtest.Manager, opts ...tenant.Option) *tenant.Manager {
+	t.Helper()
+	mgr, err := tenant.New(base, append([]tenant.Option{tenant.WithAllowList("acme", "globex")}, opts...)...)
+	require.NoError(t, err)
+	return mgr
+}
+
+func queries(base *dbtest.Manager) []string {
+	var out []string
+	for _, e := range base.Statements() {
+		out = append(out, e.Query)
+	}
+	return out
+}
+
+func TestBeginTx_PostgresSetsLocalSearchPath(t *testing.T) {
+	base := dbtest.New()
+	mgr := newManager(t, base, tenant.WithSchema(func(t string) string { return "tenant_" + t }))
+
+	_, err := uow.NewVoid(mgr).Do(acme(), func(ctx context.Context, _ database.DBTX) (struct{}, error) {
+		_, err := mgr.DBTX(ctx).ExecContext(ctx, "INSERT INTO orders (id) VALUES ($1)", 1)
+		return struct{}{}, err
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		`SET LOCAL search_path TO "tenant_acme"`,
+		"INSERT INTO orders (id) VALUES ($1)",
+	}, queries(base))
+	base.AssertExecutedInTx(t, `INSERT INTO orders`)
+	base.AssertCommittedOnce(t)
+}
+
+func TestDBTX_WrapsEachStatementInRoutedTransaction(t *testing.T) {
+	base := dbtest.New()
+	base.On(`SELECT name`).Rows([]string{"name"}, []any{"a"}, []any{"b"})
+	base.On(`SELECT count`).Rows([]string{"count"}, []any{2})
+	mgr := newManager(t, base)
+	ctx := acme()
+
+	_, err := mgr.DBTX(ctx).ExecContext(ctx, "DELETE FROM carts")
+	require.NoError(t, err)
+
+	names, err := database.QueryAll[string](ctx, mgr.DBTX(ctx), "SELECT name FROM products")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, names)
+
+	var count int
+	require.NoError(t, mgr.DBTX(ctx).QueryRowContext(ctx, "SELECT count(*) FROM products").Scan(&count))
+	require.Equal(t, 2, count)
+
+	base.AssertEvents(t,
+		dbtest.EventBegin, dbtest.EventExec, dbtest.EventExec, dbtest.EventCommit,
+		dbtest.EventBegin, dbtest.EventExec, dbtest.EventQuery, dbtest.EventCommit,
+		dbtest.EventBegin, dbtest.EventExec, dbtest.EventQueryRow, dbtest.EventCommit,
+	)
+	base.AssertExecutedInTx(t, `SELECT name`)
+}
+
+func TestDBTX_RollsBackFailedStatement(t *testing.T) {
+	base := dbtest.New()
+	boom := errors.New("duplicate key")
+	base.On(`INSERT`).Err(boom)
+	mgr := newManager(t, base)
+	ctx := acme()
+
+	_, err := mgr.DBTX(ctx).ExecContext(ctx, "INSERT INTO orders (id) VALUES (1)")
+	require.ErrorIs(t, err, boom)
+	base.AssertRolledBack(t)
+	base.AssertCommitted(t, 0)
+}
+
+func TestResolve_MissingAndUnknownTenants(t *testing.T) {
+	base := dbtest.New()
+	mgr := newManager(t, base, tenant.WithAllowFunc(func(t string) bool { return t == "initech" }))
+
+	_, err := mgr.DBTX(context.Background()).ExecContext(context.Background(), "SELECT 1")
+	require.ErrorIs(t, err, tenant.ErrMissingTenant)
+
+	ctx := database.WithTenant(context.Background(), "umbrella")
+	_, err = mgr.BeginTx(ctx, database.TxOptions{})
+	require.ErrorIs(t, err, tenant.ErrUnknownTenant)
+	require.ErrorIs(t, mgr.DBTX(ctx).QueryRowContext(ctx, "SELECT 1").Scan(new(int)), tenant.ErrUnknownTenant)
+
+	_, err = mgr.BeginTx(database.WithTenant(context.Background(), "initech"), database.TxOptions{})
+	require.NoError(t, err, "a função de allow-list complementa a lista fixa")
+
+	bad := newManager(t, dbtest.New(), tenant.WithSchema(func(t string) string { return t + `"; DROP SCHEMA public; --` }))
+	_, err = bad.BeginTx(acme(), database.TxOptions{})
+	require.ErrorIs(t, err, database.ErrInvalidConfig, "o schema mapeado também é validado")
+
+	require.Equal(t, []string{`SET LOCAL search_path TO "initech"`}, queries(base), "nenhum comando chega ao banco sem tenant válido")
+}
+
+func TestResolve_OptionalTenantUsesPoolUnchanged(t *testing.T) {
+	base := dbtest.New()
+	mgr := newManager(t, base, tenant.WithOptionalTenant(true))
+
+	_, err := mgr.DBTX(context.Background()).ExecContext(context.Background(), "SELECT 1")
+	require.NoError(t, err)
+	base.AssertExecutedOutsideTx(t, `SELECT 1`)
+	base.AssertNoTransaction(t)
+}
+
+func TestBeginTx_MySQLRestoresDefaultDatabase(t *testing.T) {
+	base := dbtest.New(dbtest.WithDriver(database.DriverMySQL))
+	base.On(`SELECT DATABASE\(\)`).Rows([]string{"database"}, []any{"app"})
+	mgr := newManager(t, base)
+
+	tx, err := mgr.BeginTx(acme(), database.TxOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(context.Background()))
+
+	require.Equal(t, []string{"SELECT DATABASE()", "USE `acme`", "USE `app`"}, queries(base),
+		"o banco da conexão é lido antes do USE e restaurado depois do commit")
+	base.AssertEvents(t,
+		dbtest.EventQueryRow, dbtest.EventExec, dbtest.EventBegin, dbtest.EventCommit, dbtest.EventExec,
+	)
+	base.AssertExecutedOutsideTx(t, "USE `app`")
+}
+
+func TestBeginTx_MySQLRestoresAfterContextCancelled(t *testing.T) {
+	base := dbtest.New(dbtest.WithDriver(database.DriverMySQL))
+	base.On(`SELECT DATABASE\(\)`).Rows([]string{"database"}, []any{"app"})
+	mgr := newManager(t, base)
+	ctx, cancel := context.WithCancel(acme())
+
+	_, err := uow.NewVoid(mgr).Do(ctx, func(ctx context.Context, tx database.DBTX) (struct{}, error) {
+		cancel()
+		require.Eventually(t, func() bool {
+			_, err := tx.ExecContext(ctx, "UPDATE orders SET total = 0")
+			return errors.Is(err, sql.ErrTxDone)
+		}, time.Second, time.Millisecond, "o ctx cancelado desfaz a transação por conta própria")
+		return struct{}{}, ctx.Err()
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	events := base.Events()
+	last := events[len(events)-1]
+	require.Equal(t, "USE `app`", last.Query,
+		"a conexão volta ao banco padrão mesmo quando o rollback não passou pelo chamador")
+	require.Equal(t, dbtest.EventRollback, events[len(events)-2].Kind, "o banco é restaurado depois do rollback")
+	base.AssertRolledBack(t)
+}
+
+func TestBeginTx_MSSQLRevertsAfterRollback(t *testing.T) {
+	base := dbtest.New(dbtest.WithDriver(database.DriverMSSQL))
+	mgr := newManager(t, base)
+
+	tx, err := mgr.BeginTx(acme(), database.TxOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback(context.Background()))
+
+	require.Equal(t, []string{"EXECUTE AS USER = N'acme'", "REVERT"}, queries(base))
+	base.AssertEvents(t, dbtest.EventExec, dbtest.EventBegin, dbtest.EventRollback, dbtest.EventExec)
+}
+
+func TestBeginTx_DiscardsConnectionWhenRestoreFails(t *testing.T) {
+	base := dbtest.New(dbtest.WithDriver(database.DriverMSSQL))
+	base.On(`REVERT`).Err(errors.New("cannot revert"))
+	mgr := newManager(t, base)
+
+	tx, err := mgr.BeginTx(acme(), database.TxOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(context.Background()), "o commit já aconteceu; a falha só afeta a conexão")
+
+	base.AssertCommittedOnce(t)
+	base.AssertEvents(t, dbtest.EventExec, dbtest.EventBegin, dbtest.EventCommit, dbtest.EventExec, dbtest.EventDiscard)
+}
+
+func TestBeginTx_RouteFailureDiscardsConnection(t *testing.T) {
+	base := dbtest.New(dbtest.WithDriver(database.DriverMSSQL))
+	denied := errors.New("cannot execute as the database principal")
+	base.On(`EXECUTE AS`).Err(denied)
+	mgr := newManager(t, base)
+
+	_, err := mgr.BeginTx(acme(), database.TxOptions{})
+	require.ErrorIs(t, err, denied)
+	base.AssertNoTransaction(t)
+	base.AssertEvents(t, dbtest.EventExec, dbtest.EventDiscard)
+}
+
+func TestNew_Validation(t *testing.T) {
+	_, err := tenant.New(dbtest.New())
+	require.ErrorIs(t, err, database.ErrInvalidConfig, "sem allow-list todo tenant seria aceito")
+
+	_, err = tenant.New(dbtest.New(dbtest.WithDriver(database.DriverSQLite)), tenant.WithAllowList("acme"))
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
+}
@@ -0,0 +1,27 @@
+package uow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_WithDefaults(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3}.withDefaults()
+
+	require.Equal(t, DefaultRetryInitialBackoff, p.InitialBackoff)
+	require.Equal(t, DefaultRetryMaxBackoff, p.MaxBackoff)
+	require.NotNil(t, p.Retryable)
+}
+
+func TestRetryPolicy_BackoffIsBoundedByMaxBackoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 50, InitialBackoff: time.Millisecond, MaxBackoff: 8 * time.Millisecond}.withDefaults()
+
+	for attempt := 1; attempt <= 50; attempt++ {
+		d := p.backoff(attempt)
+		require.GreaterOrEqual(t, d, time.Duration(0))
+		require.Less(t, d, 8*time.Millisecond)
+	}
+	require.Less(t, p.backoff(1), time.Millisecond)
+}
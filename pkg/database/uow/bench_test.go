@@ -45,6 +45,15 @@ func (m *benchManager) BeginTx(_ context.Context, _ database.TxOptions) (databas
 }
 func (m *benchManager) Ping(_ context.Context) error     { return nil }
 func (m *benchManager) Shutdown(_ context.Context) error { return nil }
+func (m *benchManager) TryLock(_ context.Context, _ string) (database.Lock, bool, error) {
+	return nil, false, database.ErrLockUnsupported
+}
+func (m *benchManager) Lock(_ context.Context, _ string) (database.Lock, error) {
+	return nil, database.ErrLockUnsupported
+}
+func (m *benchManager) WithLock(_ context.Context, _ string, _ func(context.Context) error) error {
+	return database.ErrLockUnsupported
+}
 
 var benchResult string
 
@@ -0,0 +1,208 @@
+package uow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+)
+
+var (
+	// ErrNoTransaction is returned when a hook is registered outside Do.
+	ErrNoTransaction = errors.New("uow: no transaction in context")
+	// ErrHookFailed wraps after-hook failures returned with WithHookErrors(true).
+	ErrHookFailed = errors.New("uow: transaction hook failed")
+)
+
+type Hook func(ctx context.Context) error
+
+type hookPhase string
+
+const (
+	phaseBeforeCommit  hookPhase = "before_commit"
+	phaseAfterCommit   hookPhase = "after_commit"
+	phaseAfterRollback hookPhase = "after_rollback"
+)
+
+// BeforeCommit runs fn inside the transaction; an error rolls it back.
+func BeforeCommit(ctx context.Context, fn Hook) error {
+	return register(ctx, phaseBeforeCommit, fn)
+}
+
+// AfterCommit runs fn once the transaction has committed.
+func AfterCommit(ctx context.Context, fn Hook) error {
+	return register(ctx, phaseAfterCommit, fn)
+}
+
+// AfterRollback runs fn once the transaction or its savepoint rolled back.
+func AfterRollback(ctx context.Context, fn Hook) error {
+	return register(ctx, phaseAfterRollback, fn)
+}
+
+func register(ctx context.Context, phase hookPhase, fn Hook) error {
+	h, ok := hooksFromContext(ctx)
+	if !ok {
+		return ErrNoTransaction
+	}
+	if fn == nil {
+		return nil
+	}
+	h.add(phase, fn)
+	return nil
+}
+
+type hooksKey struct{}
+
+type txHooks struct {
+	mu            sync.Mutex
+	beforeCommit  []Hook
+	afterCommit   []Hook
+	afterRollback []Hook
+	failures      []error
+}
+
+func withHooks(ctx context.Context, h *txHooks) context.Context {
+	return context.WithValue(ctx, hooksKey{}, h)
+}
+
+func hooksFromContext(ctx context.Context) (*txHooks, bool) {
+	h, ok := ctx.Value(hooksKey{}).(*txHooks)
+	return h, ok
+}
+
+func (h *txHooks) add(phase hookPhase, fn Hook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch phase {
+	case phaseBeforeCommit:
+		h.beforeCommit = append(h.beforeCommit, fn)
+	case phaseAfterCommit:
+		h.afterCommit = append(h.afterCommit, fn)
+	case phaseAfterRollback:
+		h.afterRollback = append(h.afterRollback, fn)
+	}
+}
+
+// next reads by index so hooks registered by a running hook also run.
+func (h *txHooks) next(phase hookPhase, i int) (Hook, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var list []Hook
+	switch phase {
+	case phaseBeforeCommit:
+		list = h.beforeCommit
+	case phaseAfterCommit:
+		list = h.afterCommit
+	case phaseAfterRollback:
+		list = h.afterRollback
+	}
+	if i >= len(list) {
+		return nil, false
+	}
+	return list[i], true
+}
+
+// merge hands the hooks of a released savepoint to the enclosing transaction.
+func (h *txHooks) merge(child *txHooks) {
+	child.mu.Lock()
+	defer child.mu.Unlock()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.beforeCommit = append(h.beforeCommit, child.beforeCommit...)
+	h.afterCommit = append(h.afterCommit, child.afterCommit...)
+	h.afterRollback = append(h.afterRollback, child.afterRollback...)
+	h.failures = append(h.failures, child.failures...)
+}
+
+func (h *txHooks) fail(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.failures = append(h.failures, err)
+}
+
+func (h *txHooks) err() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrHookFailed, errors.Join(h.failures...))
+}
+
+func (u *unitOfWork[T]) runBeforeCommit(ctx context.Context, span observability.Span, h *txHooks, metricAttrs []observability.Field) error {
+	for i := 0; ; i++ {
+		fn, ok := h.next(phaseBeforeCommit, i)
+		if !ok {
+			return nil
+		}
+		if err := callHook(ctx, fn); err != nil {
+			u.recordHookFailure(ctx, span, phaseBeforeCommit, i, err, metricAttrs)
+			return fmt.Errorf("uow: before commit hook: %w", err)
+		}
+	}
+}
+
+func (u *unitOfWork[T]) runAfterHooks(ctx context.Context, span observability.Span, h *txHooks, phase hookPhase, metricAttrs []observability.Field) {
+	for i := 0; ; i++ {
+		fn, ok := h.next(phase, i)
+		if !ok {
+			return
+		}
+		if err := callHook(ctx, fn); err != nil {
+			u.recordHookFailure(ctx, span, phase, i, err, metricAttrs)
+			h.fail(fmt.Errorf("%s hook %d: %w", phase, i, err))
+		}
+	}
+}
+
+func (u *unitOfWork[T]) recordHookFailure(ctx context.Context, span observability.Span, phase hookPhase, index int, err error, metricAttrs []observability.Field) {
+	u.txHookFailures.Increment(ctx, append(metricAttrs, observability.String("phase", string(phase)))...)
+	span.RecordError(err)
+	span.AddEvent(
+		"db.tx.hook_failed",
+		observability.String("db.tx.hook.phase", string(phase)),
+		observability.Int("db.tx.hook.index", index),
+	)
+	u.opts.observability.Logger().Error(
+		ctx,
+		"uow: transaction hook failed",
+		observability.String("operation", "uow."+string(phase)+"_hook"),
+		observability.String("layer", "database"),
+		observability.String("entity", "uow"),
+		observability.Int("hook_index", index),
+		observability.Error(err),
+	)
+}
+
+// discardSavepointHooks runs the AfterRollback hooks of a rolled back savepoint.
+func (u *unitOfWork[T]) discardSavepointHooks(ctx context.Context, span observability.Span, parent, child *txHooks) {
+	u.runAfterHooks(ctx, span, child, phaseAfterRollback, []observability.Field{
+		observability.String("db.system", string(u.driver)),
+	})
+
+	child.mu.Lock()
+	failures := child.failures
+	child.mu.Unlock()
+	for _, err := range failures {
+		parent.fail(err)
+	}
+}
+
+// callHook turns a panic into an error so one hook cannot skip the others.
+func callHook(ctx context.Context, fn Hook) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if panicErr, ok := r.(error); ok {
+				err = fmt.Errorf("panic: %w", panicErr)
+				return
+			}
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
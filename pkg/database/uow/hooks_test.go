@@ -0,0 +1,185 @@
+package uow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/uow"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability/fake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHooks_AfterCommitRunsInOrderAfterCommit(t *testing.T) {
+	tx := &fakeTx{}
+	u := uow.New[string](&fakeManager{tx: tx})
+
+	var calls []string
+	result, err := u.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
+		require.NoError(t, uow.AfterCommit(ctx, func(context.Context) error {
+			require.True(t, tx.committed, "o hook só roda depois do commit")
+			calls = append(calls, "first")
+			return nil
+		}))
+		require.NoError(t, uow.AfterCommit(ctx, func(context.Context) error {
+			calls = append(calls, "second")
+			return nil
+		}))
+		require.NoError(t, uow.AfterRollback(ctx, func(context.Context) error {
+			calls = append(calls, "rollback")
+			return nil
+		}))
+		require.Empty(t, calls, "nenhum hook roda antes do fim da transação")
+		return "ok", nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "ok", result)
+	require.Equal(t, []string{"first", "second"}, calls)
+}
+
+func TestHooks_AfterRollbackRunsOnError(t *testing.T) {
+	fnErr := errors.New("fn failed")
+	tx := &fakeTx{}
+	u := uow.New[string](&fakeManager{tx: tx})
+
+	var calls []string
+	_, err := u.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
+		_ = uow.AfterCommit(ctx, func(context.Context) error {
+			calls = append(calls, "commit")
+			return nil
+		})
+		_ = uow.AfterRollback(ctx, func(context.Context) error {
+			require.True(t, tx.rolledBack)
+			calls = append(calls, "rollback")
+			return nil
+		})
+		return "", fnErr
+	})
+
+	require.ErrorIs(t, err, fnErr)
+	require.Equal(t, []string{"rollback"}, calls)
+}
+
+func TestHooks_BeforeCommitFailureRollsBack(t *testing.T) {
+	hookErr := errors.New("invariante violada")
+	tx := &fakeTx{}
+	u := uow.New[string](&fakeManager{tx: tx})
+
+	rolledBack := false
+	_, err := u.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
+		_ = uow.BeforeCommit(ctx, func(ctx context.Context) error {
+			_, ok := database.FromContext(ctx)
+			require.True(t, ok, "BeforeCommit roda dentro da transação")
+			return hookErr
+		})
+		_ = uow.AfterRollback(ctx, func(context.Context) error {
+			rolledBack = true
+			return nil
+		})
+		return "ok", nil
+	})
+
+	require.ErrorIs(t, err, hookErr)
+	require.False(t, tx.committed)
+	require.True(t, tx.rolledBack)
+	require.True(t, rolledBack)
+}
+
+func TestHooks_FailuresAreLoggedTracedAndOptionallySurfaced(t *testing.T) {
+	hookErr := errors.New("cache indisponível")
+	fn := func(ctx context.Context, _ database.DBTX) (string, error) {
+		_ = uow.AfterCommit(ctx, func(context.Context) error { return hookErr })
+		_ = uow.AfterCommit(ctx, func(context.Context) error { panic("boom") })
+		return "ok", nil
+	}
+
+	obs := fake.NewProvider()
+	u := uow.New[string](&fakeManager{txFactory: func() database.Tx { return &fakeTx{} }}, uow.WithObservability(obs))
+
+	result, err := u.Do(context.Background(), fn)
+	require.NoError(t, err, "por padrão falhas de hooks não chegam ao chamador")
+	require.Equal(t, "ok", result)
+
+	require.Len(t, obs.Logger().(*fake.FakeLogger).GetEntries(), 2)
+	require.Len(t, obs.Metrics().(*fake.FakeMetrics).GetCounter("database.tx.hook_failures").GetValues(), 2)
+	spans := obs.Tracer().(*fake.FakeTracer).GetSpans()
+	require.Len(t, spans, 1)
+	require.Len(t, spans[0].Events, 2)
+	require.Equal(t, "db.tx.hook_failed", spans[0].Events[0].Name)
+
+	result, err = u.Do(context.Background(), fn, uow.WithHookErrors(true))
+	require.ErrorIs(t, err, uow.ErrHookFailed)
+	require.ErrorIs(t, err, hookErr)
+	require.ErrorContains(t, err, "panic: boom")
+	require.Equal(t, "ok", result, "o commit aconteceu, então o resultado é devolvido")
+}
+
+func TestHooks_RegisterOutsideTransaction(t *testing.T) {
+	noop := func(context.Context) error { return nil }
+	require.ErrorIs(t, uow.AfterCommit(context.Background(), noop), uow.ErrNoTransaction)
+	require.ErrorIs(t, uow.AfterRollback(context.Background(), noop), uow.ErrNoTransaction)
+	require.ErrorIs(t, uow.BeforeCommit(context.Background(), noop), uow.ErrNoTransaction)
+}
+
+func TestHooks_SavepointScopesHooks(t *testing.T) {
+	tx := &fakeTx{}
+	mgr := &fakeManager{tx: tx}
+	outer := uow.NewVoid(mgr)
+	inner := uow.NewVoid(mgr)
+
+	var calls []string
+	record := func(name string) uow.Hook {
+		return func(context.Context) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+
+	_, err := outer.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (struct{}, error) {
+		_, _ = inner.Do(ctx, func(ctx context.Context, _ database.DBTX) (struct{}, error) {
+			_ = uow.AfterCommit(ctx, record("failed savepoint commit"))
+			_ = uow.AfterRollback(ctx, record("failed savepoint rollback"))
+			return struct{}{}, errors.New("inner failed")
+		})
+		require.Equal(t, []string{"failed savepoint rollback"}, calls, "o rollback do savepoint dispara seus hooks na hora")
+
+		_, err := inner.Do(ctx, func(ctx context.Context, _ database.DBTX) (struct{}, error) {
+			return struct{}{}, uow.AfterCommit(ctx, record("released savepoint commit"))
+		})
+		require.NoError(t, err)
+		return struct{}{}, uow.AfterCommit(ctx, record("outer commit"))
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"failed savepoint rollback", "released savepoint commit", "outer commit"}, calls)
+}
+
+func TestHooks_RetryRunsAfterCommitOnlyForCommittedAttempt(t *testing.T) {
+	mgr := &fakeManager{txFactory: func() database.Tx { return &fakeTx{} }}
+	u := uow.New[int](mgr, uow.WithRetry(transientPolicy(3)))
+
+	var committed, rolledBack []int
+	attempt := 0
+	_, err := u.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (int, error) {
+		attempt++
+		n := attempt
+		_ = uow.AfterCommit(ctx, func(context.Context) error {
+			committed = append(committed, n)
+			return nil
+		})
+		_ = uow.AfterRollback(ctx, func(context.Context) error {
+			rolledBack = append(rolledBack, n)
+			return nil
+		})
+		if n == 1 {
+			return 0, errTransient
+		}
+		return n, nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []int{2}, committed)
+	require.Equal(t, []int{1}, rolledBack)
+}
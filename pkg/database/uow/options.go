@@ -2,6 +2,7 @@ package uow
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/JailtonJunior94/devkit-go/pkg/database"
 	"github.com/JailtonJunior94/devkit-go/pkg/observability"
@@ -13,12 +14,17 @@ type Option func(*options)
 type options struct {
 	isolation     database.IsolationLevel
 	readOnly      bool
+	propagation   Propagation
+	retry         RetryPolicy
+	hookErrors    bool
+	timeouts      txTimeouts
 	observability observability.Observability
 }
 
 func defaultOptions() options {
 	return options{
 		isolation:     database.LevelDefault,
+		retry:         noRetry(),
 		observability: noop.NewProvider(),
 	}
 }
@@ -43,6 +49,62 @@ func WithReadOnly(readOnly bool) Option {
 	}
 }
 
+func WithPropagation(p Propagation) Option {
+	return func(o *options) {
+		o.propagation = p
+	}
+}
+
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *options) {
+		if policy.MaxAttempts > 0 {
+			o.retry = policy.withDefaults()
+		}
+	}
+}
+
+func WithHookErrors(surface bool) Option {
+	return func(o *options) {
+		o.hookErrors = surface
+	}
+}
+
+// WithStatementTimeout aborts statements running longer than d (postgres, cockroach, MySQL SELECT).
+func WithStatementTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.timeouts.statement = d
+		}
+	}
+}
+
+// WithLockTimeout fails statements waiting longer than d for a lock.
+func WithLockTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.timeouts.lock = d
+		}
+	}
+}
+
+// WithIdleInTransactionTimeout lets the server end a transaction idle for d (postgres, cockroach, MySQL).
+func WithIdleInTransactionTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.timeouts.idle = d
+		}
+	}
+}
+
+// WithTxDeadline cancels and rolls back an attempt running longer than d.
+func WithTxDeadline(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.timeouts.deadline = d
+		}
+	}
+}
+
 func toIsolationLevel(level sql.IsolationLevel) database.IsolationLevel {
 	switch level {
 	case sql.LevelReadUncommitted:
@@ -98,3 +98,37 @@ func TestDo_CallOptionsOverrideConstructorDefaultsWithoutMutatingThem(t *testing
 	require.Equal(t, database.LevelReadCommitted, mgr.lastOpts.Isolation)
 	require.False(t, mgr.lastOpts.ReadOnly)
 }
+
+func TestWithPropagation_ConstructorDefaultAppliesToNestedCalls(t *testing.T) {
+	tx := &fakeTx{}
+	u := uow.New[string](&fakeManager{tx: tx}, uow.WithPropagation(uow.PropagationJoin))
+
+	_, err := u.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
+		return u.Do(ctx, func(_ context.Context, _ database.DBTX) (string, error) {
+			return "", nil
+		})
+	})
+
+	require.NoError(t, err)
+	require.Empty(t, tx.execs, "join não deve emitir SAVEPOINT")
+}
+
+func TestPropagation_String(t *testing.T) {
+	require.Equal(t, "savepoint", uow.PropagationSavepoint.String())
+	require.Equal(t, "join", uow.PropagationJoin.String())
+	require.Equal(t, "requires_new", uow.PropagationRequiresNew.String())
+	require.Equal(t, "Propagation(9)", uow.Propagation(9).String())
+}
+
+func TestWithRetry_ZeroMaxAttemptsIgnored(t *testing.T) {
+	mgr := &fakeManager{txFactory: func() database.Tx { return &fakeTx{} }}
+	u := uow.New[string](mgr, uow.WithRetry(uow.RetryPolicy{Retryable: func(error) bool { return true }}))
+
+	calls := 0
+	_, _ = u.Do(context.Background(), func(_ context.Context, _ database.DBTX) (string, error) {
+		calls++
+		return "", errTransient
+	})
+
+	require.Equal(t, 1, calls)
+}
@@ -0,0 +1,65 @@
+package uow
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+type Propagation int
+
+const (
+	PropagationSavepoint Propagation = iota
+	PropagationJoin
+	PropagationRequiresNew
+)
+
+func (p Propagation) String() string {
+	switch p {
+	case PropagationSavepoint:
+		return "savepoint"
+	case PropagationJoin:
+		return "join"
+	case PropagationRequiresNew:
+		return "requires_new"
+	default:
+		return "Propagation(" + strconv.Itoa(int(p)) + ")"
+	}
+}
+
+type savepointDepthKey struct{}
+
+func savepointDepth(ctx context.Context) int {
+	depth, _ := ctx.Value(savepointDepthKey{}).(int)
+	return depth
+}
+
+func withSavepointDepth(ctx context.Context, depth int) context.Context {
+	return context.WithValue(ctx, savepointDepthKey{}, depth)
+}
+
+func savepointName(depth int) string {
+	return "uow_sp_" + strconv.Itoa(depth)
+}
+
+func savepointSQL(driver database.Driver, name string) string {
+	if driver == database.DriverMSSQL {
+		return "SAVE TRANSACTION " + name
+	}
+	return "SAVEPOINT " + name
+}
+
+func releaseSavepointSQL(driver database.Driver, name string) string {
+	if driver == database.DriverMSSQL {
+		return ""
+	}
+	return "RELEASE SAVEPOINT " + name
+}
+
+func rollbackToSavepointSQL(driver database.Driver, name string) string {
+	if driver == database.DriverMSSQL {
+		return "ROLLBACK TRANSACTION " + name
+	}
+	return "ROLLBACK TO SAVEPOINT " + name
+}
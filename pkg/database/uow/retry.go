@@ -0,0 +1,78 @@
+package uow
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+const (
+	DefaultRetryInitialBackoff = 10 * time.Millisecond
+	DefaultRetryMaxBackoff     = time.Second
+)
+
+// RetryPolicy controls how Do re-runs the whole closure in a fresh transaction
+// when it fails with a transient error. Retryable defaults to
+// database.IsRetryable, which recognises serialization failures and deadlocks
+// reported by the registered drivers.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Retryable      func(err error) bool
+}
+
+func noRetry() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1, Retryable: database.IsRetryable}
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 1
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultRetryInitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = DefaultRetryMaxBackoff
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = p.InitialBackoff
+	}
+	if p.Retryable == nil {
+		p.Retryable = database.IsRetryable
+	}
+	return p
+}
+
+// backoff returns a full-jitter exponential delay for the given (1-based)
+// failed attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	exponential := p.InitialBackoff
+	for i := 1; i < attempt && exponential < p.MaxBackoff; i++ {
+		exponential *= 2
+	}
+	exponential = min(exponential, p.MaxBackoff)
+	if exponential <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(exponential)))
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,199 @@
+package uow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/uow"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability/fake"
+	"github.com/stretchr/testify/require"
+)
+
+var errTransient = errors.New("serialization failure")
+
+func transientPolicy(maxAttempts int) uow.RetryPolicy {
+	return uow.RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: time.Microsecond,
+		MaxBackoff:     time.Microsecond,
+		Retryable:      func(err error) bool { return errors.Is(err, errTransient) },
+	}
+}
+
+func TestUnitOfWork_Do_Retry_RerunsClosureInFreshTx(t *testing.T) {
+	var txs []*fakeTx
+	mgr := &fakeManager{txFactory: func() database.Tx {
+		tx := &fakeTx{}
+		txs = append(txs, tx)
+		return tx
+	}}
+	u := uow.New[string](mgr, uow.WithRetry(transientPolicy(3)))
+
+	calls := 0
+	result, err := u.Do(context.Background(), func(_ context.Context, _ database.DBTX) (string, error) {
+		calls++
+		if calls < 3 {
+			return "", errTransient
+		}
+		return "ok", nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "ok", result)
+	require.Equal(t, 3, calls)
+	require.Equal(t, 3, mgr.beginCalls)
+	require.True(t, txs[0].rolledBack)
+	require.True(t, txs[1].rolledBack)
+	require.True(t, txs[2].committed)
+}
+
+func TestUnitOfWork_Do_Retry_StopsAtMaxAttempts(t *testing.T) {
+	mgr := &fakeManager{txFactory: func() database.Tx { return &fakeTx{} }}
+	u := uow.New[string](mgr, uow.WithRetry(transientPolicy(2)))
+
+	calls := 0
+	_, err := u.Do(context.Background(), func(_ context.Context, _ database.DBTX) (string, error) {
+		calls++
+		return "", errTransient
+	})
+
+	require.ErrorIs(t, err, errTransient)
+	require.Equal(t, 2, calls)
+}
+
+func TestUnitOfWork_Do_Retry_NonRetryableErrorNotRetried(t *testing.T) {
+	mgr := &fakeManager{txFactory: func() database.Tx { return &fakeTx{} }}
+	u := uow.New[string](mgr, uow.WithRetry(transientPolicy(5)))
+
+	calls := 0
+	_, err := u.Do(context.Background(), func(_ context.Context, _ database.DBTX) (string, error) {
+		calls++
+		return "", errors.New("constraint violated")
+	})
+
+	require.Error(t, err)
+	require.Equal(t, 1, calls)
+}
+
+func TestUnitOfWork_Do_Retry_RetryableCommitErrorRetried(t *testing.T) {
+	attempt := 0
+	mgr := &fakeManager{txFactory: func() database.Tx {
+		attempt++
+		if attempt == 1 {
+			return &fakeTx{commitErr: errTransient}
+		}
+		return &fakeTx{}
+	}}
+	u := uow.New[string](mgr, uow.WithRetry(transientPolicy(3)))
+
+	calls := 0
+	result, err := u.Do(context.Background(), func(_ context.Context, _ database.DBTX) (string, error) {
+		calls++
+		return "ok", nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "ok", result)
+	require.Equal(t, 2, calls)
+}
+
+func TestUnitOfWork_Do_Retry_NotAppliedByDefault(t *testing.T) {
+	mgr := &fakeManager{txFactory: func() database.Tx { return &fakeTx{} }}
+	u := uow.New[string](mgr)
+
+	calls := 0
+	_, err := u.Do(context.Background(), func(_ context.Context, _ database.DBTX) (string, error) {
+		calls++
+		return "", errTransient
+	})
+
+	require.Error(t, err)
+	require.Equal(t, 1, calls)
+}
+
+func TestUnitOfWork_Do_Retry_PerCallOptionOverridesDefault(t *testing.T) {
+	mgr := &fakeManager{txFactory: func() database.Tx { return &fakeTx{} }}
+	u := uow.New[string](mgr)
+
+	calls := 0
+	_, _ = u.Do(context.Background(), func(_ context.Context, _ database.DBTX) (string, error) {
+		calls++
+		return "", errTransient
+	}, uow.WithRetry(transientPolicy(4)))
+
+	require.Equal(t, 4, calls)
+}
+
+func TestUnitOfWork_Do_Retry_NestedSavepointNeverRetried(t *testing.T) {
+	mgr := &fakeManager{txFactory: func() database.Tx { return &fakeTx{} }}
+	u := uow.New[string](mgr, uow.WithRetry(transientPolicy(3)))
+
+	outerCalls, innerCalls := 0, 0
+	_, err := u.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
+		outerCalls++
+		_, innerErr := u.Do(ctx, func(_ context.Context, _ database.DBTX) (string, error) {
+			innerCalls++
+			return "", errTransient
+		})
+		return "", innerErr
+	})
+
+	require.ErrorIs(t, err, errTransient)
+	// Apenas a transação de topo é reexecutada; o savepoint roda uma vez por tentativa.
+	require.Equal(t, 3, outerCalls)
+	require.Equal(t, 3, innerCalls)
+}
+
+func TestUnitOfWork_Do_Retry_CancelledCtxStopsBackoff(t *testing.T) {
+	mgr := &fakeManager{txFactory: func() database.Tx { return &fakeTx{} }}
+	policy := transientPolicy(10)
+	policy.InitialBackoff = time.Hour
+	policy.MaxBackoff = time.Hour
+	u := uow.New[string](mgr, uow.WithRetry(policy))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := u.Do(ctx, func(_ context.Context, _ database.DBTX) (string, error) {
+		calls++
+		cancel()
+		return "", errTransient
+	})
+
+	require.ErrorIs(t, err, errTransient)
+	require.Equal(t, 1, calls)
+}
+
+func TestUnitOfWork_Do_Retry_RecordsAttemptsInSpanAndMetrics(t *testing.T) {
+	obs := fake.NewProvider()
+	mgr := &fakeManager{txFactory: func() database.Tx { return &fakeTx{} }}
+	u := uow.New[string](mgr, uow.WithObservability(obs), uow.WithRetry(transientPolicy(3)))
+
+	calls := 0
+	_, err := u.Do(context.Background(), func(_ context.Context, _ database.DBTX) (string, error) {
+		calls++
+		if calls == 1 {
+			return "", errTransient
+		}
+		return "ok", nil
+	})
+	require.NoError(t, err)
+
+	retries := obs.Metrics().(*fake.FakeMetrics).GetCounter("database.tx.retries")
+	require.NotNil(t, retries)
+	require.Len(t, retries.GetValues(), 1)
+
+	spans := obs.Tracer().(*fake.FakeTracer).GetSpans()
+	require.Len(t, spans, 1)
+	var attempts int64
+	for _, f := range spans[0].Attributes {
+		if f.Key == "db.tx.attempts" {
+			attempts = f.Int64Value()
+		}
+	}
+	require.Equal(t, int64(2), attempts)
+	require.Len(t, spans[0].Events, 1)
+	require.Equal(t, "db.tx.retry", spans[0].Events[0].Name)
+}
@@ -0,0 +1,144 @@
+package uow
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+)
+
+type txTimeouts struct {
+	statement time.Duration
+	lock      time.Duration
+	idle      time.Duration
+	deadline  time.Duration
+}
+
+// timeoutStatements returns the SET LOCAL statements for driver and, where
+// the setting outlives the transaction, a session that restores it.
+func timeoutStatements(driver database.Driver, t txTimeouts) (local []string, session database.Session) {
+	switch driver {
+	case database.DriverPostgres, database.DriverCockroach:
+		if t.statement > 0 {
+			local = append(local, "SET LOCAL statement_timeout = "+millis(t.statement))
+		}
+		if t.lock > 0 {
+			local = append(local, "SET LOCAL lock_timeout = "+millis(t.lock))
+		}
+		if t.idle > 0 {
+			local = append(local, "SET LOCAL idle_in_transaction_session_timeout = "+millis(t.idle))
+		}
+	case database.DriverMySQL:
+		var s sessionVars
+		if t.lock > 0 {
+			s.add("innodb_lock_wait_timeout", seconds(t.lock))
+		}
+		if t.statement > 0 {
+			s.add("max_execution_time", millis(t.statement))
+		}
+		if t.idle > 0 {
+			s.add("wait_timeout", seconds(t.idle))
+		}
+		if len(s.names) > 0 {
+			session = &s
+		}
+	case database.DriverMSSQL:
+		if t.lock > 0 {
+			session = &lockTimeout{value: millis(t.lock)}
+		}
+	}
+	return local, session
+}
+
+// millis rounds up so a sub-millisecond timeout does not become "no timeout".
+func millis(d time.Duration) string {
+	return strconv.FormatInt(int64((d+time.Millisecond-1)/time.Millisecond), 10)
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
+}
+
+// applyTimeouts runs the statements scoped to tx.
+func (u *unitOfWork[T]) applyTimeouts(ctx context.Context, tx database.Tx, local []string) error {
+	for _, stmt := range local {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("uow: apply timeouts: %w", err)
+		}
+	}
+	return nil
+}
+
+// sessionVars sets MySQL session variables and restores the connection's values.
+type sessionVars struct {
+	names    []string
+	values   []string
+	previous []int64
+}
+
+func (s *sessionVars) add(name, value string) {
+	s.names = append(s.names, name)
+	s.values = append(s.values, value)
+}
+
+func (s *sessionVars) Enter(ctx context.Context, conn database.DBTX) error {
+	reads := make([]string, len(s.names))
+	s.previous = make([]int64, len(s.names))
+	dest := make([]any, len(s.names))
+	for i, name := range s.names {
+		reads[i] = "@@SESSION." + name
+		dest[i] = &s.previous[i]
+	}
+	if err := conn.QueryRowContext(ctx, "SELECT "+strings.Join(reads, ", ")).Scan(dest...); err != nil {
+		return fmt.Errorf("uow: read session timeouts: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, s.assign(s.values)); err != nil {
+		return fmt.Errorf("uow: apply timeouts: %w", err)
+	}
+	return nil
+}
+
+func (s *sessionVars) Exit(ctx context.Context, conn database.DBTX) error {
+	previous := make([]string, len(s.previous))
+	for i, v := range s.previous {
+		previous[i] = strconv.FormatInt(v, 10)
+	}
+	if _, err := conn.ExecContext(ctx, s.assign(previous)); err != nil {
+		return fmt.Errorf("uow: reset session timeouts: %w", err)
+	}
+	return nil
+}
+
+func (s *sessionVars) assign(values []string) string {
+	parts := make([]string, len(s.names))
+	for i, name := range s.names {
+		parts[i] = name + " = " + values[i]
+	}
+	return "SET SESSION " + strings.Join(parts, ", ")
+}
+
+// lockTimeout sets SQL Server's LOCK_TIMEOUT and restores the previous one.
+type lockTimeout struct {
+	value    string
+	previous int64
+}
+
+func (l *lockTimeout) Enter(ctx context.Context, conn database.DBTX) error {
+	if err := conn.QueryRowContext(ctx, "SELECT @@LOCK_TIMEOUT").Scan(&l.previous); err != nil {
+		return fmt.Errorf("uow: read session timeouts: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "SET LOCK_TIMEOUT "+l.value); err != nil {
+		return fmt.Errorf("uow: apply timeouts: %w", err)
+	}
+	return nil
+}
+
+func (l *lockTimeout) Exit(ctx context.Context, conn database.DBTX) error {
+	if _, err := conn.ExecContext(ctx, "SET LOCK_TIMEOUT "+strconv.FormatInt(l.previous, 10)); err != nil {
+		return fmt.Errorf("uow: reset session timeouts: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package uow_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/dbtest"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/uow"
+	"github.com/stretchr/testify/require"
+)
+
+func okFn(_ context.Context, _ database.DBTX) (string, error) { return "ok", nil }
+
+func TestTimeouts_PostgresUsesSetLocal(t *testing.T) {
+	for _, driver := range []database.Driver{database.DriverPostgres, database.DriverCockroach} {
+		tx := &fakeTx{}
+		u := uow.New[string](&fakeManager{driver: driver, tx: tx},
+			uow.WithStatementTimeout(2*time.Second),
+			uow.WithLockTimeout(500*time.Millisecond),
+			uow.WithIdleInTransactionTimeout(1500*time.Microsecond),
+		)
+
+		_, err := u.Do(context.Background(), okFn)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"SET LOCAL statement_timeout = 2000",
+			"SET LOCAL lock_timeout = 500",
+			"SET LOCAL idle_in_transaction_session_timeout = 2",
+		}, tx.execs, "%s: frações de milissegundo arredondam para cima", driver)
+		require.True(t, tx.committed)
+	}
+}
+
+func sessionQueries(db *dbtest.Manager) []string {
+	var out []string
+	for _, e := range db.Events() {
+		if e.TxID == 0 && e.Query != "" {
+			out = append(out, e.Query)
+		}
+	}
+	return out
+}
+
+func TestTimeouts_MySQLRestoresPreviousSessionValues(t *testing.T) {
+	db := dbtest.New(dbtest.WithDriver(database.DriverMySQL))
+	db.On(`SELECT @@SESSION`).Rows([]string{"lock", "exec"}, []any{int64(30), int64(0)})
+	u := uow.New[string](db, uow.WithLockTimeout(1500*time.Millisecond))
+
+	_, err := u.Do(context.Background(), okFn, uow.WithStatementTimeout(3*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"SELECT @@SESSION.innodb_lock_wait_timeout, @@SESSION.max_execution_time",
+		"SET SESSION innodb_lock_wait_timeout = 2, max_execution_time = 3000",
+		"SET SESSION innodb_lock_wait_timeout = 30, max_execution_time = 0",
+	}, sessionQueries(db), "os valores anteriores da sessão voltam, não os globais")
+	db.AssertEvents(t,
+		dbtest.EventQueryRow, dbtest.EventExec, dbtest.EventBegin, dbtest.EventCommit, dbtest.EventExec,
+	)
+}
+
+func TestTimeouts_MSSQLRestoresLockTimeoutAfterRollback(t *testing.T) {
+	db := dbtest.New(dbtest.WithDriver(database.DriverMSSQL))
+	db.On(`SELECT @@LOCK_TIMEOUT`).Rows([]string{"lock_timeout"}, []any{int64(5000)})
+	fnErr := errors.New("fn failed")
+	u := uow.New[string](db,
+		uow.WithLockTimeout(250*time.Millisecond),
+		uow.WithStatementTimeout(time.Second),
+	)
+
+	_, err := u.Do(context.Background(), func(_ context.Context, _ database.DBTX) (string, error) {
+		return "", fnErr
+	})
+	require.ErrorIs(t, err, fnErr)
+	require.Equal(t, []string{"SELECT @@LOCK_TIMEOUT", "SET LOCK_TIMEOUT 250", "SET LOCK_TIMEOUT 5000"}, sessionQueries(db),
+		"SQL Server não tem statement timeout e volta ao valor anterior depois do rollback")
+	db.AssertEvents(t,
+		dbtest.EventQueryRow, dbtest.EventExec, dbtest.EventBegin, dbtest.EventRollback, dbtest.EventExec,
+	)
+}
+
+func TestTimeouts_MySQLRestoresAfterTxDeadline(t *testing.T) {
+	db := dbtest.New(dbtest.WithDriver(database.DriverMySQL))
+	db.On(`SELECT @@SESSION`).Rows([]string{"lock"}, []any{int64(50)})
+	u := uow.New[string](db, uow.WithLockTimeout(time.Second), uow.WithTxDeadline(20*time.Millisecond))
+
+	_, err := u.Do(context.Background(), func(ctx context.Context, tx database.DBTX) (string, error) {
+		<-ctx.Done()
+		require.Eventually(t, func() bool {
+			_, err := tx.ExecContext(context.Background(), "UPDATE orders SET total = 0")
+			return errors.Is(err, sql.ErrTxDone)
+		}, time.Second, time.Millisecond, "o prazo desfaz a transação por conta própria")
+		return "", ctx.Err()
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	events := db.Events()
+	require.Equal(t, "SET SESSION innodb_lock_wait_timeout = 50", events[len(events)-1].Query,
+		"o timeout da sessão volta mesmo quando o rollback não passou pelo uow")
+	require.Equal(t, dbtest.EventRollback, events[len(events)-2].Kind)
+}
+
+func TestTimeouts_DiscardsConnectionWhenResetFails(t *testing.T) {
+	db := dbtest.New(dbtest.WithDriver(database.DriverMSSQL))
+	db.On(`SELECT @@LOCK_TIMEOUT`).Rows([]string{"lock_timeout"}, []any{int64(-1)})
+	db.On(`SET LOCK_TIMEOUT -1`).Err(errors.New("connection reset"))
+	u := uow.New[string](db, uow.WithLockTimeout(time.Second))
+
+	_, err := u.Do(context.Background(), okFn)
+	require.NoError(t, err, "o commit já aconteceu; a falha só afeta a conexão")
+	db.AssertCommittedOnce(t)
+	require.Equal(t, dbtest.EventDiscard, db.Events()[len(db.Events())-1].Kind,
+		"a conexão com o timeout alterado é descartada em vez de voltar ao pool")
+}
+
+func TestTimeouts_NothingToApply(t *testing.T) {
+	tx := &fakeTx{}
+	u := uow.New[string](&fakeManager{driver: database.DriverSQLite, tx: tx}, uow.WithLockTimeout(time.Second))
+
+	_, err := u.Do(context.Background(), okFn)
+	require.NoError(t, err)
+	require.Empty(t, tx.execs)
+}
+
+func TestTimeouts_SetFailureRollsBackWithoutRunningFn(t *testing.T) {
+	setErr := errors.New("unrecognized configuration parameter")
+	tx := &fakeTx{execErrs: map[string]error{"SET LOCAL lock_timeout = 100": setErr}}
+	u := uow.New[string](&fakeManager{tx: tx}, uow.WithLockTimeout(100*time.Millisecond))
+
+	called := false
+	_, err := u.Do(context.Background(), func(_ context.Context, _ database.DBTX) (string, error) {
+		called = true
+		return "ok", nil
+	})
+	require.ErrorIs(t, err, setErr)
+	require.False(t, called)
+	require.True(t, tx.rolledBack)
+}
+
+func TestTimeouts_TxDeadlineCancelsAttempt(t *testing.T) {
+	tx := &fakeTx{}
+	u := uow.New[string](&fakeManager{tx: tx}, uow.WithTxDeadline(20*time.Millisecond))
+
+	start := time.Now()
+	_, err := u.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
+		_, ok := ctx.Deadline()
+		require.True(t, ok)
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second)
+	require.True(t, tx.rolledBack)
+	require.False(t, tx.committed)
+}
@@ -2,7 +2,9 @@ package uow
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -30,6 +32,9 @@ type unitOfWork[T any] struct {
 	txTimer    observability.Histogram
 	txCommit   observability.Counter
 	txRollback observability.Counter
+	txRetries  observability.Counter
+
+	txHookFailures observability.Counter
 }
 
 func New[T any](mgr manager.Manager, opts ...Option) UnitOfWork[T] {
@@ -50,62 +55,145 @@ func New[T any](mgr manager.Manager, opts ...Option) UnitOfWork[T] {
 		),
 		txCommit:   o.observability.Metrics().Counter("database.tx.committed", "Committed transactions", "{transactions}"),
 		txRollback: o.observability.Metrics().Counter("database.tx.rolledback", "Rolled back transactions", "{transactions}"),
+		txRetries:  o.observability.Metrics().Counter("database.tx.retries", "Transaction attempts retried after a retryable error", "{retries}"),
+
+		txHookFailures: o.observability.Metrics().Counter("database.tx.hook_failures", "Transaction hooks that returned an error or panicked", "{hooks}"),
 	}
 }
 
-func (u *unitOfWork[T]) Do(ctx context.Context, fn func(ctx context.Context, tx database.DBTX) (T, error), opts ...Option) (result T, err error) {
-	var zero T
+func (u *unitOfWork[T]) Do(ctx context.Context, fn func(ctx context.Context, tx database.DBTX) (T, error), opts ...Option) (T, error) {
+	effectiveOpts := u.opts
+	for _, opt := range opts {
+		opt(&effectiveOpts)
+	}
+
+	if outer, ok := database.FromContext(ctx); ok {
+		// Only a transaction begun on the same manager can be joined; one of
+		// another database, or of unknown origin, is not ours to extend.
+		owner, _ := database.TxOwner(ctx)
+		switch {
+		case effectiveOpts.propagation == PropagationRequiresNew:
+			return u.run(ctx, fn, effectiveOpts)
+		case owner != any(u.mgr):
+			var zero T
+			return zero, database.ErrNestedTransaction
+		case effectiveOpts.propagation == PropagationJoin:
+			return fn(ctx, outer)
+		default:
+			return u.runSavepoint(ctx, outer, fn)
+		}
+	}
 
 	if !u.reentrant.Enter() {
+		var zero T
 		return zero, database.ErrNestedTransaction
 	}
 	defer u.reentrant.Leave()
 
-	if _, ok := database.FromContext(ctx); ok {
-		return zero, database.ErrNestedTransaction
-	}
+	return u.run(ctx, fn, effectiveOpts)
+}
 
+func (u *unitOfWork[T]) run(ctx context.Context, fn func(ctx context.Context, tx database.DBTX) (T, error), effectiveOpts options) (result T, err error) {
 	metricAttrs := []observability.Field{
 		observability.String("db.system", string(u.driver)),
 	}
+	spanAttrs := metricAttrs
+	if tenant, ok := database.TenantFromContext(ctx); ok {
+		// Spans only: the tenant is not validated here, so it stays out of
+		// metric attributes.
+		spanAttrs = append(slices.Clip(spanAttrs), observability.String("db.tenant", tenant))
+	}
 	ctx, span := u.opts.observability.Tracer().Start(
 		ctx,
 		fmt.Sprintf("db.%s.tx", u.driver),
-		observability.WithAttributes(metricAttrs...),
+		observability.WithAttributes(spanAttrs...),
 	)
 	start := time.Now()
 	outcome := "error"
+	attempts := 0
 	defer func() {
+		span.SetAttributes(observability.Int("db.tx.attempts", attempts))
 		u.txTimer.Record(ctx, float64(time.Since(start).Milliseconds()), append(metricAttrs, observability.String("outcome", outcome))...)
 		span.End()
 	}()
 
-	effectiveOpts := u.opts
-	for _, opt := range opts {
-		opt(&effectiveOpts)
-	}
-
 	txOpts := database.TxOptions{
 		Isolation: effectiveOpts.isolation,
 		ReadOnly:  effectiveOpts.readOnly,
 	}
+	policy := effectiveOpts.retry
+	var hookErrs []error
+
+	for {
+		attempts++
+		hooks := &txHooks{}
+		result, err = u.attempt(ctx, span, fn, txOpts, effectiveOpts.timeouts, hooks, metricAttrs, &outcome)
+		hookErrs = append(hookErrs, hooks.err())
+		if err == nil || attempts >= policy.MaxAttempts || !policy.Retryable(err) {
+			break
+		}
+
+		u.txRetries.Increment(ctx, metricAttrs...)
+		span.AddEvent(
+			"db.tx.retry",
+			observability.Int("db.tx.attempt", attempts),
+			observability.String("error.kind", database.Classify(err).String()),
+		)
+		if !sleepWithContext(ctx, policy.backoff(attempts)) {
+			break
+		}
+	}
+
+	var hookErr error
+	if effectiveOpts.hookErrors {
+		hookErr = errors.Join(hookErrs...)
+	}
 
-	tx, txErr := u.mgr.BeginTx(ctx, txOpts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(observability.StatusCodeError, err.Error())
+		return result, errors.Join(err, hookErr)
+	}
+
+	span.SetStatus(observability.StatusCodeOK, "ok")
+	return result, hookErr
+}
+
+func (u *unitOfWork[T]) attempt(
+	ctx context.Context,
+	span observability.Span,
+	fn func(ctx context.Context, tx database.DBTX) (T, error),
+	txOpts database.TxOptions,
+	timeouts txTimeouts,
+	hooks *txHooks,
+	metricAttrs []observability.Field,
+	outcome *string,
+) (T, error) {
+	var zero T
+
+	attemptCtx := ctx
+	if timeouts.deadline > 0 {
+		var cancel context.CancelFunc
+		attemptCtx, cancel = context.WithTimeout(ctx, timeouts.deadline)
+		defer cancel()
+	}
+
+	local, session := timeoutStatements(u.driver, timeouts)
+	txOpts.Session = session
+	tx, txErr := u.mgr.BeginTx(attemptCtx, txOpts)
 	if txErr != nil {
-		span.RecordError(txErr)
-		span.SetStatus(observability.StatusCodeError, txErr.Error())
+		*outcome = "error"
 		return zero, fmt.Errorf("uow: begin tx: %w", txErr)
 	}
 
-	txCtx := database.WithTx(ctx, tx)
-
 	defer func() {
 		if r := recover(); r != nil {
 			if rbErr := u.rollbackWithFreshContext(ctx, tx, "uow.rollback_on_panic", "uow: rollback after panic failed"); rbErr != nil {
 				span.RecordError(rbErr)
 			}
 			u.txRollback.Increment(ctx, metricAttrs...)
-			outcome = "panic"
+			*outcome = "panic"
+			u.runAfterHooks(ctx, span, hooks, phaseAfterRollback, metricAttrs)
 			if panicErr, ok := r.(error); ok {
 				span.RecordError(panicErr)
 				span.SetStatus(observability.StatusCodeError, panicErr.Error())
@@ -117,37 +205,138 @@ func (u *unitOfWork[T]) Do(ctx context.Context, fn func(ctx context.Context, tx
 		}
 	}()
 
-	result, err = fn(txCtx, tx)
+	err := u.applyTimeouts(attemptCtx, tx, local)
+	txCtx := withHooks(database.WithTxOwner(attemptCtx, tx, u.mgr), hooks)
+
+	var result T
+	if err == nil {
+		result, err = fn(txCtx, tx)
+	}
+	if err == nil {
+		err = u.runBeforeCommit(txCtx, span, hooks, metricAttrs)
+	}
 
 	if err != nil {
 		if rbErr := u.rollbackWithFreshContext(ctx, tx, "uow.rollback_on_error", "uow: rollback after fn error failed"); rbErr != nil {
 			span.RecordError(rbErr)
 		}
 		u.txRollback.Increment(ctx, metricAttrs...)
-		outcome = "rolled_back"
-		span.RecordError(err)
-		span.SetStatus(observability.StatusCodeError, err.Error())
+		*outcome = "rolled_back"
+		u.runAfterHooks(ctx, span, hooks, phaseAfterRollback, metricAttrs)
 		return zero, err
 	}
 
-	if commitErr := tx.Commit(ctx); commitErr != nil {
-
+	if commitErr := tx.Commit(attemptCtx); commitErr != nil {
 		if rbErr := u.rollbackWithFreshContext(ctx, tx, "uow.rollback_on_commit_failure", "uow: rollback after commit failure"); rbErr != nil {
 			span.RecordError(rbErr)
 		}
 		u.txRollback.Increment(ctx, metricAttrs...)
-		outcome = "rolled_back"
-		span.RecordError(commitErr)
-		span.SetStatus(observability.StatusCodeError, commitErr.Error())
+		*outcome = "rolled_back"
+		u.runAfterHooks(ctx, span, hooks, phaseAfterRollback, metricAttrs)
 		return zero, fmt.Errorf("uow: commit: %w", commitErr)
 	}
 
 	u.txCommit.Increment(ctx, metricAttrs...)
-	outcome = "committed"
+	*outcome = "committed"
+	u.runAfterHooks(ctx, span, hooks, phaseAfterCommit, metricAttrs)
+	return result, nil
+}
+
+func (u *unitOfWork[T]) runSavepoint(ctx context.Context, tx database.DBTX, fn func(ctx context.Context, tx database.DBTX) (T, error)) (result T, err error) {
+	var zero T
+
+	depth := savepointDepth(ctx) + 1
+	name := savepointName(depth)
+	ctx = withSavepointDepth(ctx, depth)
+
+	ctx, span := u.opts.observability.Tracer().Start(
+		ctx,
+		fmt.Sprintf("db.%s.savepoint", u.driver),
+		observability.WithAttributes(
+			observability.String("db.system", string(u.driver)),
+			observability.Int("db.savepoint.depth", depth),
+		),
+	)
+	defer span.End()
+
+	parent, hasHooks := hooksFromContext(ctx)
+	hooks := &txHooks{}
+	if hasHooks {
+		ctx = withHooks(ctx, hooks)
+	}
+
+	if _, spErr := tx.ExecContext(ctx, savepointSQL(u.driver, name)); spErr != nil {
+		span.RecordError(spErr)
+		span.SetStatus(observability.StatusCodeError, spErr.Error())
+		return zero, fmt.Errorf("uow: savepoint: %w", spErr)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			if rbErr := u.rollbackToSavepoint(ctx, tx, name, "uow.rollback_to_savepoint_on_panic", "uow: rollback to savepoint after panic failed"); rbErr != nil {
+				span.RecordError(rbErr)
+			}
+			if hasHooks {
+				u.discardSavepointHooks(ctx, span, parent, hooks)
+			}
+			span.SetStatus(observability.StatusCodeError, "panic")
+			panic(r)
+		}
+	}()
+
+	result, err = fn(ctx, tx)
+	if err != nil {
+		if rbErr := u.rollbackToSavepoint(ctx, tx, name, "uow.rollback_to_savepoint_on_error", "uow: rollback to savepoint after fn error failed"); rbErr != nil {
+			span.RecordError(rbErr)
+		}
+		if hasHooks {
+			u.discardSavepointHooks(ctx, span, parent, hooks)
+		}
+		span.RecordError(err)
+		span.SetStatus(observability.StatusCodeError, err.Error())
+		return zero, err
+	}
+
+	if release := releaseSavepointSQL(u.driver, name); release != "" {
+		if _, relErr := tx.ExecContext(ctx, release); relErr != nil {
+			span.RecordError(relErr)
+			span.SetStatus(observability.StatusCodeError, relErr.Error())
+			return zero, fmt.Errorf("uow: release savepoint: %w", relErr)
+		}
+	}
+
+	if hasHooks {
+		parent.merge(hooks)
+	}
 	span.SetStatus(observability.StatusCodeOK, "ok")
 	return result, nil
 }
 
+func (u *unitOfWork[T]) rollbackToSavepoint(
+	ctx context.Context,
+	tx database.DBTX,
+	name string,
+	operation string,
+	message string,
+) error {
+	rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackOnPanicTimeout)
+	defer cancel()
+
+	if _, err := tx.ExecContext(rollbackCtx, rollbackToSavepointSQL(u.driver, name)); err != nil {
+		u.opts.observability.Logger().Error(
+			ctx,
+			message,
+			observability.String("operation", operation),
+			observability.String("layer", "database"),
+			observability.String("entity", "uow"),
+			observability.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
 func (u *unitOfWork[T]) rollbackWithFreshContext(
 	ctx context.Context,
 	tx database.Tx,
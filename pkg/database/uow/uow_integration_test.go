@@ -254,3 +254,33 @@ func TestIntegration_UoW_Concurrent(t *testing.T) {
 	require.NoError(t, row.Scan(&count))
 	require.Equal(t, goroutines, count)
 }
+
+// TestIntegration_UoW_NestedSavepoint_RollsBackOnlyInnerWork verifica que um Do
+// aninhado com erro desfaz apenas o trabalho do savepoint, preservando a
+// escrita da transação externa.
+func TestIntegration_UoW_NestedSavepoint_RollsBackOnlyInnerWork(t *testing.T) {
+	ctx := context.Background()
+	mgr := setupPostgres(t)
+	u := uow.New[struct{}](mgr)
+
+	_, err := u.Do(ctx, func(ctx context.Context, tx database.DBTX) (struct{}, error) {
+		if _, execErr := tx.ExecContext(ctx, `INSERT INTO items (value) VALUES ($1)`, "outer-savepoint"); execErr != nil {
+			return struct{}{}, execErr
+		}
+		_, innerErr := u.Do(ctx, func(ctx context.Context, tx database.DBTX) (struct{}, error) {
+			if _, execErr := tx.ExecContext(ctx, `INSERT INTO items (value) VALUES ($1)`, "inner-savepoint"); execErr != nil {
+				return struct{}{}, execErr
+			}
+			return struct{}{}, fmt.Errorf("inner failed")
+		})
+		require.Error(t, innerErr)
+		return struct{}{}, nil
+	})
+	require.NoError(t, err)
+
+	var outer, inner int
+	require.NoError(t, mgr.DBTX(ctx).QueryRowContext(ctx, `SELECT COUNT(*) FROM items WHERE value = 'outer-savepoint'`).Scan(&outer))
+	require.NoError(t, mgr.DBTX(ctx).QueryRowContext(ctx, `SELECT COUNT(*) FROM items WHERE value = 'inner-savepoint'`).Scan(&inner))
+	require.Equal(t, 1, outer)
+	require.Zero(t, inner)
+}
@@ -22,12 +22,15 @@ import (
 type fakeTx struct {
 	commitErr   error
 	rollbackErr error
+	execErrs    map[string]error
 	committed   bool
 	rolledBack  bool
+	execs       []string
 }
 
-func (t *fakeTx) ExecContext(_ context.Context, _ string, _ ...any) (database.Result, error) {
-	return nil, nil
+func (t *fakeTx) ExecContext(_ context.Context, query string, _ ...any) (database.Result, error) {
+	t.execs = append(t.execs, query)
+	return nil, t.execErrs[query]
 }
 func (t *fakeTx) QueryContext(_ context.Context, _ string, _ ...any) (database.Rows, error) {
 	return nil, nil
@@ -45,6 +48,7 @@ func (t *fakeTx) Rollback(_ context.Context) error {
 // fakeManager satisfaz manager.Manager para que possa ser passado para uow.New[T].
 // Apenas BeginTx é usado pelo UoW em tempo de execução; os outros métodos são stubs.
 type fakeManager struct {
+	driver     database.Driver
 	mu         sync.Mutex
 	tx         *fakeTx
 	txFactory  func() database.Tx
@@ -53,7 +57,12 @@ type fakeManager struct {
 	beginCalls int
 }
 
-func (m *fakeManager) Driver() database.Driver { return database.DriverPostgres }
+func (m *fakeManager) Driver() database.Driver {
+	if m.driver != "" {
+		return m.driver
+	}
+	return database.DriverPostgres
+}
 func (m *fakeManager) DBTX(_ context.Context) database.DBTX {
 	return nil
 }
@@ -72,6 +81,15 @@ func (m *fakeManager) BeginTx(_ context.Context, opts database.TxOptions) (datab
 }
 func (m *fakeManager) Ping(_ context.Context) error     { return nil }
 func (m *fakeManager) Shutdown(_ context.Context) error { return nil }
+func (m *fakeManager) TryLock(_ context.Context, _ string) (database.Lock, bool, error) {
+	return nil, false, database.ErrLockUnsupported
+}
+func (m *fakeManager) Lock(_ context.Context, _ string) (database.Lock, error) {
+	return nil, database.ErrLockUnsupported
+}
+func (m *fakeManager) WithLock(_ context.Context, _ string, _ func(context.Context) error) error {
+	return database.ErrLockUnsupported
+}
 
 // --- testes ----------------------------------------------------------------
 
@@ -215,20 +233,23 @@ func TestUnitOfWork_Do_PanicValuePreserved(t *testing.T) {
 	require.Equal(t, sentinel, caught, "o valor original do pânico deve ser preservado")
 }
 
-func TestUnitOfWork_Do_ErrNestedTransaction_SameInstance(t *testing.T) {
+func TestUnitOfWork_Do_Nested_SameInstance_UsesSavepoint(t *testing.T) {
 	tx := &fakeTx{}
-	u := uow.New[string](&fakeManager{tx: tx})
+	mgr := &fakeManager{tx: tx}
+	u := uow.New[string](mgr)
 
-	var innerErr error
-	_, _ = u.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
-		// Mesma instância: tx propagada via context é detectada → ErrNestedTransaction.
-		_, innerErr = u.Do(ctx, func(_ context.Context, _ database.DBTX) (string, error) {
+	result, err := u.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
+		// Mesma instância: tx propagada via context é reutilizada com SAVEPOINT.
+		return u.Do(ctx, func(_ context.Context, _ database.DBTX) (string, error) {
 			return "inner", nil
 		})
-		return "", innerErr
 	})
 
-	require.ErrorIs(t, innerErr, database.ErrNestedTransaction)
+	require.NoError(t, err)
+	require.Equal(t, "inner", result)
+	require.Equal(t, 1, mgr.beginCalls)
+	require.Equal(t, []string{"SAVEPOINT uow_sp_1", "RELEASE SAVEPOINT uow_sp_1"}, tx.execs)
+	require.True(t, tx.committed)
 }
 
 func TestUnitOfWork_Do_NestedWithFreshContext_ReturnsErrNestedTransaction(t *testing.T) {
@@ -247,22 +268,175 @@ func TestUnitOfWork_Do_NestedWithFreshContext_ReturnsErrNestedTransaction(t *tes
 	require.Equal(t, 1, mgr.beginCalls, "nested call must not open a second transaction")
 }
 
-func TestUnitOfWork_Do_ErrNestedTransaction_DifferentInstance(t *testing.T) {
+func TestUnitOfWork_Do_Nested_SameManager_JoinsOuterTxWithSavepoint(t *testing.T) {
+	tx := &fakeTx{}
+	mgr := &fakeManager{tx: tx}
+	u1 := uow.New[string](mgr)
+	u2 := uow.New[string](mgr)
+
+	_, err := u1.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
+		// ctx carrega a tx do u1.Do; u2.Do usa o mesmo manager e deve reutilizá-la.
+		return u2.Do(ctx, func(_ context.Context, inner database.DBTX) (string, error) {
+			require.Same(t, tx, inner)
+			return "inner", nil
+		})
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, 1, mgr.beginCalls)
+	require.Equal(t, []string{"SAVEPOINT uow_sp_1", "RELEASE SAVEPOINT uow_sp_1"}, tx.execs)
+}
+
+func TestUnitOfWork_Do_Nested_DifferentManager_ReturnsErrNestedTransaction(t *testing.T) {
 	tx1 := &fakeTx{}
-	tx2 := &fakeTx{}
+	mgr2 := &fakeManager{tx: &fakeTx{}}
 	u1 := uow.New[string](&fakeManager{tx: tx1})
-	u2 := uow.New[string](&fakeManager{tx: tx2})
+	u2 := uow.New[string](mgr2)
 
-	var innerErr error
-	_, _ = u1.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
-		// ctx carrega uma tx injetada pelo u1.Do; u2.Do deve detectá-la e recusar.
-		_, innerErr = u2.Do(ctx, func(_ context.Context, _ database.DBTX) (string, error) {
-			return "inner", nil
+	_, err := u1.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
+		for _, p := range []uow.Propagation{uow.PropagationSavepoint, uow.PropagationJoin} {
+			_, err := u2.Do(ctx, okFn, uow.WithPropagation(p))
+			require.ErrorIs(t, err, database.ErrNestedTransaction, "a tx do ctx pertence a outro banco")
+		}
+		return "outer", nil
+	})
+
+	require.NoError(t, err)
+	require.Zero(t, mgr2.beginCalls)
+	require.Empty(t, tx1.execs, "nenhum savepoint é aberto na tx de outro manager")
+	require.True(t, tx1.committed)
+}
+
+func TestUnitOfWork_Do_TxOfUnknownOrigin_ReturnsErrNestedTransaction(t *testing.T) {
+	mgr := &fakeManager{tx: &fakeTx{}}
+	ctx := database.WithTx(context.Background(), &fakeTx{})
+
+	_, err := uow.New[string](mgr).Do(ctx, okFn)
+	require.ErrorIs(t, err, database.ErrNestedTransaction)
+	require.Zero(t, mgr.beginCalls)
+
+	_, err = uow.New[string](mgr).Do(ctx, okFn, uow.WithPropagation(uow.PropagationRequiresNew))
+	require.NoError(t, err, "RequiresNew abre uma transação própria")
+	require.Equal(t, 1, mgr.beginCalls)
+}
+
+func TestUnitOfWork_Do_NestedError_RollsBackToSavepointOnly(t *testing.T) {
+	innerErr := errors.New("inner failed")
+	tx := &fakeTx{}
+	u := uow.New[string](&fakeManager{tx: tx})
+
+	result, err := u.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
+		_, nestedErr := u.Do(ctx, func(_ context.Context, _ database.DBTX) (string, error) {
+			return "", innerErr
+		})
+		require.ErrorIs(t, nestedErr, innerErr)
+		return "outer", nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "outer", result)
+	require.Equal(t, []string{"SAVEPOINT uow_sp_1", "ROLLBACK TO SAVEPOINT uow_sp_1"}, tx.execs)
+	require.True(t, tx.committed)
+	require.False(t, tx.rolledBack)
+}
+
+func TestUnitOfWork_Do_NestedPanic_RollsBackToSavepointAndRepropagates(t *testing.T) {
+	tx := &fakeTx{}
+	u := uow.New[string](&fakeManager{tx: tx})
+
+	var caught any
+	func() {
+		defer func() { caught = recover() }()
+		_, _ = u.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
+			return u.Do(ctx, func(_ context.Context, _ database.DBTX) (string, error) {
+				panic("inner boom")
+			})
 		})
+	}()
+
+	require.Equal(t, "inner boom", caught)
+	require.Equal(t, []string{"SAVEPOINT uow_sp_1", "ROLLBACK TO SAVEPOINT uow_sp_1"}, tx.execs)
+	require.True(t, tx.rolledBack, "o pânico re-propagado deve desfazer a transação externa")
+}
+
+func TestUnitOfWork_Do_NestedSavepoint_DepthNamesAndMSSQLSyntax(t *testing.T) {
+	tx := &fakeTx{}
+	u := uow.New[string](&fakeManager{tx: tx, driver: database.DriverMSSQL})
+
+	_, err := u.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
+		return u.Do(ctx, func(ctx context.Context, _ database.DBTX) (string, error) {
+			return u.Do(ctx, func(_ context.Context, _ database.DBTX) (string, error) {
+				return "", errors.New("deepest failed")
+			})
+		})
+	})
+
+	require.Error(t, err)
+	require.Equal(t, []string{
+		"SAVE TRANSACTION uow_sp_1",
+		"SAVE TRANSACTION uow_sp_2",
+		"ROLLBACK TRANSACTION uow_sp_2",
+		"ROLLBACK TRANSACTION uow_sp_1",
+	}, tx.execs)
+}
+
+func TestUnitOfWork_Do_SavepointFailure_Propagated(t *testing.T) {
+	spErr := errors.New("savepoint not supported")
+	tx := &fakeTx{execErrs: map[string]error{"SAVEPOINT uow_sp_1": spErr}}
+	u := uow.New[string](&fakeManager{tx: tx})
+
+	called := false
+	_, err := u.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
+		return u.Do(ctx, func(_ context.Context, _ database.DBTX) (string, error) {
+			called = true
+			return "", nil
+		})
+	})
+
+	require.ErrorIs(t, err, spErr)
+	require.False(t, called)
+	require.True(t, tx.rolledBack)
+}
+
+func TestUnitOfWork_Do_PropagationJoin_ReusesTxWithoutSavepoint(t *testing.T) {
+	tx := &fakeTx{}
+	mgr := &fakeManager{tx: tx}
+	u := uow.New[string](mgr)
+
+	_, err := u.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
+		return u.Do(ctx, func(_ context.Context, _ database.DBTX) (string, error) {
+			return "inner", nil
+		}, uow.WithPropagation(uow.PropagationJoin))
+	})
+
+	require.NoError(t, err)
+	require.Empty(t, tx.execs)
+	require.Equal(t, 1, mgr.beginCalls)
+}
+
+func TestUnitOfWork_Do_PropagationRequiresNew_OpensIndependentTx(t *testing.T) {
+	outer := &fakeTx{}
+	inner := &fakeTx{}
+	txs := []*fakeTx{outer, inner}
+	mgr := &fakeManager{}
+	mgr.txFactory = func() database.Tx { return txs[mgr.beginCalls-1] }
+	u := uow.New[string](mgr)
+
+	_, err := u.Do(context.Background(), func(ctx context.Context, _ database.DBTX) (string, error) {
+		_, innerErr := u.Do(ctx, func(ctx context.Context, tx database.DBTX) (string, error) {
+			ctxTx, _ := database.FromContext(ctx)
+			require.Same(t, inner, ctxTx)
+			require.Same(t, inner, tx)
+			return "", nil
+		}, uow.WithPropagation(uow.PropagationRequiresNew))
 		return "", innerErr
 	})
 
-	require.ErrorIs(t, innerErr, database.ErrNestedTransaction)
+	require.NoError(t, err)
+	require.Equal(t, 2, mgr.beginCalls)
+	require.True(t, inner.committed)
+	require.True(t, outer.committed)
+	require.Empty(t, outer.execs)
 }
 
 func TestUnitOfWork_Do_BeginTxError_Propagated(t *testing.T) {
@@ -0,0 +1,54 @@
+package responses
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// CursorParam is the query parameter that carries page cursors.
+const CursorParam = "cursor"
+
+// PageEnvelope is the body written by Page.
+type PageEnvelope[T any] struct {
+	Data       []T      `json:"data"`
+	Pagination PageInfo `json:"pagination"`
+}
+
+type PageInfo struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// Page writes items with status 200 in a PageEnvelope and adds RFC 8288
+// Link headers for the next and previous pages. Their URLs are the request
+// URL with CursorParam replaced, so filters and limit carry over. Empty
+// cursors are omitted.
+func Page[T any](w http.ResponseWriter, r *http.Request, items []T, next, prev string) {
+	if items == nil {
+		items = []T{}
+	}
+
+	var links []string
+	if next != "" {
+		links = append(links, pageLink(r.URL, next, "next"))
+	}
+	if prev != "" {
+		links = append(links, pageLink(r.URL, prev, "prev"))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+
+	JSON(w, http.StatusOK, PageEnvelope[T]{
+		Data:       items,
+		Pagination: PageInfo{NextCursor: next, PrevCursor: prev},
+	})
+}
+
+func pageLink(u *url.URL, cursor, rel string) string {
+	query := u.Query()
+	query.Set(CursorParam, cursor)
+	target := url.URL{Path: u.Path, RawQuery: query.Encode()}
+	return "<" + target.String() + `>; rel="` + rel + `"`
+}
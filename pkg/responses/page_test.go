@@ -0,0 +1,41 @@
+package responses
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPage(t *testing.T) {
+	t.Run("writes envelope and Link headers", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/orders?status=open&limit=10&cursor=old", nil)
+		w := httptest.NewRecorder()
+
+		Page(w, r, []map[string]int{{"id": 1}, {"id": 2}}, "n1", "p1")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, contentTypeJSON, w.Header().Get("Content-Type"))
+		require.Equal(t,
+			`</orders?cursor=n1&limit=10&status=open>; rel="next", </orders?cursor=p1&limit=10&status=open>; rel="prev"`,
+			w.Header().Get("Link"),
+		)
+
+		var body PageEnvelope[map[string]int]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Len(t, body.Data, 2)
+		require.Equal(t, PageInfo{NextCursor: "n1", PrevCursor: "p1"}, body.Pagination)
+	})
+
+	t.Run("last page without cursors", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		w := httptest.NewRecorder()
+
+		Page[string](w, r, nil, "", "")
+
+		require.Empty(t, w.Header().Get("Link"))
+		require.JSONEq(t, `{"data":[],"pagination":{}}`, w.Body.String())
+	})
+}
@@ -0,0 +1,218 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	db "github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/manager"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability"
+	"github.com/JailtonJunior94/devkit-go/pkg/worker/consumer"
+)
+
+// ParamChannel is the Message param holding the channel of the notification.
+const ParamChannel = "channel"
+
+const (
+	defaultNotifyBufferSize         = 64
+	defaultReconnectInitialInterval = 500 * time.Millisecond
+	defaultReconnectMaxInterval     = 30 * time.Second
+	subscriptionCloseTimeout        = 5 * time.Second
+)
+
+var ErrNotifySourceStarted = errors.New("worker: notify source already started")
+
+type NotifyConfig struct {
+	Channels []string
+	// EventTypeField names a top-level string field of JSON payloads used as
+	// the event type. When empty, or missing from a payload, the channel is
+	// the event type.
+	EventTypeField           string
+	BufferSize               int
+	ReconnectInitialInterval time.Duration
+	ReconnectMaxInterval     time.Duration
+}
+
+// NotifySource is a consumer.Source fed by postgres LISTEN/NOTIFY. It keeps
+// one pooled connection of the manager subscribed and re-subscribes with
+// backoff when that connection is lost; notifications sent in between are
+// not delivered.
+type NotifySource struct {
+	listener db.Listener
+	cfg      NotifyConfig
+	obs      observability.Observability
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var _ consumer.Source = (*NotifySource)(nil)
+
+func NewNotifySource(mgr manager.Manager, cfg NotifyConfig, obs observability.Observability) (*NotifySource, error) {
+	listener, ok := mgr.(db.Listener)
+	if !ok {
+		return nil, fmt.Errorf("worker: notify source: %w", db.ErrListenUnsupported)
+	}
+	if len(cfg.Channels) == 0 {
+		return nil, fmt.Errorf("worker: notify source: %w: at least one channel is required", db.ErrInvalidConfig)
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = defaultNotifyBufferSize
+	}
+	if cfg.ReconnectInitialInterval <= 0 {
+		cfg.ReconnectInitialInterval = defaultReconnectInitialInterval
+	}
+	if cfg.ReconnectMaxInterval <= 0 {
+		cfg.ReconnectMaxInterval = defaultReconnectMaxInterval
+	}
+	return &NotifySource{listener: listener, cfg: cfg, obs: obs}, nil
+}
+
+// Messages subscribes to the channels and delivers notifications until ctx
+// is done or Stop is called. The first subscription must succeed. Once
+// stopped, the source can be started again.
+func (s *NotifySource) Messages(ctx context.Context) (<-chan consumer.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done != nil {
+		return nil, ErrNotifySourceStarted
+	}
+
+	sub, err := s.listener.Listen(ctx, s.cfg.Channels...)
+	if err != nil {
+		return nil, fmt.Errorf("worker: notify source listen: %w", err)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	out := make(chan consumer.Message, s.cfg.BufferSize)
+	done := make(chan struct{})
+	s.cancel = cancel
+	s.done = done
+	go s.run(runCtx, sub, out, done)
+	return out, nil
+}
+
+// Stop ends the subscription and waits for the delivery loop to exit.
+func (s *NotifySource) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+	if done == nil {
+		return nil
+	}
+
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *NotifySource) run(ctx context.Context, sub db.Subscription, out chan<- consumer.Message, done chan struct{}) {
+	defer func() {
+		close(out)
+		close(done)
+		s.mu.Lock()
+		s.cancel = nil
+		s.done = nil
+		s.mu.Unlock()
+	}()
+
+	for {
+		n, err := sub.Next(ctx)
+		if err != nil {
+			closeSubscription(sub)
+			if ctx.Err() != nil {
+				return
+			}
+			s.obs.Logger().Warn(ctx, "notify source connection lost",
+				observability.String("operation", "worker.consumer.notify"),
+				observability.Error(err),
+			)
+			if sub, err = s.resubscribe(ctx); err != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case out <- s.message(n):
+		case <-ctx.Done():
+			closeSubscription(sub)
+			return
+		}
+	}
+}
+
+func (s *NotifySource) resubscribe(ctx context.Context) (db.Subscription, error) {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = s.cfg.ReconnectInitialInterval
+	b.MaxInterval = s.cfg.ReconnectMaxInterval
+	b.MaxElapsedTime = 0
+
+	var sub db.Subscription
+	operation := func() error {
+		var err error
+		sub, err = s.listener.Listen(ctx, s.cfg.Channels...)
+		if errors.Is(err, db.ErrManagerClosed) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+	notify := func(err error, wait time.Duration) {
+		s.obs.Logger().Warn(ctx, "notify source listen failed",
+			observability.String("operation", "worker.consumer.notify"),
+			observability.Int64("retry_in_ms", wait.Milliseconds()),
+			observability.Error(err),
+		)
+	}
+
+	if err := backoff.RetryNotify(operation, backoff.WithContext(b, ctx), notify); err != nil {
+		if ctx.Err() == nil {
+			s.obs.Logger().Error(ctx, "notify source stopped",
+				observability.String("operation", "worker.consumer.notify"),
+				observability.Error(err),
+			)
+		}
+		return nil, err
+	}
+	s.obs.Logger().Info(ctx, "notify source listening again",
+		observability.String("operation", "worker.consumer.notify"),
+	)
+	return sub, nil
+}
+
+// message takes the event type from cfg.EventTypeField of a JSON payload,
+// falling back to the channel.
+func (s *NotifySource) message(n db.Notification) consumer.Message {
+	eventType := n.Channel
+	if s.cfg.EventTypeField != "" {
+		var fields map[string]json.RawMessage
+		if json.Unmarshal([]byte(n.Payload), &fields) == nil {
+			var field string
+			if json.Unmarshal(fields[s.cfg.EventTypeField], &field) == nil && field != "" {
+				eventType = field
+			}
+		}
+	}
+	return consumer.Message{
+		EventType: eventType,
+		Params:    map[string]string{ParamChannel: n.Channel},
+		Body:      []byte(n.Payload),
+	}
+}
+
+func closeSubscription(sub db.Subscription) {
+	ctx, cancel := context.WithTimeout(context.Background(), subscriptionCloseTimeout)
+	defer cancel()
+	_ = sub.Close(ctx)
+}
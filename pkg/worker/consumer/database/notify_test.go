@@ -0,0 +1,222 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/JailtonJunior94/devkit-go/pkg/database"
+	"github.com/JailtonJunior94/devkit-go/pkg/database/dbtest"
+	"github.com/JailtonJunior94/devkit-go/pkg/observability/noop"
+	"github.com/JailtonJunior94/devkit-go/pkg/worker/consumer"
+	"github.com/JailtonJunior94/devkit-go/pkg/worker/consumer/database"
+)
+
+// listeningManager acrescenta LISTEN ao manager em memória; cada Listen abre
+// uma fakeSubscription alimentada pelo teste.
+type listeningManager struct {
+	*dbtest.Manager
+	subs      chan *fakeSubscription
+	listenErr error
+	listens   atomic.Int32
+}
+
+func newListeningManager() *listeningManager {
+	return &listeningManager{Manager: dbtest.New(), subs: make(chan *fakeSubscription, 8)}
+}
+
+func (m *listeningManager) Listen(_ context.Context, channels ...string) (db.Subscription, error) {
+	m.listens.Add(1)
+	if m.listenErr != nil {
+		return nil, m.listenErr
+	}
+	sub := &fakeSubscription{
+		channels: channels,
+		notes:    make(chan db.Notification, 8),
+		lost:     make(chan error, 1),
+		closed:   make(chan struct{}),
+	}
+	m.subs <- sub
+	return sub, nil
+}
+
+// next devolve a próxima assinatura aberta pela fonte.
+func (m *listeningManager) next(t *testing.T) *fakeSubscription {
+	t.Helper()
+	select {
+	case sub := <-m.subs:
+		return sub
+	case <-time.After(time.Second):
+		t.Fatal("a fonte deveria ter aberto uma assinatura")
+		return nil
+	}
+}
+
+type fakeSubscription struct {
+	channels  []string
+	notes     chan db.Notification
+	lost      chan error
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (s *fakeSubscription) Next(ctx context.Context) (db.Notification, error) {
+	select {
+	case n := <-s.notes:
+		return n, nil
+	case err := <-s.lost:
+		return db.Notification{}, err
+	case <-s.closed:
+		return db.Notification{}, db.ErrSubscriptionClosed
+	case <-ctx.Done():
+		return db.Notification{}, ctx.Err()
+	}
+}
+
+func (s *fakeSubscription) Close(context.Context) error {
+	s.closeOnce.Do(func() { close(s.closed) })
+	return nil
+}
+
+func receive(t *testing.T, messages <-chan consumer.Message) consumer.Message {
+	t.Helper()
+	select {
+	case msg := <-messages:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("nenhuma mensagem recebida")
+		return consumer.Message{}
+	}
+}
+
+func TestNotifySource_RequiresListener(t *testing.T) {
+	_, err := database.NewNotifySource(dbtest.New(), database.NotifyConfig{Channels: []string{"orders"}}, noop.NewProvider())
+	require.ErrorIs(t, err, db.ErrListenUnsupported)
+
+	_, err = database.NewNotifySource(newListeningManager(), database.NotifyConfig{}, noop.NewProvider())
+	require.ErrorIs(t, err, db.ErrInvalidConfig)
+}
+
+func TestNotifySource_DeliversNotifications(t *testing.T) {
+	mgr := newListeningManager()
+	src, err := database.NewNotifySource(mgr, database.NotifyConfig{
+		Channels:       []string{"orders", "payments"},
+		EventTypeField: "type",
+	}, noop.NewProvider())
+	require.NoError(t, err)
+
+	messages, err := src.Messages(context.Background())
+	require.NoError(t, err)
+	sub := mgr.next(t)
+	require.Equal(t, []string{"orders", "payments"}, sub.channels)
+
+	sub.notes <- db.Notification{Channel: "orders", Payload: `{"type":"order.created","id":1}`}
+	msg := receive(t, messages)
+	require.Equal(t, "order.created", msg.EventType, "o tipo vem do campo JSON")
+	require.Equal(t, "orders", msg.Params[database.ParamChannel])
+	require.JSONEq(t, `{"type":"order.created","id":1}`, string(msg.Body))
+
+	sub.notes <- db.Notification{Channel: "payments", Payload: "42"}
+	msg = receive(t, messages)
+	require.Equal(t, "payments", msg.EventType, "payload sem o campo usa o canal")
+	require.Equal(t, []byte("42"), msg.Body)
+
+	require.NoError(t, src.Stop(context.Background()))
+	_, open := <-messages
+	require.False(t, open, "Stop deve fechar o canal de mensagens")
+
+}
+
+func TestNotifySource_RestartsAfterStop(t *testing.T) {
+	mgr := newListeningManager()
+	src, err := database.NewNotifySource(mgr, database.NotifyConfig{Channels: []string{"orders"}}, noop.NewProvider())
+	require.NoError(t, err)
+
+	_, err = src.Messages(context.Background())
+	require.NoError(t, err)
+	mgr.next(t)
+	_, err = src.Messages(context.Background())
+	require.ErrorIs(t, err, database.ErrNotifySourceStarted, "uma fonte ativa não inicia de novo")
+	require.NoError(t, src.Stop(context.Background()))
+
+	messages, err := src.Messages(context.Background())
+	require.NoError(t, err, "depois do Stop a fonte pode ser reiniciada")
+	sub := mgr.next(t)
+	sub.notes <- db.Notification{Channel: "orders", Payload: "again"}
+	require.Equal(t, "again", string(receive(t, messages).Body))
+	require.NoError(t, src.Stop(context.Background()))
+}
+
+func TestNotifySource_ResubscribesAfterConnectionLoss(t *testing.T) {
+	mgr := newListeningManager()
+	src, err := database.NewNotifySource(mgr, database.NotifyConfig{
+		Channels:                 []string{"orders"},
+		ReconnectInitialInterval: time.Millisecond,
+		ReconnectMaxInterval:     time.Millisecond,
+	}, noop.NewProvider())
+	require.NoError(t, err)
+
+	messages, err := src.Messages(context.Background())
+	require.NoError(t, err)
+	first := mgr.next(t)
+
+	first.lost <- errors.New("conn closed")
+	second := mgr.next(t)
+	select {
+	case <-first.closed:
+	default:
+		t.Fatal("a assinatura perdida deve ser fechada")
+	}
+
+	second.notes <- db.Notification{Channel: "orders", Payload: "after"}
+	require.Equal(t, "after", string(receive(t, messages).Body))
+	require.Equal(t, int32(2), mgr.listens.Load())
+	require.NoError(t, src.Stop(context.Background()))
+}
+
+func TestNotifySource_FailsWhenFirstListenFails(t *testing.T) {
+	mgr := newListeningManager()
+	mgr.listenErr = errors.New("connection refused")
+	src, err := database.NewNotifySource(mgr, database.NotifyConfig{Channels: []string{"orders"}}, noop.NewProvider())
+	require.NoError(t, err)
+
+	_, err = src.Messages(context.Background())
+	require.ErrorIs(t, err, mgr.listenErr)
+	require.NoError(t, src.Stop(context.Background()))
+}
+
+func TestNotifySource_PlugsIntoRunner(t *testing.T) {
+	mgr := newListeningManager()
+	src, err := database.NewNotifySource(mgr, database.NotifyConfig{Channels: []string{"orders"}}, noop.NewProvider())
+	require.NoError(t, err)
+
+	handled := make(chan consumer.Message, 1)
+	runner, err := consumer.NewRunner("orders", src, []consumer.Registration{{
+		EventType: "orders",
+		Handler: consumer.HandlerFunc(func(_ context.Context, msg consumer.Message) error {
+			handled <- msg
+			return nil
+		}),
+	}}, noop.NewProvider())
+	require.NoError(t, err)
+
+	adapter := database.NewAdapter("orders-listener", runner)
+	started := make(chan error, 1)
+	go func() { started <- adapter.Start(context.Background()) }()
+
+	mgr.next(t).notes <- db.Notification{Channel: "orders", Payload: "1"}
+	select {
+	case msg := <-handled:
+		require.Equal(t, []byte("1"), msg.Body)
+	case <-time.After(time.Second):
+		t.Fatal("o handler deveria ter recebido a notificação")
+	}
+
+	require.NoError(t, adapter.Stop(context.Background()))
+	require.NoError(t, <-started)
+}
@@ -65,7 +65,9 @@ func (r *consumerRunner) handleMessage(ctx context.Context, msg Message) {
 		))
 	defer span.End()
 
-	if err := r.reg.dispatch(ctx, msg); err != nil {
+	err := r.reg.dispatch(ctx, msg)
+	r.ack(ctx, msg, err)
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(observability.StatusCodeError, err.Error())
 		r.errCounter.Increment(ctx,
@@ -87,3 +89,18 @@ func (r *consumerRunner) handleMessage(ctx context.Context, msg Message) {
 		observability.String("result", "success"),
 	)
 }
+
+func (r *consumerRunner) ack(ctx context.Context, msg Message, err error) {
+	acker, ok := r.source.(Acknowledger)
+	if !ok {
+		return
+	}
+	if ackErr := acker.Ack(ctx, msg, err); ackErr != nil {
+		r.obs.Logger().Error(ctx, "consumer ack failed",
+			observability.String("operation", "worker.consumer.ack"),
+			observability.String("name", r.name),
+			observability.String("event_type", msg.EventType),
+			observability.Error(ackErr),
+		)
+	}
+}
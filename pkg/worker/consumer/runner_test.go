@@ -30,6 +30,17 @@ func (m *mockSource) Stop(_ context.Context) error {
 	return m.stopErr
 }
 
+// ackingSource registra o resultado de cada mensagem despachada.
+type ackingSource struct {
+	mockSource
+	acks map[string]error
+}
+
+func (a *ackingSource) Ack(_ context.Context, msg consumer.Message, err error) error {
+	a.acks[msg.EventType] = err
+	return nil
+}
+
 type RunnerSuite struct {
 	suite.Suite
 	obs *noop.Provider
@@ -72,6 +83,28 @@ func (s *RunnerSuite) TestStart_DispatchesMessages() {
 	s.Require().ElementsMatch([]string{"evt.a", "evt.b"}, handled)
 }
 
+func (s *RunnerSuite) TestStart_AcksEveryDispatch() {
+	boom := errors.New("boom")
+	msgCh := make(chan consumer.Message, 3)
+	msgCh <- consumer.Message{EventType: "evt.ok"}
+	msgCh <- consumer.Message{EventType: "evt.fail"}
+	msgCh <- consumer.Message{EventType: "evt.unknown"}
+	close(msgCh)
+
+	src := &ackingSource{mockSource: mockSource{msgCh: msgCh}, acks: map[string]error{}}
+	r, err := consumer.NewRunner("test", src, []consumer.Registration{
+		{Name: "ok", EventType: "evt.ok", Handler: consumer.HandlerFunc(func(context.Context, consumer.Message) error { return nil })},
+		{Name: "fail", EventType: "evt.fail", Handler: consumer.HandlerFunc(func(context.Context, consumer.Message) error { return boom })},
+	}, s.obs)
+	s.Require().NoError(err)
+	s.Require().NoError(r.Start(context.Background()))
+
+	s.Require().Len(src.acks, 3)
+	s.Require().NoError(src.acks["evt.ok"])
+	s.Require().ErrorIs(src.acks["evt.fail"], boom)
+	s.Require().Error(src.acks["evt.unknown"], "mensagens sem handler também são reportadas")
+}
+
 func (s *RunnerSuite) TestStop_CallsSourceStop() {
 	src := &mockSource{msgCh: make(chan consumer.Message)}
 	r, err := consumer.NewRunner("test", src, nil, s.obs)
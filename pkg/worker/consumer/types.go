@@ -23,6 +23,13 @@ type Source interface {
 	Stop(ctx context.Context) error
 }
 
+// Acknowledger is implemented by sources that need the outcome of every
+// message, such as queues that retry failed jobs. The runner calls Ack after
+// each dispatch with the error returned by the handler.
+type Acknowledger interface {
+	Ack(ctx context.Context, msg Message, err error) error
+}
+
 type Runner interface {
 	Start(ctx context.Context) error
 	Stop(ctx context.Context) error